@@ -33,6 +33,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/iam/policy"
 	"github.com/scriptburn/minio/pkg/madmin"
 )
 
@@ -795,6 +797,596 @@ func TestSetConfigHandler(t *testing.T) {
 	}
 }
 
+// TestBucketQuotaHandlers - test for set, get and remove bucket quota handlers.
+func TestBucketQuotaHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	bucketName := "mybucket"
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("Failed to make bucket %s - %v", bucketName, err)
+	}
+	defer globalBucketQuotaSys.Remove(bucketName)
+
+	quotaJSON := []byte(`{"quota": 1048576, "quotatype": "hard"}`)
+
+	testCases := []struct {
+		method             string
+		path               string
+		bucketName         string
+		data               []byte
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// No quota yet.
+		{http.MethodGet, "/get-bucket-quota", bucketName, nil, http.StatusNotFound},
+		// Test case - 2.
+		// Invalid quota.
+		{http.MethodPut, "/set-bucket-quota", bucketName, []byte(`{"quota": 0, "quotatype": "hard"}`), http.StatusBadRequest},
+		// Test case - 3.
+		// Non existent bucket.
+		{http.MethodPut, "/set-bucket-quota", "non-existent-bucket", quotaJSON, http.StatusNotFound},
+		// Test case - 4.
+		// Valid quota.
+		{http.MethodPut, "/set-bucket-quota", bucketName, quotaJSON, http.StatusOK},
+		// Test case - 5.
+		// Quota is returned.
+		{http.MethodGet, "/get-bucket-quota", bucketName, nil, http.StatusOK},
+		// Test case - 6.
+		// Quota is removed.
+		{http.MethodDelete, "/remove-bucket-quota", bucketName, nil, http.StatusOK},
+		// Test case - 7.
+		// Quota is gone.
+		{http.MethodDelete, "/remove-bucket-quota", bucketName, nil, http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucketName)
+
+		req, err := buildAdminRequest(queryVal, testCase.method, testCase.path,
+			int64(len(testCase.data)), bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct bucket quota request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`, body: %s", i+1, testCase.expectedRespStatus, rec.Code, rec.Body)
+		}
+
+		switch {
+		case testCase.method == http.MethodGet && rec.Code == http.StatusOK:
+			var q madmin.BucketQuota
+			if err = json.Unmarshal(rec.Body.Bytes(), &q); err != nil {
+				t.Fatalf("Test %d: Unable to unmarshal bucket quota %s", i+1, rec.Body)
+			}
+			if q.Quota != 1048576 || q.Type != madmin.HardQuota {
+				t.Fatalf("Test %d: Unexpected bucket quota %v", i+1, q)
+			}
+		case testCase.method == http.MethodPut && rec.Code == http.StatusOK:
+			if _, ok := globalBucketQuotaSys.Get(testCase.bucketName); !ok {
+				t.Fatalf("Test %d: Expected the bucket quota to be enforced", i+1)
+			}
+		case testCase.method == http.MethodDelete && rec.Code == http.StatusOK:
+			if _, ok := globalBucketQuotaSys.Get(testCase.bucketName); ok {
+				t.Fatalf("Test %d: Expected the bucket quota to be removed", i+1)
+			}
+		}
+	}
+}
+
+// TestNotificationTargetHandlers - test for list, set, test and remove notification target handlers.
+func TestNotificationTargetHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	eventCh := make(chan []byte, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		eventCh <- data
+	}))
+	defer webhook.Close()
+
+	cred := globalServerConfig.GetCredential()
+	targetID := event.TargetID{ID: "cache", Name: "webhook"}
+
+	// Executes notification target request and checks the response.
+	execRequest := func(method, path, targetType string, data []byte, expectedRespStatus int) *httptest.ResponseRecorder {
+		queryVal := url.Values{}
+		if targetType != "" {
+			queryVal.Set("type", targetType)
+			queryVal.Set("id", targetID.ID)
+		}
+		if data != nil {
+			var err error
+			if data, err = madmin.EncryptData(cred.SecretKey, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		req, err := buildAdminRequest(queryVal, method, path, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to construct notification target request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("%s %s: Expected the response status to be `%d`, but instead found `%d`, body: %s", method, path, expectedRespStatus, rec.Code, rec.Body)
+		}
+		return rec
+	}
+
+	// Waits for an event containing eventName delivered to the webhook.
+	waitEvent := func(eventName string) {
+		select {
+		case data := <-eventCh:
+			if !strings.Contains(string(data), eventName) {
+				t.Fatalf("Expected %s event, but instead found %s", eventName, data)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Timed out waiting for %s event", eventName)
+		}
+	}
+
+	webhookJSON := []byte(`{"enable": true, "endpoint": "` + webhook.URL + `"}`)
+	execRequest(http.MethodPut, "/set-notification-target", "unknown", webhookJSON, http.StatusBadRequest)
+	execRequest(http.MethodPut, "/set-notification-target", "webhook", []byte(`{"enable": true}`), http.StatusBadRequest)
+	execRequest(http.MethodPost, "/test-notification-target", "webhook", nil, http.StatusNotFound)
+
+	execRequest(http.MethodPut, "/set-notification-target", "webhook", webhookJSON, http.StatusOK)
+
+	// Events of buckets notifying the new target are delivered without
+	// restarting the server.
+	bucketName := "notification-bucket"
+	globalNotificationSys.AddRulesMap(bucketName, event.NewRulesMap([]event.Name{event.ObjectCreatedAll}, "*", targetID))
+	defer globalNotificationSys.RemoveNotification(bucketName)
+	sendObjectCreated := func() {
+		sendEvent(eventArgs{
+			EventName:  event.ObjectCreatedPut,
+			BucketName: bucketName,
+			Object:     ObjectInfo{Bucket: bucketName, Name: "object"},
+		})
+	}
+	sendObjectCreated()
+	waitEvent("s3:ObjectCreated:Put")
+
+	rec := execRequest(http.MethodGet, "/list-notification-targets", "", nil, http.StatusOK)
+	var targets []madmin.NotificationTarget
+	if err = json.Unmarshal(rec.Body.Bytes(), &targets); err != nil {
+		t.Fatalf("Unable to unmarshal notification targets %s", rec.Body)
+	}
+	// Disabled targets of the default config are listed too.
+	expectedTarget := madmin.NotificationTarget{Type: "webhook", ID: "cache", ARN: targetID.ToARN(globalServerConfig.GetRegion()).String(), Enabled: true, Online: true}
+	found := false
+	for _, target := range targets {
+		if target == expectedTarget {
+			found = true
+		} else if target.Enabled || target.Online {
+			t.Fatalf("Unexpected notification target %v", target)
+		}
+	}
+	if !found {
+		t.Fatalf("Expected notification target %v in %v", expectedTarget, targets)
+	}
+
+	execRequest(http.MethodPost, "/test-notification-target", "webhook", nil, http.StatusOK)
+	waitEvent("s3:TestEvent")
+
+	// Events are no longer delivered to a removed target.
+	execRequest(http.MethodDelete, "/remove-notification-target", "webhook", nil, http.StatusOK)
+	execRequest(http.MethodDelete, "/remove-notification-target", "webhook", nil, http.StatusNotFound)
+	sendObjectCreated()
+	select {
+	case data := <-eventCh:
+		t.Fatalf("Unexpected event delivered to removed target %s", data)
+	case <-time.After(time.Second):
+	}
+}
+
+// TestUserPolicyHandlers - test for multiple canned policies and inline policy of a user.
+func TestUserPolicyHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = globalIAMSys.SetUser("policyuser", madmin.UserInfo{SecretKey: "policyuser123", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+
+	inlinePolicy := []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/secret*"]}]}`)
+	userQuery := url.Values{"accessKey": {"policyuser"}}
+
+	testCases := []struct {
+		method             string
+		path               string
+		queryVal           url.Values
+		data               []byte
+		expectedRespStatus int
+		// Expected permissions after the request.
+		expectedAllowed map[string]bool
+	}{
+		// Test case - 1.
+		// Non existent policy.
+		{http.MethodPut, "/set-user-policy", url.Values{"accessKey": {"policyuser"}, "name": {"readonly,nopolicy"}}, nil, http.StatusNotFound,
+			map[string]bool{"myobject": false, "secretobject": false, "put": false}},
+		// Test case - 2.
+		// Multiple policies are set.
+		{http.MethodPut, "/set-user-policy", url.Values{"accessKey": {"policyuser"}, "name": {"readonly,writeonly"}}, nil, http.StatusOK,
+			map[string]bool{"myobject": true, "secretobject": true, "put": true}},
+		// Test case - 3.
+		// No inline policy yet.
+		{http.MethodGet, "/user-inline-policy", userQuery, nil, http.StatusNotFound, nil},
+		// Test case - 4.
+		// Explicit deny of inline policy wins.
+		{http.MethodPut, "/set-user-inline-policy", userQuery, inlinePolicy, http.StatusOK,
+			map[string]bool{"myobject": true, "secretobject": false, "put": true}},
+		// Test case - 5.
+		{http.MethodGet, "/user-inline-policy", userQuery, nil, http.StatusOK, nil},
+		// Test case - 6.
+		// Malformed inline policy.
+		{http.MethodPut, "/set-user-inline-policy", userQuery, []byte(`{"Statement": "foo"}`), http.StatusBadRequest, nil},
+		// Test case - 7.
+		{http.MethodDelete, "/remove-user-inline-policy", userQuery, nil, http.StatusOK,
+			map[string]bool{"myobject": true, "secretobject": true, "put": true}},
+		// Test case - 8.
+		{http.MethodDelete, "/remove-user-inline-policy", userQuery, nil, http.StatusNotFound, nil},
+		// Test case - 9.
+		// All policies are removed.
+		{http.MethodPut, "/set-user-policy", url.Values{"accessKey": {"policyuser"}, "name": {""}}, nil, http.StatusOK,
+			map[string]bool{"myobject": false, "secretobject": false, "put": false}},
+	}
+
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(testCase.queryVal, testCase.method, testCase.path,
+			int64(len(testCase.data)), bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct user policy request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`, body: %s", i+1, testCase.expectedRespStatus, rec.Code, rec.Body)
+		}
+
+		if testCase.method == http.MethodGet && rec.Code == http.StatusOK {
+			p, err := iampolicy.ParseConfig(rec.Body)
+			if err != nil || len(p.Statements) != 1 {
+				t.Fatalf("Test %d: Unexpected inline policy %v, %v", i+1, p, err)
+			}
+		}
+
+		// Policies are persisted.
+		if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, expected := range testCase.expectedAllowed {
+			args := iampolicy.Args{
+				AccountName: "policyuser",
+				Action:      iampolicy.GetObjectAction,
+				BucketName:  "mybucket",
+				ObjectName:  name,
+			}
+			if name == "put" {
+				args.Action = iampolicy.PutObjectAction
+				args.ObjectName = "myobject"
+			}
+			if allowed := globalIAMSys.IsAllowed(args); allowed != expected {
+				t.Fatalf("Test %d: Expected %v for %s, but got %v", i+1, expected, name, allowed)
+			}
+		}
+	}
+}
+
+// TestServiceAccountHandlers - test for service account handlers and permissions of service accounts.
+func TestServiceAccountHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	parentCred := auth.Credentials{AccessKey: "parentuser", SecretKey: "parentuser123"}
+	otherCred := auth.Credentials{AccessKey: "otheruser", SecretKey: "otheruser123"}
+	for _, cred := range []auth.Credentials{parentCred, otherCred} {
+		if err = globalIAMSys.SetUser(cred.AccessKey, madmin.UserInfo{SecretKey: cred.SecretKey, Status: madmin.AccountEnabled}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = globalIAMSys.SetUserPolicy(parentCred.AccessKey, []string{"readwrite"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Executes admin request signed with given credentials.
+	execAdminRequest := func(cred auth.Credentials, method, path string, queryVal url.Values, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, "/minio/admin/v1"+path+"?"+queryVal.Encode(),
+			int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	addServiceAccount := func(cred auth.Credentials, serviceAccountReq madmin.AddServiceAccountReq) (*httptest.ResponseRecorder, auth.Credentials) {
+		data, err := json.Marshal(serviceAccountReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := madmin.EncryptData(cred.SecretKey, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := execAdminRequest(cred, http.MethodPut, "/add-service-account", nil, edata)
+		if rec.Code != http.StatusOK {
+			return rec, auth.Credentials{}
+		}
+		data, err = madmin.DecryptData(cred.SecretKey, rec.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var serviceAccountResp madmin.AddServiceAccountResp
+		if err = json.Unmarshal(data, &serviceAccountResp); err != nil {
+			t.Fatal(err)
+		}
+		return rec, serviceAccountResp.Credentials
+	}
+
+	getObjectArgs := func(accountName string, action iampolicy.Action) iampolicy.Args {
+		return iampolicy.Args{
+			AccountName: accountName,
+			Action:      action,
+			BucketName:  "mybucket",
+			ObjectName:  "myobject",
+		}
+	}
+
+	sessionPolicy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`
+
+	// Parent user adds its service account without admin credentials.
+	rec, serviceCred := addServiceAccount(parentCred, madmin.AddServiceAccountReq{Policy: json.RawMessage(sessionPolicy)})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d, body: %s", rec.Code, rec.Body)
+	}
+
+	// Users cannot add service accounts of other users.
+	if rec, _ = addServiceAccount(otherCred, madmin.AddServiceAccountReq{Parent: parentCred.AccessKey}); rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected to fail with %d, but got %d", http.StatusForbidden, rec.Code)
+	}
+
+	// Admin adds service account of a user.
+	rec, adminServiceCred := addServiceAccount(globalServerConfig.GetCredential(), madmin.AddServiceAccountReq{Parent: parentCred.AccessKey})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d, body: %s", rec.Code, rec.Body)
+	}
+
+	cred, ok := globalIAMSys.GetUser(serviceCred.AccessKey)
+	if !ok || cred.SecretKey != serviceCred.SecretKey || cred.ParentUser != parentCred.AccessKey {
+		t.Fatalf("Unexpected service account %v", cred)
+	}
+
+	// Service accounts cannot manage service accounts.
+	if rec, _ = addServiceAccount(serviceCred, madmin.AddServiceAccountReq{}); rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected to fail with %d, but got %d", http.StatusForbidden, rec.Code)
+	}
+
+	rec = execAdminRequest(parentCred, http.MethodGet, "/list-service-accounts", nil, nil)
+	var listResp madmin.ListServiceAccountsResp
+	if err = json.Unmarshal(rec.Body.Bytes(), &listResp); err != nil {
+		t.Fatal(err)
+	}
+	if len(listResp.Accounts) != 2 {
+		t.Fatalf("Unexpected service accounts %v", listResp.Accounts)
+	}
+
+	// Service accounts are persisted.
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		accountName string
+		action      iampolicy.Action
+		expected    bool
+	}{
+		{serviceCred.AccessKey, iampolicy.GetObjectAction, true},
+		// Session policy restricts permissions of parent user.
+		{serviceCred.AccessKey, iampolicy.PutObjectAction, false},
+		{adminServiceCred.AccessKey, iampolicy.PutObjectAction, true},
+		{adminServiceCred.AccessKey, iampolicy.CreateBucketAction, true},
+	} {
+		if allowed := globalIAMSys.IsAllowed(getObjectArgs(testCase.accountName, testCase.action)); allowed != testCase.expected {
+			t.Fatalf("Expected %v for %s of %s, but got %v", testCase.expected, testCase.action, testCase.accountName, allowed)
+		}
+	}
+
+	// Service accounts cannot be managed as users.
+	queryVal := url.Values{"accessKey": {serviceCred.AccessKey}, "status": {string(madmin.AccountDisabled)}}
+	if rec = execAdminRequest(globalServerConfig.GetCredential(), http.MethodPut, "/set-user-status", queryVal, nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected to fail with %d, but got %d", http.StatusBadRequest, rec.Code)
+	}
+	if err = globalIAMSys.SetUser(serviceCred.AccessKey, madmin.UserInfo{SecretKey: "serviceuser123", Status: madmin.AccountEnabled}); err != errIAMActionNotAllowed {
+		t.Fatalf("Expected to fail with %v, but got %v", errIAMActionNotAllowed, err)
+	}
+	if cred, ok = globalIAMSys.GetUser(serviceCred.AccessKey); !ok || cred.SecretKey != serviceCred.SecretKey || cred.ParentUser != parentCred.AccessKey || !cred.IsValid() {
+		t.Fatalf("Unexpected service account %v", cred)
+	}
+
+	// Service accounts are revoked when parent user is disabled.
+	if err = globalIAMSys.SetUserStatus(parentCred.AccessKey, madmin.AccountDisabled); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = globalIAMSys.GetUser(serviceCred.AccessKey); ok {
+		t.Fatal("Expected service account of disabled user to be revoked")
+	}
+	if err = globalIAMSys.SetUserStatus(parentCred.AccessKey, madmin.AccountEnabled); err != nil {
+		t.Fatal(err)
+	}
+
+	queryVal = url.Values{"accessKey": {serviceCred.AccessKey}}
+	if rec = execAdminRequest(otherCred, http.MethodDelete, "/delete-service-account", queryVal, nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected to fail with %d, but got %d", http.StatusForbidden, rec.Code)
+	}
+	if rec = execAdminRequest(parentCred, http.MethodDelete, "/delete-service-account", queryVal, nil); rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d, body: %s", rec.Code, rec.Body)
+	}
+	if rec = execAdminRequest(parentCred, http.MethodDelete, "/delete-service-account", queryVal, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected to fail with %d, but got %d", http.StatusNotFound, rec.Code)
+	}
+
+	// Service accounts are removed with parent user.
+	if err = globalIAMSys.DeleteUser(parentCred.AccessKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = globalIAMSys.GetUser(adminServiceCred.AccessKey); ok {
+		t.Fatal("Expected service account of removed user to be removed")
+	}
+}
+
+// TestGroupHandlers - test for group management handlers and group policies.
+func TestGroupHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = globalIAMSys.SetUser("groupuser", madmin.UserInfo{SecretKey: "groupuser123", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method             string
+		path               string
+		queryVal           url.Values
+		data               []byte
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Group is created with its member.
+		{http.MethodPut, "/update-group-members", nil, []byte(`{"group": "devs", "members": ["groupuser"]}`), http.StatusOK},
+		// Test case - 2.
+		// Non existent user.
+		{http.MethodPut, "/update-group-members", nil, []byte(`{"group": "devs", "members": ["nouser"]}`), http.StatusNotFound},
+		// Test case - 3.
+		// Non existent policy.
+		{http.MethodPut, "/set-group-policy", url.Values{"group": {"devs"}, "name": {"nopolicy"}}, nil, http.StatusNotFound},
+		// Test case - 4.
+		// Non existent group.
+		{http.MethodPut, "/set-group-policy", url.Values{"group": {"nogroup"}, "name": {"readonly"}}, nil, http.StatusNotFound},
+		// Test case - 5.
+		// Multiple policies are attached.
+		{http.MethodPut, "/set-group-policy", url.Values{"group": {"devs"}, "name": {"readonly,writeonly"}}, nil, http.StatusOK},
+		// Test case - 6.
+		{http.MethodGet, "/group-info", url.Values{"group": {"devs"}}, nil, http.StatusOK},
+		// Test case - 7.
+		{http.MethodGet, "/list-groups", nil, nil, http.StatusOK},
+		// Test case - 8.
+		// Group with members cannot be removed.
+		{http.MethodDelete, "/remove-group", url.Values{"group": {"devs"}}, nil, http.StatusBadRequest},
+		// Test case - 9.
+		{http.MethodPut, "/update-group-members", nil, []byte(`{"group": "devs", "members": ["groupuser"], "isRemove": true}`), http.StatusOK},
+		// Test case - 10.
+		{http.MethodDelete, "/remove-group", url.Values{"group": {"devs"}}, nil, http.StatusOK},
+		// Test case - 11.
+		{http.MethodGet, "/group-info", url.Values{"group": {"devs"}}, nil, http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(testCase.queryVal, testCase.method, testCase.path,
+			int64(len(testCase.data)), bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct group request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`, body: %s", i+1, testCase.expectedRespStatus, rec.Code, rec.Body)
+		}
+
+		switch {
+		case testCase.path == "/group-info" && rec.Code == http.StatusOK:
+			var gdesc madmin.GroupDesc
+			if err = json.Unmarshal(rec.Body.Bytes(), &gdesc); err != nil {
+				t.Fatalf("Test %d: Unable to unmarshal group description %s", i+1, rec.Body)
+			}
+			if gdesc.Name != "devs" || strings.Join(gdesc.Members, ",") != "groupuser" ||
+				strings.Join(gdesc.Policies, ",") != "readonly,writeonly" {
+				t.Fatalf("Test %d: Unexpected group description %v", i+1, gdesc)
+			}
+		case testCase.path == "/list-groups":
+			if rec.Body.String() != `["devs"]` {
+				t.Fatalf("Test %d: Unexpected groups %s", i+1, rec.Body)
+			}
+		case testCase.path == "/set-group-policy" && rec.Code == http.StatusOK:
+			// Group memberships and policies are persisted.
+			if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+				t.Fatal(err)
+			}
+			users, err := globalIAMSys.ListUsers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(users["groupuser"].MemberOf, ",") != "devs" {
+				t.Fatalf("Test %d: Unexpected groups of user %v", i+1, users["groupuser"])
+			}
+
+			// Permissions of user are union of policies of its groups.
+			for action, expected := range map[iampolicy.Action]bool{
+				iampolicy.GetObjectAction:    true,
+				iampolicy.PutObjectAction:    true,
+				iampolicy.DeleteBucketAction: false,
+			} {
+				args := iampolicy.Args{
+					AccountName: "groupuser",
+					Action:      action,
+					BucketName:  "mybucket",
+					ObjectName:  "myobject",
+				}
+				if allowed := globalIAMSys.IsAllowed(args); allowed != expected {
+					t.Fatalf("Test %d: Expected %v for %s, but got %v", i+1, expected, action, allowed)
+				}
+			}
+		}
+	}
+
+	// Removed member has no group policies.
+	if globalIAMSys.IsAllowed(iampolicy.Args{AccountName: "groupuser", Action: iampolicy.GetObjectAction, BucketName: "mybucket"}) {
+		t.Fatal("Expected group policies to be detached from removed member")
+	}
+}
+
 func TestAdminServerInfo(t *testing.T) {
 	adminTestBed, err := prepareAdminXLTestBed()
 	if err != nil {
@@ -843,6 +1435,104 @@ func TestAdminServerInfo(t *testing.T) {
 	}
 }
 
+// TestAdminActionPolicy - tests admin APIs are allowed to IAM users
+// as per admin actions in their policies.
+func TestAdminActionPolicy(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+
+	opsPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:ServerInfo", "admin:Heal"]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	allAdminPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:*"]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetCannedPolicy("ops", *opsPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetCannedPolicy("alladmin", *allAdminPolicy); err != nil {
+		t.Fatal(err)
+	}
+
+	opsCred := auth.Credentials{AccessKey: "opsuser", SecretKey: "opsuser123"}
+	adminCred := auth.Credentials{AccessKey: "adminuser", SecretKey: "adminuser123"}
+	rwCred := auth.Credentials{AccessKey: "rwuser", SecretKey: "rwuser123"}
+	for cred, policyName := range map[auth.Credentials]string{opsCred: "ops", adminCred: "alladmin", rwCred: "readwrite"} {
+		if err = globalIAMSys.SetUser(cred.AccessKey, madmin.UserInfo{SecretKey: cred.SecretKey, Status: madmin.AccountEnabled}); err != nil {
+			t.Fatal(err)
+		}
+		if err = globalIAMSys.SetUserPolicy(cred.AccessKey, []string{policyName}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		cred               auth.Credentials
+		method             string
+		path               string
+		data               []byte
+		expectedStatusCode int
+	}{
+		{opsCred, http.MethodGet, "/info", nil, http.StatusOK},
+		{opsCred, http.MethodGet, "/service", nil, http.StatusOK},
+		{opsCred, http.MethodGet, "/config", nil, http.StatusForbidden},
+		{opsCred, http.MethodGet, "/list-users", nil, http.StatusForbidden},
+		{opsCred, http.MethodPost, "/service", []byte(`{"action": "stop"}`), http.StatusForbidden},
+		{adminCred, http.MethodGet, "/list-users", nil, http.StatusOK},
+		// Admin credentials are updated only with admin credentials.
+		{adminCred, http.MethodPut, "/config/credential", []byte("{}"), http.StatusForbidden},
+		// s3:* does not allow admin APIs.
+		{rwCred, http.MethodGet, "/info", nil, http.StatusForbidden},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newTestRequest(testCase.method, "/minio/admin/v1"+testCase.path,
+			int64(len(testCase.data)), bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = signRequestV4(req, testCase.cred.AccessKey, testCase.cred.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatusCode {
+			t.Errorf("case %d: expected status %d, got %d", i+1, testCase.expectedStatusCode, rec.Code)
+		}
+	}
+
+	// Config is encrypted with the secret key of the requester.
+	req, err := newTestRequest(http.MethodGet, "/minio/admin/v1/config", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = signRequestV4(req, adminCred.AccessKey, adminCred.SecretKey); err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if _, err = madmin.DecryptData(adminCred.SecretKey, rec.Body); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestToAdminAPIErr - test for toAdminAPIErr helper function.
 func TestToAdminAPIErr(t *testing.T) {
 	testCases := []struct {
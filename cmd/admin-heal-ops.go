@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/event"
 	"github.com/scriptburn/minio/pkg/madmin"
 	"github.com/scriptburn/minio/pkg/sync/errgroup"
 )
@@ -781,6 +782,18 @@ func (h *healSequence) healObject(bucket, object string) error {
 	}
 	if err != nil {
 		hri.Detail = err.Error()
+	} else if before, after := hri.GetOnlineCounts(); !h.settings.DryRun && after > before {
+		// Notify object healed event when drives were healed.
+		sendEvent(eventArgs{
+			EventName:  event.ObjectHealed,
+			BucketName: bucket,
+			Object: ObjectInfo{
+				Bucket: bucket,
+				Name:   object,
+				Size:   hri.ObjectSize,
+			},
+			Host: "Internal: [HEAL]",
+		})
 	}
 	return h.pushHealResultItem(hri)
 }
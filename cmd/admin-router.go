@@ -99,6 +99,14 @@ func registerAdminRouter(router *mux.Router, enableIAM bool) {
 		adminV1Router.Methods(http.MethodPut).Path("/set-user-status").HandlerFunc(httpTraceHdrs(adminAPI.SetUserStatus)).
 			Queries("accessKey", "{accessKey:.*}").Queries("status", "{status:.*}")
 
+		// Inline policy of user IAM
+		adminV1Router.Methods(http.MethodPut).Path("/set-user-inline-policy").HandlerFunc(httpTraceHdrs(adminAPI.SetUserInlinePolicy)).
+			Queries("accessKey", "{accessKey:.*}")
+		adminV1Router.Methods(http.MethodGet).Path("/user-inline-policy").HandlerFunc(httpTraceHdrs(adminAPI.GetUserInlinePolicy)).
+			Queries("accessKey", "{accessKey:.*}")
+		adminV1Router.Methods(http.MethodDelete).Path("/remove-user-inline-policy").HandlerFunc(httpTraceHdrs(adminAPI.RemoveUserInlinePolicy)).
+			Queries("accessKey", "{accessKey:.*}")
+
 		// Remove policy IAM
 		adminV1Router.Methods(http.MethodDelete).Path("/remove-canned-policy").HandlerFunc(httpTraceHdrs(adminAPI.RemoveCannedPolicy)).Queries("name", "{name:.*}")
 
@@ -110,6 +118,53 @@ func registerAdminRouter(router *mux.Router, enableIAM bool) {
 
 		// List policies
 		adminV1Router.Methods(http.MethodGet).Path("/list-canned-policies").HandlerFunc(httpTraceHdrs(adminAPI.ListCannedPolicies))
+
+		// Add/Remove members of a group
+		adminV1Router.Methods(http.MethodPut).Path("/update-group-members").HandlerFunc(httpTraceHdrs(adminAPI.UpdateGroupMembers))
+		// Get group info
+		adminV1Router.Methods(http.MethodGet).Path("/group-info").HandlerFunc(httpTraceHdrs(adminAPI.GetGroup)).Queries("group", "{group:.*}")
+		// List groups
+		adminV1Router.Methods(http.MethodGet).Path("/list-groups").HandlerFunc(httpTraceHdrs(adminAPI.ListGroups))
+		// Remove group
+		adminV1Router.Methods(http.MethodDelete).Path("/remove-group").HandlerFunc(httpTraceHdrs(adminAPI.RemoveGroup)).Queries("group", "{group:.*}")
+		// Set policies of a group
+		adminV1Router.Methods(http.MethodPut).Path("/set-group-policy").HandlerFunc(httpTraceHdrs(adminAPI.SetGroupPolicy)).
+			Queries("group", "{group:.*}").Queries("name", "{name:.*}")
+
+		// Set policies of an LDAP user or group DN
+		adminV1Router.Methods(http.MethodPut).Path("/set-ldap-policy").HandlerFunc(httpTraceHdrs(adminAPI.SetLDAPPolicy)).
+			Queries("dn", "{dn:.*}").Queries("name", "{name:.*}")
+		// List policies of LDAP user and group DNs
+		adminV1Router.Methods(http.MethodGet).Path("/list-ldap-policies").HandlerFunc(httpTraceHdrs(adminAPI.ListLDAPPolicies))
+
+		// Service accounts
+		adminV1Router.Methods(http.MethodPut).Path("/add-service-account").HandlerFunc(httpTraceHdrs(adminAPI.AddServiceAccount))
+		adminV1Router.Methods(http.MethodGet).Path("/list-service-accounts").HandlerFunc(httpTraceHdrs(adminAPI.ListServiceAccounts))
+		adminV1Router.Methods(http.MethodDelete).Path("/delete-service-account").HandlerFunc(httpTraceHdrs(adminAPI.DeleteServiceAccount)).
+			Queries("accessKey", "{accessKey:.*}")
+
+		// -- Bucket quota APIs --
+
+		// Set bucket quota
+		adminV1Router.Methods(http.MethodPut).Path("/set-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.SetBucketQuota)).Queries("bucket", "{bucket:.*}")
+		// Get bucket quota
+		adminV1Router.Methods(http.MethodGet).Path("/get-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetBucketQuota)).Queries("bucket", "{bucket:.*}")
+		// Remove bucket quota
+		adminV1Router.Methods(http.MethodDelete).Path("/remove-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.RemoveBucketQuota)).Queries("bucket", "{bucket:.*}")
+
+		// -- Notification target APIs --
+
+		// List notification targets
+		adminV1Router.Methods(http.MethodGet).Path("/list-notification-targets").HandlerFunc(httpTraceHdrs(adminAPI.ListNotificationTargets))
+		// Add or update notification target
+		adminV1Router.Methods(http.MethodPut).Path("/set-notification-target").HandlerFunc(httpTraceHdrs(adminAPI.SetNotificationTarget)).
+			Queries("type", "{type:.*}").Queries("id", "{id:.*}")
+		// Send test event to notification target
+		adminV1Router.Methods(http.MethodPost).Path("/test-notification-target").HandlerFunc(httpTraceHdrs(adminAPI.TestNotificationTarget)).
+			Queries("type", "{type:.*}").Queries("id", "{id:.*}")
+		// Remove notification target
+		adminV1Router.Methods(http.MethodDelete).Path("/remove-notification-target").HandlerFunc(httpTraceHdrs(adminAPI.RemoveNotificationTarget)).
+			Queries("type", "{type:.*}").Queries("id", "{id:.*}")
 	}
 
 	// If none of the routes match, return error.
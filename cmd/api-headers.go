@@ -94,6 +94,14 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 			// values to client.
 			continue
 		}
+		if k == amzObjectTagging {
+			// Only the number of tags is sent, tags are
+			// returned by GetObjectTagging.
+			if tags, err := getObjectTags(objInfo); err == nil {
+				w.Header().Set(amzTaggingCount, strconv.Itoa(len(tags.TagSet.Tags)))
+			}
+			continue
+		}
 		w.Header().Set(k, v)
 	}
 
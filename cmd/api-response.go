@@ -70,6 +70,59 @@ type ListObjectsResponse struct {
 	EncodingType string `xml:"EncodingType,omitempty"`
 }
 
+// ListVersionsResponse - format for list object versions response.
+type ListVersionsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult" json:"-"`
+
+	Name            string
+	Prefix          string
+	KeyMarker       string
+	VersionIDMarker string `xml:"VersionIdMarker"`
+
+	// When response is truncated (the IsTruncated element value in the response
+	// is true), you can use these values as key-marker and version-id-marker in
+	// the subsequent request to get the next set of object versions.
+	NextKeyMarker       string `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string `xml:"NextVersionIdMarker,omitempty"`
+
+	MaxKeys   int
+	Delimiter string `xml:"Delimiter,omitempty"`
+	// A flag that indicates whether or not ListObjectVersions returned all of the
+	// results that satisfied the search criteria.
+	IsTruncated bool
+
+	Versions       []ObjectVersion `xml:"Version"`
+	DeleteMarkers  []DeleteMarker  `xml:"DeleteMarker"`
+	CommonPrefixes []CommonPrefix
+}
+
+// ObjectVersion container for an object version in ListVersionsResponse
+type ObjectVersion struct {
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+	ETag         string
+	Size         int64
+
+	// Owner of the object.
+	Owner Owner
+
+	// The class of storage used to store the object.
+	StorageClass string
+}
+
+// DeleteMarker container for a delete marker in ListVersionsResponse
+type DeleteMarker struct {
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+
+	// Owner of the delete marker.
+	Owner Owner
+}
+
 // ListObjectsV2Response - format for list objects response.
 type ListObjectsV2Response struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult" json:"-"`
@@ -413,6 +466,69 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 	return data
 }
 
+// generates an ListObjectVersions response for the said bucket with other enumerated options.
+func generateListVersionsResponse(bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
+	var versions []ObjectVersion
+	var deleteMarkers []DeleteMarker
+	var prefixes []CommonPrefix
+	var owner = Owner{}
+	var data = ListVersionsResponse{}
+
+	owner.ID = globalMinioDefaultOwnerID
+	for _, object := range resp.Objects {
+		if object.Name == "" {
+			continue
+		}
+		versionID := object.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		lastModified := object.ModTime.UTC().Format(timeFormatAMZLong)
+		if object.DeleteMarker {
+			deleteMarkers = append(deleteMarkers, DeleteMarker{
+				Key:          object.Name,
+				VersionID:    versionID,
+				IsLatest:     object.IsLatest,
+				LastModified: lastModified,
+				Owner:        owner,
+			})
+			continue
+		}
+		var content = ObjectVersion{}
+		content.Key = object.Name
+		content.VersionID = versionID
+		content.IsLatest = object.IsLatest
+		content.LastModified = lastModified
+		if object.ETag != "" {
+			content.ETag = "\"" + object.ETag + "\""
+		}
+		content.Size = object.Size
+		content.StorageClass = object.StorageClass
+		content.Owner = owner
+		versions = append(versions, content)
+	}
+	data.Name = bucket
+	data.Versions = versions
+	data.DeleteMarkers = deleteMarkers
+
+	data.Prefix = prefix
+	data.KeyMarker = keyMarker
+	data.VersionIDMarker = versionIDMarker
+	data.Delimiter = delimiter
+	data.MaxKeys = maxKeys
+
+	data.NextKeyMarker = resp.NextKeyMarker
+	data.NextVersionIDMarker = resp.NextVersionIDMarker
+	data.IsTruncated = resp.IsTruncated
+	for _, prefix := range resp.Prefixes {
+		var prefixItem = CommonPrefix{}
+		prefixItem.Prefix = prefix
+		prefixes = append(prefixes, prefixItem)
+	}
+	data.CommonPrefixes = prefixes
+	return data
+}
+
 // generates CopyObjectResponse from etag and lastModified time.
 func generateCopyObjectResponse(etag string, lastModified time.Time) CopyObjectResponse {
 	return CopyObjectResponse{
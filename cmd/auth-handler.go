@@ -117,33 +117,73 @@ func getRequestAuthType(r *http.Request) authType {
 	return authTypeUnknown
 }
 
-// checkAdminRequestAuthType checks whether the request is a valid signature V2 or V4 request.
+// checkAdminRequestAuthType checks whether the request is a valid signature V4 request
+// of admin credentials, or of IAM user credentials allowed the admin action.
 // It does not accept presigned or JWT or anonymous requests.
-func checkAdminRequestAuthType(ctx context.Context, r *http.Request, region string) APIErrorCode {
+func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iampolicy.Action, region string) APIErrorCode {
+	_, s3Err := checkAdminRequestAuthTypeCredentials(ctx, r, action, region)
+	return s3Err
+}
+
+// checkAdminRequestAuthTypeCredentials is same as checkAdminRequestAuthType
+// but also returns credentials of the request, for admin APIs which encrypt
+// data with the secret key of the requester.
+func checkAdminRequestAuthTypeCredentials(ctx context.Context, r *http.Request, action iampolicy.Action, region string) (auth.Credentials, APIErrorCode) {
+	cred, owner, s3Err := validateAdminSignature(ctx, r, region)
+	if s3Err != ErrNone {
+		return cred, s3Err
+	}
+
+	return cred, checkAdminActionAllowed(r, cred, owner, action)
+}
+
+// checkAdminActionAllowed checks whether credentials of a validated admin
+// request are allowed the admin action, admin credentials are allowed all
+// admin actions.
+func checkAdminActionAllowed(r *http.Request, cred auth.Credentials, owner bool, action iampolicy.Action) APIErrorCode {
+	if owner {
+		return ErrNone
+	}
+
+	if globalIAMSys != nil && globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          action,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         false,
+		Claims:          mustGetClaimsFromToken(r),
+	}) {
+		return ErrNone
+	}
+	return ErrAccessDenied
+}
+
+// validateAdminSignature validates the signature of admin request of
+// admin or IAM user credentials, returns credentials of the request and
+// whether they are admin credentials.
+func validateAdminSignature(ctx context.Context, r *http.Request, region string) (auth.Credentials, bool, APIErrorCode) {
+	var cred auth.Credentials
+	var owner bool
 	s3Err := ErrAccessDenied
 	if _, ok := r.Header["X-Amz-Content-Sha256"]; ok &&
 		getRequestAuthType(r) == authTypeSigned && !skipContentSha256Cksum(r) {
-		// We only support admin credentials to access admin APIs.
-
-		var owner bool
-		_, owner, s3Err = getReqAccessKeyV4(r, region)
+		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 		if s3Err != ErrNone {
-			return s3Err
-		}
-
-		if !owner {
-			return ErrAccessDenied
+			return cred, owner, s3Err
 		}
 
 		// we only support V4 (no presign) with auth body
-		s3Err = isReqAuthenticated(ctx, r, region)
+		s3Err = isReqAuthenticated(ctx, r, region, serviceS3)
+		if s3Err == ErrNone && !owner {
+			// Session token of temporary credentials is validated too.
+			_, s3Err = checkClaimsFromToken(r, cred)
+		}
 	}
 	if s3Err != ErrNone {
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
 		ctx := logger.SetReqInfo(ctx, reqInfo)
 		logger.LogIf(ctx, errors.New(getAPIError(s3Err).Description))
 	}
-	return s3Err
+	return cred, owner, s3Err
 }
 
 // Fetch the security token set by the client.
@@ -219,20 +259,16 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 	return claims, ErrNone
 }
 
-// Check request auth type verifies the incoming http request
-// - validates the request signature
-// - validates the policy action if anonymous tests bucket policies if any,
-//   for authenticated requests validates IAM policies.
-// returns APIErrorCode if any to be replied to the client.
-func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (s3Err APIErrorCode) {
-	var cred auth.Credentials
-	var owner bool
+// authenticateRequest - validates the request signature and session token
+// claims, returns the credentials of the request and whether they are of
+// the owner. Anonymous requests return empty credentials.
+func authenticateRequest(ctx context.Context, r *http.Request, action policy.Action) (cred auth.Credentials, owner bool, claims map[string]interface{}, s3Err APIErrorCode) {
 	switch getRequestAuthType(r) {
 	case authTypeUnknown, authTypeStreamingSigned:
-		return ErrAccessDenied
+		return cred, owner, nil, ErrAccessDenied
 	case authTypePresignedV2, authTypeSignedV2:
 		if s3Err = isReqAuthenticatedV2(r); s3Err != ErrNone {
-			return s3Err
+			return cred, owner, nil, s3Err
 		}
 		cred, owner, s3Err = getReqAccessKeyV2(r)
 	case authTypeSigned, authTypePresigned:
@@ -241,16 +277,26 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		case policy.GetBucketLocationAction, policy.ListAllMyBucketsAction:
 			region = ""
 		}
-		if s3Err = isReqAuthenticated(ctx, r, region); s3Err != ErrNone {
-			return s3Err
+		if s3Err = isReqAuthenticated(ctx, r, region, serviceS3); s3Err != ErrNone {
+			return cred, owner, nil, s3Err
 		}
-		cred, owner, s3Err = getReqAccessKeyV4(r, region)
+		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 	}
 	if s3Err != ErrNone {
-		return s3Err
+		return cred, owner, nil, s3Err
 	}
 
-	claims, s3Err := checkClaimsFromToken(r, cred)
+	claims, s3Err = checkClaimsFromToken(r, cred)
+	return cred, owner, claims, s3Err
+}
+
+// Check request auth type verifies the incoming http request
+// - validates the request signature
+// - validates the policy action if anonymous tests bucket policies if any,
+//   for authenticated requests validates IAM policies.
+// returns APIErrorCode if any to be replied to the client.
+func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (s3Err APIErrorCode) {
+	cred, owner, claims, s3Err := authenticateRequest(ctx, r, action)
 	if s3Err != ErrNone {
 		return s3Err
 	}
@@ -278,12 +324,25 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
 	}
 
+	// Tags of the object are needed only to evaluate the policies of
+	// non-owners, the owner is always allowed.
+	var tagValues map[string][]string
+	if !owner {
+		if tagValues, s3Err = getObjectTagConditionValues(ctx, r, action, bucketName, objectName); s3Err != ErrNone {
+			return s3Err
+		}
+	}
+
 	if cred.AccessKey == "" {
+		conditionValues := getConditionValues(r, locationConstraint)
+		for key, values := range tagValues {
+			conditionValues[key] = values
+		}
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, locationConstraint),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -292,11 +351,15 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		return ErrAccessDenied
 	}
 
+	conditionValues := getConditionValues(r, "")
+	for key, values := range tagValues {
+		conditionValues[key] = values
+	}
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, ""),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
@@ -314,21 +377,21 @@ func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 	return doesPresignV2SignatureMatch(r)
 }
 
-func reqSignatureV4Verify(r *http.Request, region string) (s3Error APIErrorCode) {
-	sha256sum := getContentSha256Cksum(r)
+func reqSignatureV4Verify(r *http.Request, region string, stype serviceType) (s3Error APIErrorCode) {
+	sha256sum := getContentSha256Cksum(r, stype)
 	switch {
 	case isRequestSignatureV4(r):
-		return doesSignatureMatch(sha256sum, r, region)
+		return doesSignatureMatch(sha256sum, r, region, stype)
 	case isRequestPresignedSignatureV4(r):
-		return doesPresignedSignatureMatch(sha256sum, r, region)
+		return doesPresignedSignatureMatch(sha256sum, r, region, stype)
 	default:
 		return ErrAccessDenied
 	}
 }
 
 // Verify if request has valid AWS Signature Version '4'.
-func isReqAuthenticated(ctx context.Context, r *http.Request, region string) (s3Error APIErrorCode) {
-	if errCode := reqSignatureV4Verify(r, region); errCode != ErrNone {
+func isReqAuthenticated(ctx context.Context, r *http.Request, region string, stype serviceType) (s3Error APIErrorCode) {
+	if errCode := reqSignatureV4Verify(r, region, stype); errCode != ErrNone {
 		return errCode
 	}
 
@@ -419,10 +482,10 @@ func (a authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	writeErrorResponse(w, ErrSignatureVersionNotSupported, r.URL, guessIsBrowserReq(r))
 }
 
-// isPutAllowed - check if PUT operation is allowed on the resource, this
+// isPutActionAllowed - check if PUT operation is allowed on the resource, this
 // call verifies bucket policies and IAM policies, supports multi user
 // checks etc.
-func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request) (s3Err APIErrorCode) {
+func isPutActionAllowed(atype authType, bucketName, objectName string, r *http.Request, action policy.Action) (s3Err APIErrorCode) {
 	var cred auth.Credentials
 	var owner bool
 	switch atype {
@@ -432,7 +495,7 @@ func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request
 		cred, owner, s3Err = getReqAccessKeyV2(r)
 	case authTypeStreamingSigned, authTypePresigned, authTypeSigned:
 		region := globalServerConfig.GetRegion()
-		cred, owner, s3Err = getReqAccessKeyV4(r, region)
+		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 	}
 	if s3Err != ErrNone {
 		return s3Err
@@ -443,12 +506,23 @@ func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request
 		return s3Err
 	}
 
+	conditionValues := getConditionValues(r, "")
+	if !owner {
+		tagValues, s3Err := getObjectTagConditionValues(context.Background(), r, action, bucketName, objectName)
+		if s3Err != ErrNone {
+			return s3Err
+		}
+		for key, values := range tagValues {
+			conditionValues[key] = values
+		}
+	}
+
 	if cred.AccessKey == "" {
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
-			Action:          policy.PutObjectAction,
+			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, ""),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -459,9 +533,9 @@ func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request
 
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
-		Action:          policy.PutObjectAction,
+		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, ""),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
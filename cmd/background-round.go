@@ -0,0 +1,62 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/scriptburn/minio/cmd/logger"
+)
+
+// claimBackgroundRound - returns true if the last round of a background
+// task ran more than interval ago, the round is then recorded in
+// timeFile as started by this node. The time is shared by all nodes so
+// that a round runs once per interval in the cluster and is not delayed
+// by server restarts. The lock is only held while deciding, not during
+// the round.
+func claimBackgroundRound(ctx context.Context, objAPI ObjectLayer, lockFile, timeFile string, interval time.Duration) bool {
+	// Skip this round if another node is deciding.
+	roundLock := globalNSMutex.NewNSLock(minioMetaBucket, lockFile)
+	if err := roundLock.GetLock(newDynamicTimeout(time.Second, time.Second)); err != nil {
+		return false
+	}
+	defer roundLock.Unlock()
+
+	now := UTCNow()
+	data, err := readConfig(ctx, objAPI, timeFile)
+	switch err {
+	case nil:
+		var lastRound time.Time
+		if lastRound.UnmarshalText(data) == nil && now.Sub(lastRound) < interval {
+			return false
+		}
+	case errConfigNotFound:
+	default:
+		return false
+	}
+
+	if data, err = now.MarshalText(); err != nil {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	if err = saveConfig(ctx, objAPI, timeFile, data); err != nil {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	return true
+}
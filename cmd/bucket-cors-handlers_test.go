@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/cors"
+)
+
+// Wrapper for calling bucket CORS HTTP handler tests for both XL multiple disks and single node setup.
+func TestBucketCorsHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"PutBucketCors", "GetBucketCors", "DeleteBucketCors", "GetObject"})
+}
+
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalBucketCORSSys.Remove(bucketName)
+
+	// Executes CORS configuration request and checks the response.
+	execCORSRequest := func(method string, data []byte, expectedRespStatus int, expectedErrCode string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketCORSURL("", bucketName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("%s %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, method, expectedRespStatus, rec.Code)
+		}
+		if expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("%s %s: Unable to unmarshal response body %s", instanceType, method, rec.Body.String())
+			}
+			if errorResponse.Code != expectedErrCode {
+				t.Fatalf("%s %s: Expected the error code to be `%s`, but instead found `%s`", instanceType, method, expectedErrCode, errorResponse.Code)
+			}
+		}
+		return rec
+	}
+
+	objectName := "object"
+	data := []byte("hello")
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to put object: %v", instanceType, err)
+	}
+
+	// Executes GetObject request from origin through the CORS handler of
+	// the server.
+	corsRouter := setCorsHandler(apiRouter)
+	execGetObject := func(origin string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", getGetObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{"Origin": origin})
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		corsRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected GetObject to succeed, but found status `%d`", instanceType, rec.Code)
+		}
+		return rec
+	}
+
+	execCORSRequest("GET", nil, http.StatusNotFound, "NoSuchCORSConfiguration")
+	execCORSRequest("PUT", []byte(`<CORSConfiguration></CORSConfiguration>`), http.StatusBadRequest, "MalformedXML")
+
+	corsData := []byte(`<CORSConfiguration><CORSRule><ID>app</ID><AllowedOrigin>https://app.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`)
+	execCORSRequest("PUT", corsData, http.StatusOK, "")
+
+	rec := execCORSRequest("GET", nil, http.StatusOK, "")
+	config, err := cors.ParseCORSConfig(rec.Body)
+	if err != nil {
+		t.Fatalf("%s: Unable to parse CORS configuration: %v", instanceType, err)
+	}
+	if len(config.Rules) != 1 || config.Rules[0].ID != "app" {
+		t.Fatalf("%s: Unexpected CORS configuration %v", instanceType, config)
+	}
+
+	// Requests of allowed origins get CORS headers, others do not.
+	if origin := execGetObject("https://app.example.com").Header().Get("Access-Control-Allow-Origin"); origin != "https://app.example.com" {
+		t.Fatalf("%s: Expected allowed origin in CORS headers, found `%s`", instanceType, origin)
+	}
+	if origin := execGetObject("https://evil.example.com").Header().Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("%s: Expected no CORS headers for other origins, found `%s`", instanceType, origin)
+	}
+
+	// Preflight requests are answered by the allowed methods.
+	rec = httptest.NewRecorder()
+	req, err := http.NewRequest("OPTIONS", getGetObjectURL("", bucketName, objectName), nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+	}
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	corsRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Methods") != "GET, PUT" || rec.Header().Get("Access-Control-Max-Age") != "3000" {
+		t.Fatalf("%s: Unexpected preflight response `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+
+	// Removed CORS configuration no longer applies.
+	execCORSRequest("DELETE", nil, http.StatusNoContent, "")
+	execCORSRequest("GET", nil, http.StatusNotFound, "NoSuchCORSConfiguration")
+	if origin := execGetObject("https://app.example.com").Header().Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("%s: Expected no CORS headers after removing CORS configuration, found `%s`", instanceType, origin)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
+)
+
+const (
+	// Bucket CORS configuration file.
+	bucketCORSConfig = "cors.xml"
+)
+
+// BucketCORSSys - bucket CORS subsystem.
+type BucketCORSSys struct {
+	sync.RWMutex
+	bucketCORSMap map[string]cors.Config
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the CORS
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification
+// we should delete the corresponding CORS configuration during sys.refresh()
+func (sys *BucketCORSSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketCORSMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketCORSMap, bucket)
+		}
+	}
+}
+
+// Set - sets CORS configuration to given bucket name.
+func (sys *BucketCORSSys) Set(bucketName string, config cors.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketCORSMap[bucketName] = config
+}
+
+// Get - returns CORS configuration of given bucket name.
+func (sys *BucketCORSSys) Get(bucketName string) (config cors.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketCORSMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes CORS configuration for given bucket name.
+func (sys *BucketCORSSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketCORSMap, bucketName)
+}
+
+// Refresh BucketCORSSys.
+func (sys *BucketCORSSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketCORS(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketCORSNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes CORS system from cors.xml of all buckets.
+func (sys *BucketCORSSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketCORSSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing CORS needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketCORSSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for CORS subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketCORSSys - creates new CORS system.
+func NewBucketCORSSys() *BucketCORSSys {
+	return &BucketCORSSys{
+		bucketCORSMap: make(map[string]cors.Config),
+	}
+}
+
+func getCORSConfig(objAPI ObjectLayer, bucketName string) (*cors.Config, error) {
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCORSConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketCORSNotFound{Bucket: bucketName}
+		}
+
+		return nil, err
+	}
+
+	return cors.ParseCORSConfig(bytes.NewReader(configData))
+}
+
+func saveCORSConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *cors.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCORSConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeCORSConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCORSConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketCORSNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scriptburn/minio/cmd/crypto"
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/sse"
+)
+
+// Wrapper for calling bucket encryption HTTP handler tests for both XL multiple disks and single node setup.
+func TestBucketEncryptionHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketEncryptionHandlers, []string{"PutBucketEncryption", "GetBucketEncryption", "DeleteBucketEncryption", "PutObject", "GetObject"})
+}
+
+func testBucketEncryptionHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	kms := GlobalKMS
+	defer func() { GlobalKMS = kms }()
+	defer globalBucketSSEConfigSys.Remove(bucketName)
+
+	// Executes request with credentials and checks the response.
+	execRequest := func(method, urlStr string, data []byte, expectedRespStatus int, expectedErrCode string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, urlStr,
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("%s %s %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, method, urlStr, expectedRespStatus, rec.Code)
+		}
+		if expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("%s %s %s: Unable to unmarshal response body %s", instanceType, method, urlStr, rec.Body.String())
+			}
+			if errorResponse.Code != expectedErrCode {
+				t.Fatalf("%s %s %s: Expected the error code to be `%s`, but instead found `%s`", instanceType, method, urlStr, expectedErrCode, errorResponse.Code)
+			}
+		}
+		return rec
+	}
+
+	// Uploads object through the API and returns its metadata.
+	putObject := func(objectName string) map[string]string {
+		execRequest("PUT", getPutObjectURL("", bucketName, objectName), []byte("hello"), http.StatusOK, "")
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to get object info of %s: %v", instanceType, objectName, err)
+		}
+		return objInfo.UserDefined
+	}
+
+	encryptionURL := getBucketEncryptionURL("", bucketName)
+	sseData := []byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)
+
+	// KMS is not configured.
+	GlobalKMS = nil
+	execRequest("PUT", encryptionURL, sseData, http.StatusBadRequest, "InvalidArgument")
+
+	GlobalKMS = crypto.NewKMS([32]byte{})
+	execRequest("GET", encryptionURL, nil, http.StatusNotFound, "ServerSideEncryptionConfigurationNotFoundError")
+	execRequest("PUT", encryptionURL, []byte(`<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`), http.StatusBadRequest, "MalformedXML")
+	execRequest("PUT", encryptionURL, sseData, http.StatusOK, "")
+
+	rec := execRequest("GET", encryptionURL, nil, http.StatusOK, "")
+	config, err := sse.ParseBucketSSEConfig(rec.Body)
+	if err != nil {
+		t.Fatalf("%s: Unable to parse encryption configuration: %v", instanceType, err)
+	}
+	if config.Algorithm() != sse.AWSKms || config.KeyID() != "my-key" {
+		t.Fatalf("%s: Unexpected encryption configuration %v", instanceType, config)
+	}
+
+	// Uploads without encryption headers are encrypted with the
+	// configured key and are decrypted on download.
+	metadata := putObject("encrypted")
+	if !crypto.S3.IsEncrypted(metadata) || metadata[crypto.S3KMSKeyID] != "my-key" {
+		t.Fatalf("%s: Expected object to be encrypted with `my-key`, found metadata %v", instanceType, metadata)
+	}
+	rec = execRequest("GET", getGetObjectURL("", bucketName, "encrypted"), nil, http.StatusOK, "")
+	if rec.Body.String() != "hello" || rec.Header().Get(crypto.SSEHeader) != crypto.SSEAlgorithmAES256 {
+		t.Fatalf("%s: Unexpected encrypted object download `%s` %v", instanceType, rec.Body.String(), rec.Header())
+	}
+
+	// Uploads are no longer encrypted once the configuration is removed.
+	execRequest("DELETE", encryptionURL, nil, http.StatusNoContent, "")
+	execRequest("GET", encryptionURL, nil, http.StatusNotFound, "ServerSideEncryptionConfigurationNotFoundError")
+	if metadata = putObject("plain"); crypto.IsEncrypted(metadata) {
+		t.Fatalf("%s: Expected object not to be encrypted after removing the configuration, found metadata %v", instanceType, metadata)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/crypto"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/sse"
+)
+
+const (
+	// Bucket server side encryption configuration file.
+	bucketSSEConfig = "bucket-encryption.xml"
+)
+
+// BucketSSEConfigSys - bucket server side encryption configuration subsystem.
+type BucketSSEConfigSys struct {
+	sync.RWMutex
+	bucketSSEConfigMap map[string]sse.BucketSSEConfig
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the encryption
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification
+// we should delete the corresponding encryption configuration during sys.refresh()
+func (sys *BucketSSEConfigSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketSSEConfigMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketSSEConfigMap, bucket)
+		}
+	}
+}
+
+// Set - sets encryption configuration to given bucket name.
+func (sys *BucketSSEConfigSys) Set(bucketName string, config sse.BucketSSEConfig) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketSSEConfigMap[bucketName] = config
+}
+
+// Get - returns encryption configuration of given bucket name.
+func (sys *BucketSSEConfigSys) Get(bucketName string) (config sse.BucketSSEConfig, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketSSEConfigMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes encryption configuration for given bucket name.
+func (sys *BucketSSEConfigSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketSSEConfigMap, bucketName)
+}
+
+// Refresh BucketSSEConfigSys.
+func (sys *BucketSSEConfigSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketSSEConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketSSEConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes bucket encryption configuration system from bucket-encryption.xml of all buckets.
+func (sys *BucketSSEConfigSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketSSEConfigSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing encryption configuration needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketSSEConfigSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for bucket encryption subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketSSEConfigSys - creates new bucket encryption configuration system.
+func NewBucketSSEConfigSys() *BucketSSEConfigSys {
+	return &BucketSSEConfigSys{
+		bucketSSEConfigMap: make(map[string]sse.BucketSSEConfig),
+	}
+}
+
+// setBucketEncryptionHeader - requests SSE-S3 for uploads to a bucket
+// with a default encryption configuration unless the client requested
+// server side encryption itself, so the object is encrypted exactly as
+// if the client had sent the header.
+func setBucketEncryptionHeader(h http.Header, bucket string) {
+	if crypto.SSEC.IsRequested(h) || crypto.S3.IsRequested(h) {
+		return
+	}
+
+	if _, ok := globalBucketSSEConfigSys.Get(bucket); ok {
+		h.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	}
+}
+
+// getBucketKMSKeyID - returns the KMS key ID used to generate the keys
+// of new SSE-S3 objects in the bucket.
+func getBucketKMSKeyID(bucket string) string {
+	if config, ok := globalBucketSSEConfigSys.Get(bucket); ok {
+		if keyID := config.KeyID(); keyID != "" {
+			return keyID
+		}
+	}
+	return globalKMSKeyID
+}
+
+func getBucketSSEConfig(objAPI ObjectLayer, bucketName string) (*sse.BucketSSEConfig, error) {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+
+		return nil, err
+	}
+
+	return sse.ParseBucketSSEConfig(bytes.NewReader(configData))
+}
+
+func saveBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *sse.BucketSSEConfig) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
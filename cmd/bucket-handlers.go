@@ -323,6 +323,9 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	}
 
 	var dErrs = make([]error, len(deleteObjects.Objects))
+	// Removals on a bucket with versioning configured are notified
+	// by deleteObjectVersion().
+	var notified = make([]bool, len(deleteObjects.Objects))
 	for index, object := range deleteObjects.Objects {
 		// If the request is denied access, each item
 		// should be marked as 'AccessDenied'
@@ -333,23 +336,50 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			}
 			continue
 		}
-		dErrs[index] = deleteObject(ctx, bucket, object.ObjectName)
+
+		var opts ObjectOptions
+		setVersioningOpts(&opts, bucket, "")
+
+		// Objects protected by object lock are reported as not deleted.
+		if err := enforceObjectLock(ctx, objectAPI, r, bucket, object.ObjectName, opts); err != nil {
+			dErrs[index] = err
+			continue
+		}
+
+		// Deleting on a bucket with versioning configured places
+		// a delete marker as DeleteObject API does.
+		if opts.Versioned || opts.VersionSuspended {
+			_, dErrs[index] = deleteObjectVersion(ctx, objectAPI, bucket, object.ObjectName, opts, r)
+			notified[index] = true
+			continue
+		}
+		size := getQuotaObjectSize(ctx, objectAPI, bucket, object.ObjectName, opts)
+		if dErrs[index] = deleteObject(ctx, bucket, object.ObjectName); dErrs[index] == nil {
+			freeBucketUsage(bucket, size)
+		}
 	}
 
 	// Collect deleted objects and errors if any.
 	var deletedObjects []ObjectIdentifier
+	var notifyObjects []ObjectIdentifier
 	var deleteErrors []DeleteError
 	for index, err := range dErrs {
 		object := deleteObjects.Objects[index]
 		// Success deleted objects are collected separately.
 		if err == nil {
 			deletedObjects = append(deletedObjects, object)
+			if !notified[index] {
+				notifyObjects = append(notifyObjects, object)
+			}
 			continue
 		}
 		if _, ok := err.(ObjectNotFound); ok {
 			// If the object is not found it should be
 			// accounted as deleted as per S3 spec.
 			deletedObjects = append(deletedObjects, object)
+			if !notified[index] {
+				notifyObjects = append(notifyObjects, object)
+			}
 			continue
 		}
 		// Error during delete should be collected separately.
@@ -375,7 +405,8 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	}
 
 	// Notify deleted event for objects.
-	for _, dobj := range deletedObjects {
+	for _, dobj := range notifyObjects {
+		replicateDeleteAsync(bucket, dobj.ObjectName)
 		sendEvent(eventArgs{
 			EventName:  event.ObjectRemovedDelete,
 			BucketName: bucket,
@@ -391,6 +422,21 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	}
 }
 
+// sendBucketEvent - notifies bucket created or removed event of request.
+func sendBucketEvent(eventName event.Name, bucket string, r *http.Request) {
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       host,
+		Port:       port,
+	})
+}
+
 // PutBucketHandler - PUT Bucket
 // ----------
 // This implementation of the PUT operation creates a new bucket for authenticated request
@@ -445,6 +491,9 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 				w.Header().Set("Location", getObjectLocation(r, globalDomainName, bucket, ""))
 
 				writeSuccessResponseHeadersOnly(w)
+
+				// Notify bucket created event.
+				sendBucketEvent(event.BucketCreated, bucket, r)
 				return
 			}
 			writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -466,6 +515,9 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 	w.Header().Set("Location", path.Clean(r.URL.Path)) // Clean any trailing slashes.
 
 	writeSuccessResponseHeadersOnly(w)
+
+	// Notify bucket created event.
+	sendBucketEvent(event.BucketCreated, bucket, r)
 }
 
 // PostPolicyBucketHandler - POST policy
@@ -617,6 +669,12 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	if s3Error := setObjectLockMetadata(r, bucket, object, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setReplicationStatus(metadata, formValues, bucket, object)
+
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -631,6 +689,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	setBucketEncryptionHeader(r.Header, bucket)
+	setBucketEncryptionHeader(formValues, bucket)
 	// get gateway encryption options
 	var opts ObjectOptions
 	opts, err = putEncryptionOpts(ctx, r, bucket, object, nil)
@@ -638,6 +698,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket, "")
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(formValues) && !hasSuffix(object, slashSeparator) { // handle SSE-C and SSE-S3 requests
 			var reader io.Reader
@@ -664,6 +725,19 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		}
 	}
 
+	// Deny overwriting an object protected by object lock.
+	if err = enforceObjectLock(ctx, objectAPI, r, bucket, object, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Deny writing beyond the hard quota of the bucket.
+	replacedSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, opts)
+	if err = enforceBucketQuota(bucket, fileSize, replacedSize); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	objInfo, err := objectAPI.PutObject(ctx, bucket, object, pReader, metadata, opts)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -673,6 +747,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	location := getObjectLocation(r, globalDomainName, bucket, object)
 	w.Header().Set("ETag", `"`+objInfo.ETag+`"`)
 	w.Header().Set("Location", location)
+	setVersionHeaders(w, objInfo)
+
+	updateBucketUsage(bucket, objInfo, replacedSize)
+	replicateObjectAsync(bucket, objInfo)
 
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
@@ -785,6 +863,13 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 
 	globalNotificationSys.RemoveNotification(bucket)
 	globalPolicySys.Remove(bucket)
+	globalBucketVersioningSys.Remove(bucket)
+	globalLifecycleSys.Remove(bucket)
+	globalBucketObjectLockSys.Remove(bucket)
+	globalBucketCORSSys.Remove(bucket)
+	globalBucketSSEConfigSys.Remove(bucket)
+	globalBucketReplicationSys.Remove(bucket)
+	globalBucketQuotaSys.Remove(bucket)
 	globalNotificationSys.DeleteBucket(ctx, bucket)
 
 	if globalDNSConfig != nil {
@@ -798,4 +883,7 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 
 	// Write success response.
 	writeSuccessNoContent(w)
+
+	// Notify bucket removed event.
+	sendBucketEvent(event.BucketRemoved, bucket, r)
 }
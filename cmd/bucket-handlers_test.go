@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/versioning"
 )
 
 // Wrapper for calling GetBucketPolicy HTTP handler tests for both XL multiple disks and single node setup.
@@ -793,3 +794,62 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 	// `ExecObjectLayerAPINilTest` manages the operation.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling DeleteMultipleObjects HTTP handler tests on a bucket with versioning enabled.
+func TestAPIDeleteMultipleObjectsVersionedHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIDeleteMultipleObjectsVersionedHandler, []string{"DeleteMultipleObjects"})
+}
+
+func testAPIDeleteMultipleObjectsVersionedHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	if !obj.IsVersioningSupported() {
+		return
+	}
+
+	globalBucketVersioningSys.Set(bucketName, versioning.Versioning{Status: versioning.Enabled})
+	defer globalBucketVersioningSys.Remove(bucketName)
+
+	ctx := context.Background()
+	contentBytes := []byte("hello")
+	objectNames := []string{"test-object-1", "test-object-2"}
+	var objectIdentifierList []ObjectIdentifier
+	for i, objectName := range objectNames {
+		_, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewBuffer(contentBytes), int64(len(contentBytes)), "", ""), nil, ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatalf("Put Object %d:  Error uploading object: <ERROR> %v", i, err)
+		}
+		objectIdentifierList = append(objectIdentifierList, ObjectIdentifier{objectName})
+	}
+
+	deleteRequest := encodeResponse(DeleteObjectsRequest{Objects: objectIdentifierList})
+	req, err := newTestSignedRequestV4("POST", getDeleteMultipleObjectsURL("", bucketName),
+		int64(len(deleteRequest)), bytes.NewReader(deleteRequest), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for DeleteMultipleObjects: <ERROR> %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	// Every object is hidden by a delete marker and its data is kept
+	// as a noncurrent version.
+	for _, objectName := range objectNames {
+		if _, err = obj.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{}); err == nil {
+			t.Fatalf("Minio %s: Expected object %s to be hidden by a delete marker", instanceType, objectName)
+		}
+
+		result, err := obj.ListObjectVersions(ctx, bucketName, objectName, "", "", "", 1000)
+		if err != nil {
+			t.Fatalf("Minio %s: %v", instanceType, err)
+		}
+		if len(result.Objects) != 2 {
+			t.Fatalf("Minio %s: Expected 2 versions of %s, got %d", instanceType, objectName, len(result.Objects))
+		}
+		if !result.Objects[0].DeleteMarker || result.Objects[1].DeleteMarker || result.Objects[1].Size != int64(len(contentBytes)) {
+			t.Fatalf("Minio %s: Expected a delete marker on top of the object version, got %#v", instanceType, result.Objects)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+)
+
+// Wrapper for calling bucket lifecycle HTTP handler tests for both XL multiple disks and single node setup.
+func TestBucketLifecycleHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLifecycleHandlers, []string{"PutBucketLifecycle", "GetBucketLifecycle", "DeleteBucketLifecycle"})
+}
+
+func testBucketLifecycleHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalLifecycleSys.Remove(bucketName)
+
+	// Executes lifecycle configuration request and checks the response.
+	execLifecycleRequest := func(method string, data []byte, expectedRespStatus int, expectedErrCode string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, getBucketLifecycleURL("", bucketName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("%s %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, method, expectedRespStatus, rec.Code)
+		}
+		if expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("%s %s: Unable to unmarshal response body %s", instanceType, method, rec.Body.String())
+			}
+			if errorResponse.Code != expectedErrCode {
+				t.Fatalf("%s %s: Expected the error code to be `%s`, but instead found `%s`", instanceType, method, expectedErrCode, errorResponse.Code)
+			}
+		}
+		return rec
+	}
+
+	putObject := func(object string) {
+		data := []byte("hello")
+		if _, err := obj.PutObject(context.Background(), bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: Failed to put object %s: %v", instanceType, object, err)
+		}
+	}
+
+	// Checks whether object is still present after a lifecycle round.
+	isExpired := func(object string) bool {
+		lifecycleRound(context.Background(), obj)
+		_, err := obj.GetObjectInfo(context.Background(), bucketName, object, ObjectOptions{})
+		if _, ok := err.(ObjectNotFound); ok {
+			return true
+		}
+		if err != nil {
+			t.Fatalf("%s: Failed to get object info of %s: %v", instanceType, object, err)
+		}
+		return false
+	}
+
+	putObject("logs/a")
+	putObject("data/a")
+
+	execLifecycleRequest("GET", nil, http.StatusNotFound, "NoSuchLifecycleConfiguration")
+	execLifecycleRequest("PUT", []byte(`<LifecycleConfiguration></LifecycleConfiguration>`), http.StatusBadRequest, "MalformedXML")
+
+	lifecycleData := []byte(`<LifecycleConfiguration><Rule><ID>logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Date>2019-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`)
+	execLifecycleRequest("PUT", lifecycleData, http.StatusOK, "")
+
+	// Objects matching the rule are expired, others are kept.
+	if !isExpired("logs/a") {
+		t.Fatalf("%s: Expected logs/a to be expired", instanceType)
+	}
+	if isExpired("data/a") {
+		t.Fatalf("%s: Expected data/a not to be expired", instanceType)
+	}
+
+	rec := execLifecycleRequest("GET", nil, http.StatusOK, "")
+	lc, err := lifecycle.ParseLifecycleConfig(rec.Body)
+	if err != nil {
+		t.Fatalf("%s: Unable to parse lifecycle configuration: %v", instanceType, err)
+	}
+	if len(lc.Rules) != 1 || lc.Rules[0].ID != "logs" {
+		t.Fatalf("%s: Unexpected lifecycle configuration %v", instanceType, lc)
+	}
+
+	// Objects are no longer expired once the configuration is removed.
+	execLifecycleRequest("DELETE", nil, http.StatusNoContent, "")
+	execLifecycleRequest("GET", nil, http.StatusNotFound, "NoSuchLifecycleConfiguration")
+	putObject("logs/b")
+	if isExpired("logs/b") {
+		t.Fatalf("%s: Expected logs/b not to be expired after removing lifecycle configuration", instanceType)
+	}
+}
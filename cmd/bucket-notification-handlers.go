@@ -17,15 +17,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/gorilla/mux"
 	"github.com/scriptburn/minio/cmd/logger"
 	"github.com/scriptburn/minio/pkg/event"
 	"github.com/scriptburn/minio/pkg/event/target"
+	"github.com/scriptburn/minio/pkg/iam/policy"
 	xnet "github.com/scriptburn/minio/pkg/net"
 	"github.com/scriptburn/minio/pkg/policy"
 )
@@ -189,16 +192,72 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
-	values := r.URL.Query()
+	eventNames, pattern, s3Error := parseListenNotificationQuery(ctx, r.URL.Query())
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, err := objAPI.GetBucketInfo(ctx, bucketName); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	host, err := xnet.ParseHost(r.RemoteAddr)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	target, err := target.NewHTTPClientTarget(*host, w)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	rulesMap := event.NewRulesMap(eventNames, pattern, target.ID())
+
+	if err = globalNotificationSys.AddRemoteTarget(bucketName, target, rulesMap); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer globalNotificationSys.RemoveRemoteTarget(bucketName, target.ID())
+	defer globalNotificationSys.RemoveRulesMap(bucketName, rulesMap)
+
+	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = SaveListener(objAPI, bucketName, eventNames, pattern, target.ID(), *thisAddr); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, pattern, target.ID(), *thisAddr)
+
+	<-target.DoneCh
+
+	if err = RemoveListener(objAPI, bucketName, target.ID(), *thisAddr); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+}
 
+// parseListenNotificationQuery - parses events, prefix and suffix query
+// parameters of listen notification requests.
+func parseListenNotificationQuery(ctx context.Context, values url.Values) (eventNames []event.Name, pattern string, s3Error APIErrorCode) {
 	var prefix string
 	if len(values["prefix"]) > 1 {
-		writeErrorResponse(w, ErrFilterNamePrefix, r.URL, guessIsBrowserReq(r))
+		return nil, "", ErrFilterNamePrefix
 	}
 	if len(values["prefix"]) == 1 {
 		if err := event.ValidateFilterRuleValue(values["prefix"][0]); err != nil {
-			writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
+			return nil, "", toAPIErrorCode(ctx, err)
 		}
 
 		prefix = values["prefix"][0]
@@ -206,56 +265,128 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 
 	var suffix string
 	if len(values["suffix"]) > 1 {
-		writeErrorResponse(w, ErrFilterNameSuffix, r.URL, guessIsBrowserReq(r))
+		return nil, "", ErrFilterNameSuffix
 	}
 	if len(values["suffix"]) == 1 {
 		if err := event.ValidateFilterRuleValue(values["suffix"][0]); err != nil {
-			writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
+			return nil, "", toAPIErrorCode(ctx, err)
 		}
 
 		suffix = values["suffix"][0]
 	}
 
-	pattern := event.NewPattern(prefix, suffix)
-
-	eventNames := []event.Name{}
+	eventNames = []event.Name{}
 	for _, s := range values["events"] {
 		eventName, err := event.ParseName(s)
 		if err != nil {
-			writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
+			return nil, "", toAPIErrorCode(ctx, err)
 		}
 
 		eventNames = append(eventNames, eventName)
 	}
 
-	if _, err := objAPI.GetBucketInfo(ctx, bucketName); err != nil {
-		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+	return eventNames, event.NewPattern(prefix, suffix), ErrNone
+}
+
+// listenNotificationTarget - HTTP client target of server wide listeners,
+// skips events of buckets the listener is not allowed to listen on.
+type listenNotificationTarget struct {
+	*target.HTTPClientTarget
+	isAllowed func(bucketName string) bool
+}
+
+// Send - sends event to HTTP client if it is allowed to listen on the bucket.
+func (target listenNotificationTarget) Send(eventData event.Event) error {
+	if !target.isAllowed(eventData.S3.Bucket.Name) {
+		return nil
+	}
+
+	return target.HTTPClientTarget.Send(eventData)
+}
+
+// ListenNotificationHandler - This HTTP handler sends events to the
+// connected HTTP client of all buckets it is allowed to listen on. Client
+// should send prefix/suffix object name to match and events to watch as
+// query parameters.
+func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListenNotification")
+
+	defer logger.AuditLog(w, r, "ListenNotification", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if !objAPI.IsNotificationSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	if !objAPI.IsListenBucketSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	cred, owner, claims, s3Error := authenticateRequest(ctx, r, policy.ListenBucketNotificationAction)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	eventNames, pattern, s3Error := parseListenNotificationQuery(ctx, r.URL.Query())
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Policies are evaluated for every event, hence changes to policies
+	// apply to the running listener.
+	conditionValues := getConditionValues(r, "")
+	isAllowed := func(bucketName string) bool {
+		if cred.AccessKey == "" {
+			return globalPolicySys.IsAllowed(policy.Args{
+				Action:          policy.ListenBucketNotificationAction,
+				BucketName:      bucketName,
+				ConditionValues: conditionValues,
+				IsOwner:         false,
+			})
+		}
+
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          iampolicy.ListenBucketNotificationAction,
+			BucketName:      bucketName,
+			ConditionValues: conditionValues,
+			IsOwner:         owner,
+			Claims:          claims,
+		})
+	}
+
 	host, err := xnet.ParseHost(r.RemoteAddr)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	target, err := target.NewHTTPClientTarget(*host, w)
+	httpTarget, err := target.NewHTTPClientTarget(*host, w)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	target := listenNotificationTarget{httpTarget, isAllowed}
+
+	// Server wide listeners are registered on empty bucket name.
 	rulesMap := event.NewRulesMap(eventNames, pattern, target.ID())
 
-	if err = globalNotificationSys.AddRemoteTarget(bucketName, target, rulesMap); err != nil {
+	if err = globalNotificationSys.AddRemoteTarget("", target, rulesMap); err != nil {
 		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
-	defer globalNotificationSys.RemoveRemoteTarget(bucketName, target.ID())
-	defer globalNotificationSys.RemoveRulesMap(bucketName, rulesMap)
+	defer globalNotificationSys.RemoveRemoteTarget("", target.ID())
+	defer globalNotificationSys.RemoveRulesMap("", rulesMap)
 
 	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
 	if err != nil {
@@ -263,17 +394,17 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
-	if err = SaveListener(objAPI, bucketName, eventNames, pattern, target.ID(), *thisAddr); err != nil {
+	if err = SaveListener(objAPI, "", eventNames, pattern, target.ID(), *thisAddr); err != nil {
 		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, pattern, target.ID(), *thisAddr)
+	globalNotificationSys.ListenBucketNotification(ctx, "", eventNames, pattern, target.ID(), *thisAddr)
 
-	<-target.DoneCh
+	<-httpTarget.DoneCh
 
-	if err = RemoveListener(objAPI, bucketName, target.ID(), *thisAddr); err != nil {
+	if err = RemoveListener(objAPI, "", target.ID(), *thisAddr); err != nil {
 		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -0,0 +1,388 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/objectlock"
+	"github.com/scriptburn/minio/pkg/policy"
+)
+
+const (
+	// Object lock configuration, retention and legal hold documents fit
+	// comfortably in this limit.
+	maxObjectLockConfigSize = 1 * humanize.MiByte
+)
+
+// PutBucketObjectLockConfigHandler - This HTTP handler enables object lock
+// on a bucket and stores its default retention as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTObjectLockConfiguration.html
+// Object lock cannot be disabled once enabled.
+func (api objectAPIHandlers) PutBucketObjectLockConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketObjectLockConfig")
+
+	defer logger.AuditLog(w, r, "PutBucketObjectLockConfig", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketObjectLockConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutBucketObjectLockConfig always needs Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if r.ContentLength > maxObjectLockConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := objectlock.ParseObjectLockConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = objAPI.SetBucketObjectLockConfig(ctx, bucket, config); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	globalBucketObjectLockSys.Set(bucket, *config)
+	globalNotificationSys.SetBucketObjectLockConfig(ctx, bucket, config)
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketObjectLockConfigHandler - This HTTP handler returns the object
+// lock configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETObjectLockConfiguration.html
+func (api objectAPIHandlers) GetBucketObjectLockConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketObjectLockConfig")
+
+	defer logger.AuditLog(w, r, "GetBucketObjectLockConfig", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketObjectLockConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := objAPI.GetBucketObjectLockConfig(ctx, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// Write object lock configuration to client.
+	writeSuccessResponseXML(w, encodeResponse(config))
+}
+
+// PutObjectRetentionHandler - This HTTP handler sets the retention of an
+// object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTRetention.html
+func (api objectAPIHandlers) PutObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectRetention")
+
+	defer logger.AuditLog(w, r, "PutObjectRetention", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectRetentionAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, ok := globalBucketObjectLockSys.Get(bucket); !ok {
+		writeErrorResponse(w, ErrObjectLockConfigurationMissing, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutObjectRetention always needs Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if r.ContentLength > maxObjectLockConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	retention, err := objectlock.ParseObjectRetention(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if retention.Mode != "" && !retention.RetainUntilDate.After(UTCNow()) {
+		writeErrorResponse(w, ErrPastObjectLockRetainDate, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	opts := ObjectOptions{VersionID: versionID}
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = checkObjectRetentionUpdate(r, objInfo, *retention); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// An empty retention removes the retention of the object.
+	metadata := map[string]string{
+		amzObjectLockMode:            string(retention.Mode),
+		amzObjectLockRetainUntilDate: "",
+	}
+	if retention.Mode != "" {
+		metadata[amzObjectLockRetainUntilDate] = retention.RetainUntilDate.UTC().Format(time.RFC3339)
+	}
+
+	if err = objAPI.UpdateObjectMetadata(ctx, bucket, object, metadata, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetObjectRetentionHandler - This HTTP handler returns the retention of
+// an object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETRetention.html
+func (api objectAPIHandlers) GetObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectRetention")
+
+	defer logger.AuditLog(w, r, "GetObjectRetention", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectRetentionAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	retention := getObjectRetention(objInfo.UserDefined)
+	if !retention.Mode.IsValid() {
+		writeErrorResponse(w, ErrNoSuchObjectLockConfiguration, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	retention.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Write object retention to client.
+	writeSuccessResponseXML(w, encodeResponse(retention))
+}
+
+// PutObjectLegalHoldHandler - This HTTP handler sets the legal hold of an
+// object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTLegalHold.html
+func (api objectAPIHandlers) PutObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectLegalHold")
+
+	defer logger.AuditLog(w, r, "PutObjectLegalHold", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectLegalHoldAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, ok := globalBucketObjectLockSys.Get(bucket); !ok {
+		writeErrorResponse(w, ErrObjectLockConfigurationMissing, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutObjectLegalHold always needs Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if r.ContentLength > maxObjectLockConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	legalHold, err := objectlock.ParseObjectLegalHold(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	metadata := map[string]string{amzObjectLockLegalHold: string(legalHold.Status)}
+	opts := ObjectOptions{VersionID: versionID}
+	if err = objAPI.UpdateObjectMetadata(ctx, bucket, object, metadata, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetObjectLegalHoldHandler - This HTTP handler returns the legal hold of
+// an object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETLegalHold.html
+func (api objectAPIHandlers) GetObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectLegalHold")
+
+	defer logger.AuditLog(w, r, "GetObjectLegalHold", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectLegalHoldAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	legalHold := getObjectLegalHold(objInfo.UserDefined)
+	if !legalHold.Status.IsValid() {
+		writeErrorResponse(w, ErrNoSuchObjectLockConfiguration, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	legalHold.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Write object legal hold to client.
+	writeSuccessResponseXML(w, encodeResponse(legalHold))
+}
@@ -0,0 +1,143 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scriptburn/minio/pkg/auth"
+)
+
+// Wrapper for calling object lock HTTP handler tests for both XL multiple disks and single node setup.
+func TestObjectLockHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectLockHandlers, []string{
+		"PutBucketObjectLockConfig", "GetBucketObjectLockConfig",
+		"PutObjectRetention", "GetObjectRetention",
+		"PutObjectLegalHold", "GetObjectLegalHold",
+		"PutObject", "DeleteObject",
+	})
+}
+
+func testObjectLockHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	configData := []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`)
+	retainUntil := UTCNow().Add(48 * time.Hour).Format(time.RFC3339)
+	complianceData := []byte(fmt.Sprintf(`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>%s</RetainUntilDate></Retention>`, retainUntil))
+	shortRetainUntil := UTCNow().Add(time.Hour).Format(time.RFC3339)
+	governanceData := []byte(fmt.Sprintf(`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>%s</RetainUntilDate></Retention>`, shortRetainUntil))
+	shortenData := []byte(fmt.Sprintf(`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>%s</RetainUntilDate></Retention>`, shortRetainUntil))
+	bypass := map[string]string{amzBypassGovernanceRetention: "true"}
+
+	testCases := []struct {
+		method             string
+		url                string
+		data               []byte
+		headers            map[string]string
+		expectedRespStatus int
+		expectedErrCode    string
+	}{
+		// Test case - 1.
+		// No object lock configuration yet.
+		{"GET", getBucketObjectLockConfigURL("", bucketName), nil, nil, http.StatusNotFound, "ObjectLockConfigurationNotFoundError"},
+		// Test case - 2.
+		// Retention headers need an object lock configuration.
+		{"PUT", getPutObjectURL("", bucketName, "governed"), []byte("hello"),
+			map[string]string{amzObjectLockLegalHold: "ON"}, http.StatusBadRequest, "InvalidRequest"},
+		// Test case - 3.
+		// Malformed object lock configuration.
+		{"PUT", getBucketObjectLockConfigURL("", bucketName), []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Disabled</ObjectLockEnabled></ObjectLockConfiguration>`),
+			nil, http.StatusBadRequest, "MalformedXML"},
+		// Test case - 4.
+		// Valid object lock configuration.
+		{"PUT", getBucketObjectLockConfigURL("", bucketName), configData, nil, http.StatusOK, ""},
+		// Test case - 5.
+		// Object lock configuration is returned.
+		{"GET", getBucketObjectLockConfigURL("", bucketName), nil, nil, http.StatusOK, ""},
+		// Test case - 6.
+		// Object gets the default GOVERNANCE retention.
+		{"PUT", getPutObjectURL("", bucketName, "governed"), []byte("hello"), nil, http.StatusOK, ""},
+		// Test case - 7.
+		// Object retention is returned.
+		{"GET", getObjectRetentionURL("", bucketName, "governed"), nil, nil, http.StatusOK, ""},
+		// Test case - 8.
+		// Retained object cannot be overwritten.
+		{"PUT", getPutObjectURL("", bucketName, "governed"), []byte("world"), nil, http.StatusForbidden, "AccessDenied"},
+		// Test case - 9.
+		// Retained object cannot be removed.
+		{"DELETE", getDeleteObjectURL("", bucketName, "governed"), nil, nil, http.StatusForbidden, "AccessDenied"},
+		// Test case - 10.
+		// GOVERNANCE retention is bypassed.
+		{"DELETE", getDeleteObjectURL("", bucketName, "governed"), nil, bypass, http.StatusNoContent, ""},
+		// Test case - 11.
+		// Object with a legal hold.
+		{"PUT", getPutObjectURL("", bucketName, "held"), []byte("hello"),
+			map[string]string{amzObjectLockLegalHold: "ON"}, http.StatusOK, ""},
+		// Test case - 12.
+		// Legal hold cannot be bypassed.
+		{"DELETE", getDeleteObjectURL("", bucketName, "held"), nil, bypass, http.StatusForbidden, "AccessDenied"},
+		// Test case - 13.
+		// Invalid legal hold status.
+		{"PUT", getObjectLegalHoldURL("", bucketName, "held"), []byte(`<LegalHold><Status>on</Status></LegalHold>`), nil, http.StatusBadRequest, "MalformedXML"},
+		// Test case - 14.
+		// Legal hold is released.
+		{"PUT", getObjectLegalHoldURL("", bucketName, "held"), []byte(`<LegalHold><Status>OFF</Status></LegalHold>`), nil, http.StatusOK, ""},
+		// Test case - 15.
+		// Legal hold is returned.
+		{"GET", getObjectLegalHoldURL("", bucketName, "held"), nil, nil, http.StatusOK, ""},
+		// Test case - 16.
+		// Shortening GOVERNANCE retention needs the bypass.
+		{"PUT", getObjectRetentionURL("", bucketName, "held"), governanceData, nil, http.StatusForbidden, "AccessDenied"},
+		// Test case - 17.
+		// GOVERNANCE retention is extended into COMPLIANCE retention.
+		{"PUT", getObjectRetentionURL("", bucketName, "held"), complianceData, nil, http.StatusOK, ""},
+		// Test case - 18.
+		// COMPLIANCE retention cannot be shortened.
+		{"PUT", getObjectRetentionURL("", bucketName, "held"), shortenData, bypass, http.StatusForbidden, "AccessDenied"},
+		// Test case - 19.
+		// COMPLIANCE retention cannot be bypassed.
+		{"DELETE", getDeleteObjectURL("", bucketName, "held"), nil, bypass, http.StatusForbidden, "AccessDenied"},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, testCase.url, int64(len(testCase.data)),
+			bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		if testCase.expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, rec.Body.String())
+			}
+			if errorResponse.Code != testCase.expectedErrCode {
+				t.Fatalf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedErrCode, errorResponse.Code)
+			}
+		}
+	}
+}
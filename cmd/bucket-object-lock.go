@@ -0,0 +1,400 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/objectlock"
+	"github.com/scriptburn/minio/pkg/policy"
+)
+
+const (
+	// Bucket object lock configuration file.
+	bucketObjectLockConfig = "object-lock.xml"
+
+	// Request headers and metadata keys carrying the retention and the
+	// legal hold of an object, they are sent back as response headers
+	// along with the user defined metadata.
+	amzObjectLockMode            = "X-Amz-Object-Lock-Mode"
+	amzObjectLockRetainUntilDate = "X-Amz-Object-Lock-Retain-Until-Date"
+	amzObjectLockLegalHold       = "X-Amz-Object-Lock-Legal-Hold"
+
+	// Request header asking to bypass a GOVERNANCE mode retention.
+	amzBypassGovernanceRetention = "X-Amz-Bypass-Governance-Retention"
+)
+
+// BucketObjectLockSys - bucket object lock subsystem.
+type BucketObjectLockSys struct {
+	sync.RWMutex
+	bucketObjectLockMap map[string]objectlock.Config
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the object
+// lock configuration for a deleted bucket. i.e if we miss a delete-bucket
+// notification we should delete the corresponding configuration during sys.refresh()
+func (sys *BucketObjectLockSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketObjectLockMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketObjectLockMap, bucket)
+		}
+	}
+}
+
+// Set - sets object lock configuration to given bucket name.
+func (sys *BucketObjectLockSys) Set(bucketName string, config objectlock.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketObjectLockMap[bucketName] = config
+}
+
+// Get - returns object lock configuration of given bucket name, ok is
+// false if object lock is not enabled on the bucket.
+func (sys *BucketObjectLockSys) Get(bucketName string) (config objectlock.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketObjectLockMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes object lock configuration for given bucket name.
+func (sys *BucketObjectLockSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketObjectLockMap, bucketName)
+}
+
+// Refresh BucketObjectLockSys.
+func (sys *BucketObjectLockSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketObjectLockConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketObjectLockConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes object lock system from object-lock.xml of all buckets.
+func (sys *BucketObjectLockSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketObjectLockSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing object lock needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketObjectLockSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for object lock subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketObjectLockSys - creates new object lock system.
+func NewBucketObjectLockSys() *BucketObjectLockSys {
+	return &BucketObjectLockSys{
+		bucketObjectLockMap: make(map[string]objectlock.Config),
+	}
+}
+
+func getObjectLockConfig(objAPI ObjectLayer, bucketName string) (*objectlock.Config, error) {
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketObjectLockConfigNotFound{Bucket: bucketName}
+		}
+
+		return nil, err
+	}
+
+	return objectlock.ParseObjectLockConfig(bytes.NewReader(configData))
+}
+
+func saveObjectLockConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *objectlock.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeObjectLockConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketObjectLockConfigNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// getObjectRetention - returns the retention kept in the object metadata.
+func getObjectRetention(meta map[string]string) objectlock.Retention {
+	retention := objectlock.Retention{
+		Mode: objectlock.Mode(meta[amzObjectLockMode]),
+	}
+	if t, err := time.Parse(time.RFC3339, meta[amzObjectLockRetainUntilDate]); err == nil {
+		retention.RetainUntilDate = t
+	}
+	return retention
+}
+
+// getObjectLegalHold - returns the legal hold kept in the object metadata.
+func getObjectLegalHold(meta map[string]string) objectlock.LegalHold {
+	return objectlock.LegalHold{
+		Status: objectlock.LegalHoldStatus(meta[amzObjectLockLegalHold]),
+	}
+}
+
+// removeObjectLockMetadata - removes the retention and the legal hold
+// from the given metadata, a copied object does not inherit them.
+func removeObjectLockMetadata(meta map[string]string) {
+	delete(meta, amzObjectLockMode)
+	delete(meta, amzObjectLockRetainUntilDate)
+	delete(meta, amzObjectLockLegalHold)
+}
+
+// isObjectLocked - returns true if the object is under a legal hold or
+// an active retention period.
+func isObjectLocked(meta map[string]string) bool {
+	if getObjectLegalHold(meta).Status == objectlock.LegalHoldOn {
+		return true
+	}
+	return getObjectRetention(meta).IsActive(UTCNow())
+}
+
+// isGovernanceBypassAllowed - returns true if the request asks to bypass
+// GOVERNANCE mode retention and is allowed to do so.
+func isGovernanceBypassAllowed(r *http.Request, bucket, object string) bool {
+	if !strings.EqualFold(r.Header.Get(amzBypassGovernanceRetention), "true") {
+		return false
+	}
+	return isPutActionAllowed(getRequestAuthType(r), bucket, object, r, policy.BypassGovernanceRetentionAction) == ErrNone
+}
+
+// checkObjectLocked - returns ObjectLocked if the object may not be
+// removed or overwritten by the request. Legal holds and COMPLIANCE mode
+// retention protect an object from everyone, GOVERNANCE mode retention
+// can be bypassed with the s3:BypassGovernanceRetention permission.
+// Internal operations pass a nil request and never bypass retention.
+func checkObjectLocked(r *http.Request, objInfo ObjectInfo) error {
+	if !isObjectLocked(objInfo.UserDefined) {
+		return nil
+	}
+
+	if r != nil && getObjectLegalHold(objInfo.UserDefined).Status != objectlock.LegalHoldOn &&
+		getObjectRetention(objInfo.UserDefined).Mode == objectlock.Governance &&
+		isGovernanceBypassAllowed(r, objInfo.Bucket, objInfo.Name) {
+		return nil
+	}
+
+	return ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name}
+}
+
+// enforceObjectLock - returns ObjectLocked if a delete or a write with the
+// given options would remove a locked object version. Writes without a
+// version ID on a versioning enabled bucket keep all existing versions.
+func enforceObjectLock(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket, object string, opts ObjectOptions) error {
+	if _, ok := globalBucketObjectLockSys.Get(bucket); !ok {
+		return nil
+	}
+
+	versionID := opts.VersionID
+	if versionID == "" {
+		if opts.Versioned {
+			return nil
+		}
+		if opts.VersionSuspended {
+			// The "null" version is replaced.
+			versionID = nullVersionID
+		}
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		switch err.(type) {
+		case ObjectNotFound, VersionNotFound, MethodNotAllowed:
+			// Nothing to protect.
+			return nil
+		}
+		return err
+	}
+
+	return checkObjectLocked(r, objInfo)
+}
+
+// setObjectLockMetadata - validates the object lock request headers of
+// PutObject, CopyObject and NewMultipartUpload and adds the retention
+// and the legal hold to the metadata of the new object. Objects without
+// an explicit retention get the default retention of the bucket.
+func setObjectLockMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	mode := r.Header.Get(amzObjectLockMode)
+	retainUntil := r.Header.Get(amzObjectLockRetainUntilDate)
+	legalHold := r.Header.Get(amzObjectLockLegalHold)
+
+	config, ok := globalBucketObjectLockSys.Get(bucket)
+	if !ok {
+		if mode != "" || retainUntil != "" || legalHold != "" {
+			return ErrObjectLockConfigurationMissing
+		}
+		return ErrNone
+	}
+
+	rAuthType := getRequestAuthType(r)
+	switch {
+	case mode != "" || retainUntil != "":
+		if mode == "" || retainUntil == "" {
+			return ErrObjectLockInvalidHeaders
+		}
+		if !objectlock.Mode(mode).IsValid() {
+			return ErrUnknownObjectLockMode
+		}
+
+		retainUntilDate, err := time.Parse(time.RFC3339, retainUntil)
+		if err != nil {
+			return ErrInvalidRetentionDate
+		}
+		if !retainUntilDate.After(UTCNow()) {
+			return ErrPastObjectLockRetainDate
+		}
+
+		if s3Err := isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectRetentionAction); s3Err != ErrNone {
+			return s3Err
+		}
+
+		metadata[amzObjectLockMode] = mode
+		metadata[amzObjectLockRetainUntilDate] = retainUntilDate.UTC().Format(time.RFC3339)
+	default:
+		if dr, ok := config.DefaultRetention(); ok {
+			metadata[amzObjectLockMode] = string(dr.Mode)
+			metadata[amzObjectLockRetainUntilDate] = dr.RetainUntil(UTCNow()).Format(time.RFC3339)
+		}
+	}
+
+	if legalHold != "" {
+		if !objectlock.LegalHoldStatus(legalHold).IsValid() {
+			return ErrUnknownLegalHoldStatus
+		}
+
+		if s3Err := isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectLegalHoldAction); s3Err != ErrNone {
+			return s3Err
+		}
+
+		metadata[amzObjectLockLegalHold] = legalHold
+	}
+
+	return ErrNone
+}
+
+// checkObjectRetentionUpdate - returns ObjectLocked if the request may not
+// replace the active retention of the object with the given retention.
+// COMPLIANCE mode retention can only be extended, GOVERNANCE mode
+// retention can be shortened or removed only when bypass is allowed.
+func checkObjectRetentionUpdate(r *http.Request, objInfo ObjectInfo, retention objectlock.Retention) error {
+	current := getObjectRetention(objInfo.UserDefined)
+	if !current.IsActive(UTCNow()) {
+		return nil
+	}
+
+	extended := retention.Mode.IsValid() && !retention.RetainUntilDate.Before(current.RetainUntilDate)
+	switch current.Mode {
+	case objectlock.Compliance:
+		if extended && retention.Mode == objectlock.Compliance {
+			return nil
+		}
+	case objectlock.Governance:
+		if extended || isGovernanceBypassAllowed(r, objInfo.Bucket, objInfo.Name) {
+			return nil
+		}
+	}
+
+	return ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name}
+}
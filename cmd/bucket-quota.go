@@ -0,0 +1,649 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/madmin"
+)
+
+const (
+	// Bucket quota configuration file.
+	bucketQuotaConfigFile = "quota.json"
+
+	// Bucket quota configuration is a tiny JSON document.
+	maxBucketQuotaConfigSize = 1 * humanize.KiByte
+
+	// Interval between two crawls of the usage of the buckets having
+	// a quota, writes and deletes are accounted in between.
+	bucketUsageCrawlInterval = time.Hour
+
+	// Interval at which usage changes are sent to the other nodes.
+	bucketUsageSyncInterval = time.Second
+
+	// Lock held while deciding which node crawls and while saving the
+	// crawled usage.
+	bucketUsageLockFile = "bucket-usage.lock"
+)
+
+var (
+	// Usage of the buckets having a quota computed by the last crawls,
+	// shared by all nodes.
+	bucketUsageFile = path.Join(minioConfigPrefix, "bucket-usage.json")
+
+	// Time of the last crawl.
+	bucketUsageCrawlFile = path.Join(minioConfigPrefix, "bucket-usage-crawl.json")
+)
+
+var errInvalidBucketQuota = errors.New("invalid bucket quota configuration")
+
+// bucketUsageInfo - usage of a bucket computed by a crawl.
+type bucketUsageInfo struct {
+	Usage      uint64    `json:"usage"`
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+// bucketUsage - usage of a bucket computed by the last crawl along
+// with the changes made by the writes and deletes of all nodes since.
+type bucketUsage struct {
+	bucketUsageInfo
+	delta int64
+}
+
+// BucketQuotaSys - bucket quota subsystem, it keeps the quota of all
+// buckets along with the usage of the buckets having one.
+type BucketQuotaSys struct {
+	sync.RWMutex
+	quotaMap map[string]madmin.BucketQuota
+	usageMap map[string]bucketUsage
+
+	// Usage changes made on this node not sent to the other nodes yet.
+	pendingMap map[string]int64
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the quota
+// for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding quota during sys.refresh()
+func (sys *BucketQuotaSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.quotaMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.quotaMap, bucket)
+			delete(sys.usageMap, bucket)
+			delete(sys.pendingMap, bucket)
+		}
+	}
+}
+
+// Set - sets quota to given bucket name.
+func (sys *BucketQuotaSys) Set(bucketName string, q madmin.BucketQuota) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.quotaMap[bucketName] = q
+}
+
+// Get - returns quota of given bucket name.
+func (sys *BucketQuotaSys) Get(bucketName string) (q madmin.BucketQuota, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	q, ok = sys.quotaMap[bucketName]
+	return q, ok
+}
+
+// List - returns the quotas of all buckets.
+func (sys *BucketQuotaSys) List() map[string]madmin.BucketQuota {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	quotas := make(map[string]madmin.BucketQuota, len(sys.quotaMap))
+	for bucket, q := range sys.quotaMap {
+		quotas[bucket] = q
+	}
+	return quotas
+}
+
+// Remove - removes quota and usage for given bucket name.
+func (sys *BucketQuotaSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.quotaMap, bucketName)
+	delete(sys.usageMap, bucketName)
+	delete(sys.pendingMap, bucketName)
+}
+
+// usage - returns the usage in bytes of the bucket.
+func (u bucketUsage) usage() uint64 {
+	if u.delta < 0 && uint64(-u.delta) > u.Usage {
+		return 0
+	}
+	return uint64(int64(u.Usage) + u.delta)
+}
+
+// Usage - returns the usage in bytes of given bucket name.
+func (sys *BucketQuotaSys) Usage(bucketName string) uint64 {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	return sys.usageMap[bucketName].usage()
+}
+
+// addUsage - adds delta bytes, negative when freeing space, to the usage
+// of given bucket name and queues the change to be sent to the other
+// nodes, returns the usage before and after the update.
+func (sys *BucketQuotaSys) addUsage(bucketName string, delta int64) (before, after uint64) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	u := sys.usageMap[bucketName]
+	before = u.usage()
+	u.delta += delta
+	sys.usageMap[bucketName] = u
+	if globalIsDistXL {
+		sys.pendingMap[bucketName] += delta
+	}
+	return before, u.usage()
+}
+
+// applyUsageChanges - adds the usage changes received from another node.
+func (sys *BucketQuotaSys) applyUsageChanges(changes map[string]int64) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket, delta := range changes {
+		if _, ok := sys.quotaMap[bucket]; !ok {
+			continue
+		}
+		u := sys.usageMap[bucket]
+		u.delta += delta
+		sys.usageMap[bucket] = u
+	}
+}
+
+// takePendingUsageChanges - returns and clears the usage changes made on
+// this node not sent to the other nodes yet.
+func (sys *BucketQuotaSys) takePendingUsageChanges() map[string]int64 {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if len(sys.pendingMap) == 0 {
+		return nil
+	}
+	changes := sys.pendingMap
+	sys.pendingMap = make(map[string]int64)
+	return changes
+}
+
+// setCrawledUsage - replaces the usage of the buckets having a quota by
+// the given crawled usage when it is newer, changes made before the
+// crawl are dropped as the crawl accounts them.
+func (sys *BucketQuotaSys) setCrawledUsage(usageInfos map[string]bucketUsageInfo) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket, info := range usageInfos {
+		if _, ok := sys.quotaMap[bucket]; !ok {
+			continue
+		}
+		if u, ok := sys.usageMap[bucket]; ok && !info.LastUpdate.After(u.LastUpdate) {
+			continue
+		}
+		sys.usageMap[bucket] = bucketUsage{bucketUsageInfo: info}
+	}
+}
+
+// Refresh BucketQuotaSys.
+func (sys *BucketQuotaSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		q, err := getBucketQuotaConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketQuotaConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *q)
+	}
+	return nil
+}
+
+// refreshUsage - crawls the usage of all buckets having a quota if no
+// node did in the last crawl interval, otherwise loads the usage
+// crawled by the other nodes.
+func (sys *BucketQuotaSys) refreshUsage(objAPI ObjectLayer) error {
+	quotas := sys.List()
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	if !claimBackgroundRound(ctx, objAPI, bucketUsageLockFile, bucketUsageCrawlFile, bucketUsageCrawlInterval) {
+		return sys.loadUsage(objAPI)
+	}
+
+	var buckets []string
+	for bucket := range quotas {
+		buckets = append(buckets, bucket)
+	}
+	return sys.crawlUsage(objAPI, buckets...)
+}
+
+// refreshBucketUsage - crawls the usage of given bucket name having a
+// quota and shares it with the other nodes.
+func (sys *BucketQuotaSys) refreshBucketUsage(objAPI ObjectLayer, bucketName string) error {
+	ctx := context.Background()
+	lastUpdate := UTCNow()
+	usage, err := getBucketUsage(ctx, objAPI, bucketName)
+	if err != nil {
+		return err
+	}
+
+	usageInfos := map[string]bucketUsageInfo{
+		bucketName: {Usage: usage, LastUpdate: lastUpdate},
+	}
+	sys.setCrawledUsage(usageInfos)
+	return sys.saveUsage(ctx, objAPI, usageInfos)
+}
+
+// crawlUsage - computes the usage of given buckets having a quota and
+// shares it with the other nodes.
+func (sys *BucketQuotaSys) crawlUsage(objAPI ObjectLayer, buckets ...string) error {
+	ctx := context.Background()
+	usageInfos := make(map[string]bucketUsageInfo)
+	for _, bucket := range buckets {
+		lastUpdate := UTCNow()
+		usage, err := getBucketUsage(ctx, objAPI, bucket)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		usageInfos[bucket] = bucketUsageInfo{Usage: usage, LastUpdate: lastUpdate}
+	}
+
+	// Set the crawled usage even if it can not be shared.
+	sys.setCrawledUsage(usageInfos)
+	return sys.saveUsage(ctx, objAPI, usageInfos)
+}
+
+// loadUsage - loads the usage crawled by any node.
+func (sys *BucketQuotaSys) loadUsage(objAPI ObjectLayer) error {
+	usageInfos, err := readBucketsUsage(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	sys.setCrawledUsage(usageInfos)
+	return nil
+}
+
+// saveUsage - adds the given crawled usage to the usage shared by all
+// nodes, the usage of buckets without a quota is dropped.
+func (sys *BucketQuotaSys) saveUsage(ctx context.Context, objAPI ObjectLayer, usageInfos map[string]bucketUsageInfo) error {
+	usageLock := globalNSMutex.NewNSLock(minioMetaBucket, bucketUsageLockFile)
+	if err := usageLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer usageLock.Unlock()
+
+	savedInfos, err := readBucketsUsage(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	for bucket, info := range usageInfos {
+		if savedInfo, ok := savedInfos[bucket]; !ok || info.LastUpdate.After(savedInfo.LastUpdate) {
+			savedInfos[bucket] = info
+		}
+	}
+	for bucket := range savedInfos {
+		if _, ok := sys.Get(bucket); !ok {
+			delete(savedInfos, bucket)
+		}
+	}
+
+	data, err := json.Marshal(savedInfos)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, bucketUsageFile, data)
+}
+
+// syncUsage - sends the usage changes made on this node to the other
+// nodes, so that every node enforces the quota on the usage of all.
+func (sys *BucketQuotaSys) syncUsage() {
+	if changes := sys.takePendingUsageChanges(); changes != nil {
+		globalNotificationSys.UpdateBucketUsage(context.Background(), changes)
+	}
+}
+
+// Init - initializes bucket quota system from quota.json of all buckets.
+func (sys *BucketQuotaSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Compute bucket usage and refresh BucketQuotaSys in background.
+		go func() {
+			logger.LogIf(context.Background(), sys.refreshUsage(objAPI))
+
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			syncTicker := time.NewTicker(bucketUsageSyncInterval)
+			defer syncTicker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-syncTicker.C:
+					sys.syncUsage()
+				case <-ticker.C:
+					sys.refresh(objAPI)
+					logger.LogIf(context.Background(), sys.refreshUsage(objAPI))
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing bucket quota needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketQuotaSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for bucket quota subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketQuotaSys - creates new bucket quota system.
+func NewBucketQuotaSys() *BucketQuotaSys {
+	return &BucketQuotaSys{
+		quotaMap:   make(map[string]madmin.BucketQuota),
+		usageMap:   make(map[string]bucketUsage),
+		pendingMap: make(map[string]int64),
+	}
+}
+
+// readBucketsUsage - reads the usage crawled by all nodes.
+func readBucketsUsage(ctx context.Context, objAPI ObjectLayer) (map[string]bucketUsageInfo, error) {
+	usageInfos := make(map[string]bucketUsageInfo)
+	data, err := readConfig(ctx, objAPI, bucketUsageFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return usageInfos, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &usageInfos); err != nil {
+		return nil, err
+	}
+	return usageInfos, nil
+}
+
+// getBucketUsage - returns the total size of all objects in the bucket,
+// including the non current versions of versioned objects.
+func getBucketUsage(ctx context.Context, objAPI ObjectLayer, bucket string) (usage uint64, err error) {
+	if objAPI.IsVersioningSupported() {
+		var keyMarker, versionIDMarker string
+		for {
+			res, err := objAPI.ListObjectVersions(ctx, bucket, "", keyMarker, versionIDMarker, "", maxObjectList)
+			if err != nil {
+				return 0, err
+			}
+			for _, obj := range res.Objects {
+				usage += uint64(obj.Size)
+			}
+			if !res.IsTruncated {
+				return usage, nil
+			}
+			keyMarker, versionIDMarker = res.NextKeyMarker, res.NextVersionIDMarker
+		}
+	}
+
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return 0, err
+		}
+		for _, obj := range res.Objects {
+			usage += uint64(obj.Size)
+		}
+		if !res.IsTruncated {
+			return usage, nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// getQuotaObjectSize - returns the size of the object version which is
+// replaced by a write or removed by a delete with the given options, zero
+// if the bucket has no quota. A bucket with versioning enabled keeps the
+// current version unless a version ID is given, with versioning
+// suspended the "null" version is replaced.
+func getQuotaObjectSize(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) int64 {
+	if _, ok := globalBucketQuotaSys.Get(bucket); !ok {
+		return 0
+	}
+
+	versionID := opts.VersionID
+	if versionID == "" {
+		if opts.Versioned {
+			return 0
+		}
+		if opts.VersionSuspended {
+			versionID = nullVersionID
+		}
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		return 0
+	}
+	return objInfo.Size
+}
+
+// enforceBucketQuota - returns BucketQuotaExceeded if writing size more
+// bytes to the bucket, replacing an object version of replacedSize
+// bytes, would go over its hard quota. A write of unknown size can not
+// be checked and is denied.
+func enforceBucketQuota(bucket string, size, replacedSize int64) error {
+	q, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok || q.Type != madmin.HardQuota {
+		return nil
+	}
+
+	if size < 0 {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+
+	usage := globalBucketQuotaSys.Usage(bucket)
+	if uint64(replacedSize) > usage {
+		usage = 0
+	} else {
+		usage -= uint64(replacedSize)
+	}
+	if usage+uint64(size) > q.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+	return nil
+}
+
+// enforceBucketQuotaMultipart - same as enforceBucketQuota for the size
+// of the given parts of a multipart upload.
+func enforceBucketQuotaMultipart(ctx context.Context, objAPI ObjectLayer, bucket, object, uploadID string, parts []CompletePart, replacedSize int64) error {
+	if q, ok := globalBucketQuotaSys.Get(bucket); !ok || q.Type != madmin.HardQuota {
+		return nil
+	}
+
+	partSizes := make(map[int]int64)
+	var partNumberMarker int
+	for {
+		listPartsInfo, err := objAPI.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxPartsList, ObjectOptions{})
+		if err != nil {
+			return err
+		}
+		for _, part := range listPartsInfo.Parts {
+			partSizes[part.PartNumber] = part.Size
+		}
+		partNumberMarker = listPartsInfo.NextPartNumberMarker
+		if !listPartsInfo.IsTruncated {
+			break
+		}
+	}
+
+	var size int64
+	for _, part := range parts {
+		size += partSizes[part.PartNumber]
+	}
+	return enforceBucketQuota(bucket, size, replacedSize)
+}
+
+// updateBucketUsage - accounts a newly written object, which replaced an
+// object version of replacedSize bytes, in the usage of its bucket.
+// Crossing a soft quota is reported through a notification event and the
+// soft quota metric.
+func updateBucketUsage(bucket string, objInfo ObjectInfo, replacedSize int64) {
+	q, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok {
+		return
+	}
+
+	before, after := globalBucketQuotaSys.addUsage(bucket, objInfo.Size-replacedSize)
+	if q.Type != madmin.SoftQuota || after <= q.Quota {
+		return
+	}
+
+	bucketSoftQuotaExceeded.WithLabelValues(bucket).Inc()
+
+	// Notify only once when the usage goes over the quota.
+	if before > q.Quota {
+		return
+	}
+	sendEvent(eventArgs{
+		EventName:  event.BucketQuotaSoftLimitExceeded,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [QUOTA]",
+	})
+}
+
+// freeBucketUsage - removes the size of a deleted object version from the
+// usage of its bucket.
+func freeBucketUsage(bucket string, size int64) {
+	if _, ok := globalBucketQuotaSys.Get(bucket); !ok || size == 0 {
+		return
+	}
+	globalBucketQuotaSys.addUsage(bucket, -size)
+}
+
+func getBucketQuotaConfig(objAPI ObjectLayer, bucketName string) (*madmin.BucketQuota, error) {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+
+		return nil, err
+	}
+
+	return parseBucketQuota(configData)
+}
+
+// parseBucketQuota - parses and validates a bucket quota.
+func parseBucketQuota(data []byte) (*madmin.BucketQuota, error) {
+	var q madmin.BucketQuota
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, err
+	}
+
+	if !q.IsValid() {
+		return nil, errInvalidBucketQuota
+	}
+
+	return &q, nil
+}
+
+func saveBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, q *madmin.BucketQuota) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/madmin"
+)
+
+func TestParseBucketQuota(t *testing.T) {
+	testCases := []struct {
+		data          string
+		expectedQuota madmin.BucketQuota
+		expectErr     bool
+	}{
+		{`{"quota": 1048576, "quotatype": "hard"}`, madmin.BucketQuota{Quota: 1048576, Type: madmin.HardQuota}, false},
+		{`{"quota": 1, "quotatype": "soft"}`, madmin.BucketQuota{Quota: 1, Type: madmin.SoftQuota}, false},
+		{`{"quota": 0, "quotatype": "hard"}`, madmin.BucketQuota{}, true},
+		{`{"quota": 1048576, "quotatype": "fifo"}`, madmin.BucketQuota{}, true},
+		{`{"quota": 1048576}`, madmin.BucketQuota{}, true},
+		{`{"quota": -1, "quotatype": "hard"}`, madmin.BucketQuota{}, true},
+		{`{"quota": 1048576`, madmin.BucketQuota{}, true},
+	}
+
+	for i, testCase := range testCases {
+		q, err := parseBucketQuota([]byte(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr && *q != testCase.expectedQuota {
+			t.Fatalf("test %v: quota: expected: %v, got: %v", i+1, testCase.expectedQuota, *q)
+		}
+	}
+}
+
+// Wrapper for calling bucket quota enforcement tests for both XL multiple disks and single node setup.
+func TestBucketQuotaEnforcement(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketQuotaEnforcement, []string{"PutObject", "DeleteObject"})
+}
+
+func testBucketQuotaEnforcement(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalBucketQuotaSys.Remove(bucketName)
+
+	// Usage is only kept for buckets having a quota.
+	globalBucketQuotaSys.Set(bucketName, madmin.BucketQuota{Quota: 1, Type: madmin.SoftQuota})
+	if err := globalBucketQuotaSys.refreshBucketUsage(obj, bucketName); err != nil {
+		t.Fatalf("%s: Unable to compute bucket usage: %v", instanceType, err)
+	}
+	usage := globalBucketQuotaSys.Usage(bucketName)
+
+	data := []byte("hello, world")
+	softQuotaExceeded := func() float64 {
+		var m dto.Metric
+		if err := bucketSoftQuotaExceeded.WithLabelValues(bucketName).Write(&m); err != nil {
+			t.Fatalf("%s: Unable to read soft quota metric: %v", instanceType, err)
+		}
+		return m.GetCounter().GetValue()
+	}
+	softQuotaExceededBefore := softQuotaExceeded()
+
+	testCases := []struct {
+		quota              madmin.BucketQuota
+		objectName         string
+		expectedRespStatus int
+		expectedErrCode    string
+		expectedUsage      uint64
+	}{
+		// Test case - 1.
+		// Object fits in the hard quota.
+		{madmin.BucketQuota{Quota: usage + 2*uint64(len(data)), Type: madmin.HardQuota}, "object-1", http.StatusOK, "", usage + uint64(len(data))},
+		// Test case - 2.
+		// Object fits exactly in the hard quota.
+		{madmin.BucketQuota{Quota: usage + 2*uint64(len(data)), Type: madmin.HardQuota}, "object-2", http.StatusOK, "", usage + 2*uint64(len(data))},
+		// Test case - 3.
+		// Object goes over the hard quota.
+		{madmin.BucketQuota{Quota: usage + 2*uint64(len(data)), Type: madmin.HardQuota}, "object-3", http.StatusBadRequest, "XMinioBucketQuotaExceeded", usage + 2*uint64(len(data))},
+		// Test case - 4.
+		// Object goes over the soft quota.
+		{madmin.BucketQuota{Quota: usage + 2*uint64(len(data)), Type: madmin.SoftQuota}, "object-3", http.StatusOK, "", usage + 3*uint64(len(data))},
+	}
+
+	for i, testCase := range testCases {
+		globalBucketQuotaSys.Set(bucketName, testCase.quota)
+
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		if testCase.expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, rec.Body.String())
+			}
+			if errorResponse.Code != testCase.expectedErrCode {
+				t.Fatalf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedErrCode, errorResponse.Code)
+			}
+		}
+
+		if u := globalBucketQuotaSys.Usage(bucketName); u != testCase.expectedUsage {
+			t.Fatalf("Test %d: %s: Expected the usage to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedUsage, u)
+		}
+	}
+
+	if n := softQuotaExceeded() - softQuotaExceededBefore; n != 1 {
+		t.Fatalf("%s: Expected one write over the soft quota, but instead found `%v`", instanceType, n)
+	}
+
+	// Overwriting an object frees the replaced object, it fits in a
+	// hard quota already reached.
+	globalBucketQuotaSys.Set(bucketName, madmin.BucketQuota{Quota: usage + 3*uint64(len(data)), Type: madmin.HardQuota})
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, "object-1"),
+		int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if u := globalBucketQuotaSys.Usage(bucketName); u != usage+3*uint64(len(data)) {
+		t.Fatalf("%s: Expected the usage to be `%d`, but instead found `%d`", instanceType, usage+3*uint64(len(data)), u)
+	}
+
+	// Deleting an object frees its size.
+	rec = httptest.NewRecorder()
+	req, err = newTestSignedRequestV4("DELETE", getDeleteObjectURL("", bucketName, "object-1"),
+		0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if u := globalBucketQuotaSys.Usage(bucketName); u != usage+2*uint64(len(data)) {
+		t.Fatalf("%s: Expected the usage to be `%d`, but instead found `%d`", instanceType, usage+2*uint64(len(data)), u)
+	}
+
+	// Crawled usage matches the accounted usage.
+	if err = globalBucketQuotaSys.refreshBucketUsage(obj, bucketName); err != nil {
+		t.Fatalf("%s: Unable to compute bucket usage: %v", instanceType, err)
+	}
+	if u := globalBucketQuotaSys.Usage(bucketName); u != usage+2*uint64(len(data)) {
+		t.Fatalf("%s: Expected the usage to be `%d`, but instead found `%d`", instanceType, usage+2*uint64(len(data)), u)
+	}
+}
+
+func TestBucketQuotaSysUsage(t *testing.T) {
+	defer func(isDistXL bool) {
+		globalIsDistXL = isDistXL
+	}(globalIsDistXL)
+	globalIsDistXL = true
+
+	sys := NewBucketQuotaSys()
+	sys.Set("bucket", madmin.BucketQuota{Quota: 100, Type: madmin.HardQuota})
+
+	crawlTime := UTCNow()
+	sys.setCrawledUsage(map[string]bucketUsageInfo{
+		"bucket":       {Usage: 50, LastUpdate: crawlTime},
+		"other-bucket": {Usage: 10, LastUpdate: crawlTime},
+	})
+	if u := sys.Usage("other-bucket"); u != 0 {
+		t.Fatalf("expected no usage for a bucket without quota, got %d", u)
+	}
+
+	// Changes of this node are queued for the other nodes.
+	if before, after := sys.addUsage("bucket", 20); before != 50 || after != 70 {
+		t.Fatalf("expected usage to go from 50 to 70, got %d to %d", before, after)
+	}
+	if _, after := sys.addUsage("bucket", -5); after != 65 {
+		t.Fatalf("expected usage 65, got %d", after)
+	}
+	if changes := sys.takePendingUsageChanges(); !reflect.DeepEqual(changes, map[string]int64{"bucket": 15}) {
+		t.Fatalf("expected pending changes of 15 bytes, got %v", changes)
+	}
+	if changes := sys.takePendingUsageChanges(); changes != nil {
+		t.Fatalf("expected no pending changes, got %v", changes)
+	}
+
+	// Changes of other nodes are applied, not queued again.
+	sys.applyUsageChanges(map[string]int64{"bucket": 30, "other-bucket": 5})
+	if u := sys.Usage("bucket"); u != 95 {
+		t.Fatalf("expected usage 95, got %d", u)
+	}
+	if changes := sys.takePendingUsageChanges(); changes != nil {
+		t.Fatalf("expected no pending changes, got %v", changes)
+	}
+
+	// An older crawl is ignored, a newer one replaces all changes.
+	sys.setCrawledUsage(map[string]bucketUsageInfo{"bucket": {Usage: 10, LastUpdate: crawlTime.Add(-time.Minute)}})
+	if u := sys.Usage("bucket"); u != 95 {
+		t.Fatalf("expected usage 95, got %d", u)
+	}
+	sys.setCrawledUsage(map[string]bucketUsageInfo{"bucket": {Usage: 90, LastUpdate: crawlTime.Add(time.Minute)}})
+	if u := sys.Usage("bucket"); u != 90 {
+		t.Fatalf("expected usage 90, got %d", u)
+	}
+
+	// Usage does not go below zero.
+	sys.addUsage("bucket", -100)
+	if u := sys.Usage("bucket"); u != 0 {
+		t.Fatalf("expected usage 0, got %d", u)
+	}
+}
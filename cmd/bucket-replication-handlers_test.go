@@ -0,0 +1,108 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/replication"
+)
+
+// Wrapper for calling bucket replication HTTP handler tests for both XL multiple disks and single node setup.
+func TestBucketReplicationHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketReplicationHandlers, []string{"PutBucketReplication", "GetBucketReplication", "DeleteBucketReplication", "PutObject", "HeadObject"})
+}
+
+func testBucketReplicationHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalBucketReplicationSys.Remove(bucketName)
+
+	// Executes request with credentials and checks the response.
+	execRequest := func(method, urlStr string, data []byte, expectedRespStatus int, expectedErrCode string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, urlStr,
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("%s %s %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, method, urlStr, expectedRespStatus, rec.Code)
+		}
+		if expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("%s %s %s: Unable to unmarshal response body %s", instanceType, method, urlStr, rec.Body.String())
+			}
+			if errorResponse.Code != expectedErrCode {
+				t.Fatalf("%s %s %s: Expected the error code to be `%s`, but instead found `%s`", instanceType, method, urlStr, expectedErrCode, errorResponse.Code)
+			}
+		}
+		return rec
+	}
+
+	replicationURL := getBucketReplicationURL("", bucketName)
+	replicationData := []byte(`<ReplicationConfiguration><Rule><ID>backup</ID><Status>Enabled</Status><Prefix>logs/</Prefix><Destination><Bucket>arn:aws:s3:::backup</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`)
+
+	execRequest("GET", replicationURL, nil, http.StatusNotFound, "ReplicationConfigurationNotFoundError")
+	execRequest("PUT", replicationURL, []byte(`<ReplicationConfiguration></ReplicationConfiguration>`), http.StatusBadRequest, "MalformedXML")
+	execRequest("PUT", replicationURL, replicationData, http.StatusOK, "")
+
+	// The secret key of the remote target is stored encrypted.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+	data, err := readConfig(context.Background(), obj, configFile)
+	if err != nil {
+		t.Fatalf("%s: Unable to read replication configuration: %v", instanceType, err)
+	}
+	if bytes.Contains(data, []byte("minio123")) {
+		t.Fatalf("%s: Expected the secret key to be encrypted, but instead found %s", instanceType, data)
+	}
+	config, err := getBucketReplicationConfig(obj, bucketName)
+	if err != nil {
+		t.Fatalf("%s: Unable to load replication configuration: %v", instanceType, err)
+	}
+	if secretKey := config.Destination().SecretKey; secretKey != "minio123" {
+		t.Fatalf("%s: Expected the secret key to be `minio123`, but instead found `%s`", instanceType, secretKey)
+	}
+
+	// The secret key of the remote target is not returned.
+	rec := execRequest("GET", replicationURL, nil, http.StatusOK, "")
+	var returnedConfig replication.Config
+	if err = xml.Unmarshal(rec.Body.Bytes(), &returnedConfig); err != nil {
+		t.Fatalf("%s: Unable to parse replication configuration: %v", instanceType, err)
+	}
+	dest := returnedConfig.Destination()
+	if dest.BucketName() != "backup" || dest.AccessKey != "minio" || dest.SecretKey != "" {
+		t.Fatalf("%s: Unexpected replication destination %v", instanceType, dest)
+	}
+
+	// Objects are no longer replicated once the configuration is removed.
+	execRequest("DELETE", replicationURL, nil, http.StatusNoContent, "")
+	execRequest("GET", replicationURL, nil, http.StatusNotFound, "ReplicationConfigurationNotFoundError")
+	execRequest("PUT", getPutObjectURL("", bucketName, "logs/object"), []byte("hello"), http.StatusOK, "")
+	rec = execRequest("HEAD", getHeadObjectURL("", bucketName, "logs/object"), nil, http.StatusOK, "")
+	if status := rec.Header().Get(amzReplicationStatus); status != "" {
+		t.Fatalf("%s: Expected no replication status after removing the configuration, but instead found `%s`", instanceType, status)
+	}
+}
@@ -0,0 +1,569 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/crypto"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/madmin"
+	"github.com/scriptburn/minio/pkg/replication"
+)
+
+const (
+	// Bucket replication configuration file.
+	bucketReplicationConfig = "replication.xml"
+
+	// Replication status of an object, reported by HEAD and GET.
+	amzReplicationStatus = "X-Amz-Replication-Status"
+
+	// Number of objects waiting to be replicated, objects which do
+	// not fit are picked up by the next resync.
+	replicationQueueSize = 10000
+
+	// Number of concurrent uploads to the remote targets.
+	replicationWorkers = 4
+
+	// Interval between two sweeps retrying pending and failed objects.
+	replicationResyncInterval = 15 * time.Minute
+
+	// Lock held by the node running a replication resync.
+	replicationResyncLockFile = "replication-resync.lock"
+)
+
+var errReplicationQueueFull = errors.New("Replication queue is full, the object is replicated by the next resync")
+
+// replicationTask - an object version to upload or an object to remove
+// on the remote target of a bucket.
+type replicationTask struct {
+	bucket string
+	object ObjectInfo
+	delete bool
+}
+
+// BucketReplicationSys - bucket replication subsystem.
+type BucketReplicationSys struct {
+	sync.RWMutex
+	bucketReplicationMap map[string]replication.Config
+	clientMap            map[replication.Destination]*miniogo.Client
+	queue                chan replicationTask
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the replication
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification
+// we should delete the corresponding replication configuration during sys.refresh()
+func (sys *BucketReplicationSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketReplicationMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketReplicationMap, bucket)
+		}
+	}
+}
+
+// Set - sets replication configuration to given bucket name.
+func (sys *BucketReplicationSys) Set(bucketName string, config replication.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketReplicationMap[bucketName] = config
+}
+
+// Get - returns replication configuration of given bucket name.
+func (sys *BucketReplicationSys) Get(bucketName string) (config replication.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketReplicationMap[bucketName]
+	return config, ok
+}
+
+// List - returns the replication configurations of all buckets.
+func (sys *BucketReplicationSys) List() map[string]replication.Config {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	configs := make(map[string]replication.Config, len(sys.bucketReplicationMap))
+	for bucket, config := range sys.bucketReplicationMap {
+		configs[bucket] = config
+	}
+	return configs
+}
+
+// Remove - removes replication configuration for given bucket name.
+func (sys *BucketReplicationSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketReplicationMap, bucketName)
+}
+
+// getClient - returns a client of the remote target, clients are kept
+// so that connections to the target are reused.
+func (sys *BucketReplicationSys) getClient(dest replication.Destination) (*miniogo.Client, error) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if client, ok := sys.clientMap[dest]; ok {
+		return client, nil
+	}
+
+	u, err := dest.EndpointURL()
+	if err != nil {
+		return nil, err
+	}
+	client, err := miniogo.New(u.Host, dest.AccessKey, dest.SecretKey, u.Scheme == "https")
+	if err != nil {
+		return nil, err
+	}
+	client.SetCustomTransport(NewCustomHTTPTransport())
+	sys.clientMap[dest] = client
+	return client, nil
+}
+
+// Refresh BucketReplicationSys.
+func (sys *BucketReplicationSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketReplication(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketReplicationConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes replication system from replication.xml of all
+// buckets and starts replicating queued objects.
+func (sys *BucketReplicationSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		for i := 0; i < replicationWorkers; i++ {
+			go sys.startWorker()
+		}
+		go sys.startResync()
+
+		// Refresh BucketReplicationSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing replication needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketReplicationSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for replication subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketReplicationSys - creates new replication system.
+func NewBucketReplicationSys() *BucketReplicationSys {
+	return &BucketReplicationSys{
+		bucketReplicationMap: make(map[string]replication.Config),
+		clientMap:            make(map[replication.Destination]*miniogo.Client),
+		queue:                make(chan replicationTask, replicationQueueSize),
+	}
+}
+
+// enqueue - queues a replication task without blocking the caller.
+func (sys *BucketReplicationSys) enqueue(task replicationTask) {
+	select {
+	case sys.queue <- task:
+	default:
+		reqInfo := &logger.ReqInfo{BucketName: task.bucket, ObjectName: task.object.Name}
+		ctx := logger.SetReqInfo(context.Background(), reqInfo)
+		logger.LogOnceIf(ctx, errReplicationQueueFull, task.bucket)
+	}
+}
+
+// startWorker - replicates queued objects until the server stops.
+func (sys *BucketReplicationSys) startWorker() {
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case task := <-sys.queue:
+			objAPI := newObjectLayerFn()
+			if objAPI == nil {
+				continue
+			}
+
+			reqInfo := &logger.ReqInfo{BucketName: task.bucket, ObjectName: task.object.Name}
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			if task.delete {
+				logger.LogIf(ctx, sys.replicateDelete(ctx, task.bucket, task.object.Name))
+			} else {
+				logger.LogIf(ctx, sys.replicateObject(ctx, objAPI, task.bucket, task.object))
+			}
+		}
+	}
+}
+
+// replicateObject - uploads an object version to the remote target of
+// the bucket and records the outcome in the replication status of the
+// object.
+func (sys *BucketReplicationSys) replicateObject(ctx context.Context, objAPI ObjectLayer, bucket string, objInfo ObjectInfo) error {
+	config, ok := sys.Get(bucket)
+	if !ok {
+		return nil
+	}
+	if _, ok = config.Match(objInfo.Name); !ok {
+		return nil
+	}
+	dest := config.Destination()
+
+	opts := ObjectOptions{VersionID: objInfo.VersionID}
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, objInfo.Name, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		switch err.(type) {
+		case ObjectNotFound, VersionNotFound:
+			// Removed in the meantime, nothing to replicate.
+			return nil
+		}
+		return err
+	}
+	srcInfo := gr.ObjInfo
+
+	// The object was replicated by an earlier task.
+	if replication.ObjectStatus(srcInfo.UserDefined[amzReplicationStatus]) == replication.Completed {
+		gr.Close()
+		return nil
+	}
+
+	client, err := sys.getClient(dest)
+	if err == nil {
+		_, err = client.PutObjectWithContext(ctx, dest.BucketName(), srcInfo.Name, gr,
+			getReplicationObjectSize(srcInfo), getReplicationPutOptions(srcInfo))
+	}
+	gr.Close()
+
+	status := replication.Completed
+	eventName := event.ObjectReplicationComplete
+	if err != nil {
+		status = replication.Failed
+		eventName = event.ObjectReplicationFailed
+		logger.LogIf(ctx, err)
+	}
+
+	// Record the status only on the uploaded object, an object written
+	// in the meantime keeps its pending status and is replicated by its
+	// own task.
+	opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+		return oi.ETag != srcInfo.ETag || !oi.ModTime.Equal(srcInfo.ModTime)
+	}
+	metadata := map[string]string{amzReplicationStatus: string(status)}
+	if err = objAPI.UpdateObjectMetadata(ctx, bucket, srcInfo.Name, metadata, opts); err != nil {
+		switch err.(type) {
+		case PreconditionFailed, ObjectNotFound, VersionNotFound:
+			return nil
+		}
+		return err
+	}
+
+	// Notify replication completed or failed event.
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     srcInfo,
+		Host:       "Internal: [REPLICATION]",
+	})
+	return nil
+}
+
+// replicateDelete - removes an object from the remote target of the
+// bucket.
+func (sys *BucketReplicationSys) replicateDelete(ctx context.Context, bucket, object string) error {
+	config, ok := sys.Get(bucket)
+	if !ok {
+		return nil
+	}
+	if _, ok = config.Match(object); !ok {
+		return nil
+	}
+	dest := config.Destination()
+
+	client, err := sys.getClient(dest)
+	if err != nil {
+		return err
+	}
+	return client.RemoveObject(dest.BucketName(), object)
+}
+
+// startResync - periodically queues the objects whose replication is
+// pending or failed, a single node resyncs at a time.
+func (sys *BucketReplicationSys) startResync() {
+	ticker := time.NewTicker(replicationResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			objAPI := newObjectLayerFn()
+			if objAPI == nil {
+				continue
+			}
+
+			// Skip this round if another node is already resyncing.
+			resyncLock := globalNSMutex.NewNSLock(minioMetaBucket, replicationResyncLockFile)
+			if err := resyncLock.GetLock(newDynamicTimeout(time.Second, time.Second)); err != nil {
+				continue
+			}
+			sys.resync(context.Background(), objAPI)
+			resyncLock.Unlock()
+		}
+	}
+}
+
+// resync - walks the objects of all buckets having a replication
+// configuration and queues the ones not replicated yet.
+func (sys *BucketReplicationSys) resync(ctx context.Context, objAPI ObjectLayer) {
+	for bucket := range sys.List() {
+		marker := ""
+		for {
+			res, err := objAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				break
+			}
+
+			for _, obj := range res.Objects {
+				switch replication.ObjectStatus(obj.UserDefined[amzReplicationStatus]) {
+				case replication.Pending, replication.Failed:
+					sys.enqueue(replicationTask{bucket: bucket, object: obj})
+				}
+			}
+
+			if !res.IsTruncated {
+				break
+			}
+			marker = res.NextMarker
+		}
+	}
+}
+
+// getReplicationObjectSize - returns the size of the object as sent to
+// the remote target, i.e. after decryption and decompression.
+func getReplicationObjectSize(objInfo ObjectInfo) int64 {
+	switch {
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		size, err := objInfo.DecryptedSize()
+		if err != nil {
+			return -1
+		}
+		return size
+	case objInfo.IsCompressed():
+		return objInfo.GetActualSize()
+	default:
+		return objInfo.Size
+	}
+}
+
+// getReplicationPutOptions - returns the upload options carrying the
+// content headers and the user metadata of the object, SSE-S3 objects
+// are encrypted by the remote target as well.
+func getReplicationPutOptions(objInfo ObjectInfo) (opts miniogo.PutObjectOptions) {
+	opts.UserMetadata = make(map[string]string)
+	for k, v := range objInfo.UserDefined {
+		switch strings.ToLower(k) {
+		case "content-type":
+			opts.ContentType = v
+		case "content-encoding":
+			opts.ContentEncoding = v
+		case "content-disposition":
+			opts.ContentDisposition = v
+		case "content-language":
+			opts.ContentLanguage = v
+		case "cache-control":
+			opts.CacheControl = v
+		default:
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+				opts.UserMetadata[k] = v
+			}
+		}
+	}
+	if crypto.S3.IsEncrypted(objInfo.UserDefined) {
+		opts.ServerSideEncryption = encrypt.NewSSE()
+	}
+	return opts
+}
+
+// setReplicationStatus - marks new objects matching a replication rule
+// of the bucket as pending, objects encrypted with a client provided
+// key cannot be read by the server and are never replicated.
+func setReplicationStatus(metadata map[string]string, h http.Header, bucket, object string) {
+	delete(metadata, amzReplicationStatus)
+
+	if crypto.SSEC.IsRequested(h) {
+		return
+	}
+
+	if config, ok := globalBucketReplicationSys.Get(bucket); ok {
+		if _, ok = config.Match(object); ok {
+			metadata[amzReplicationStatus] = string(replication.Pending)
+		}
+	}
+}
+
+// replicateObjectAsync - queues a new object version for replication if
+// it was marked as pending when it was written.
+func replicateObjectAsync(bucket string, objInfo ObjectInfo) {
+	if replication.ObjectStatus(objInfo.UserDefined[amzReplicationStatus]) != replication.Pending {
+		return
+	}
+
+	globalBucketReplicationSys.enqueue(replicationTask{bucket: bucket, object: objInfo})
+}
+
+// replicateDeleteAsync - queues the removal of an object from the remote
+// target of the bucket.
+func replicateDeleteAsync(bucket, object string) {
+	if config, ok := globalBucketReplicationSys.Get(bucket); ok {
+		if _, ok = config.Match(object); ok {
+			globalBucketReplicationSys.enqueue(replicationTask{
+				bucket: bucket,
+				object: ObjectInfo{Bucket: bucket, Name: object},
+				delete: true,
+			})
+		}
+	}
+}
+
+func getBucketReplicationConfig(objAPI ObjectLayer, bucketName string) (*replication.Config, error) {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketReplicationConfigNotFound{Bucket: bucketName}
+		}
+
+		return nil, err
+	}
+
+	config, err := replication.ParseConfig(bytes.NewReader(configData))
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret key of the remote target is stored encrypted with the
+	// server credentials.
+	encrypted, err := base64.StdEncoding.DecodeString(config.Destination().SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := madmin.DecryptData(globalServerConfig.GetCredential().SecretKey, bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, err
+	}
+	decrypted := config.WithSecretKey(string(secretKey))
+	return &decrypted, nil
+}
+
+func saveBucketReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *replication.Config) error {
+	encrypted, err := madmin.EncryptData(globalServerConfig.GetCredential().SecretKey, []byte(config.Destination().SecretKey))
+	if err != nil {
+		return err
+	}
+
+	data, err := xml.Marshal(config.WithSecretKey(base64.StdEncoding.EncodeToString(encrypted)))
+	if err != nil {
+		return err
+	}
+
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeBucketReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketReplicationConfigNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
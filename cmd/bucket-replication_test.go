@@ -0,0 +1,214 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/replication"
+)
+
+// Wrapper for calling bucket replication tests for both XL multiple disks and single node setup.
+func TestBucketReplication(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketReplication, []string{"PutBucketReplication", "PutObject", "HeadObject", "DeleteObject", "GetBucketLocation"})
+}
+
+func testBucketReplication(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalBucketReplicationSys.Remove(bucketName)
+
+	// The remote target is a second bucket served by the same router.
+	remoteBucket := "replica-" + getRandomBucketName()[:40]
+	if err := obj.MakeBucketWithLocation(context.Background(), remoteBucket, ""); err != nil {
+		t.Fatalf("%s: Unable to create the remote bucket: %v", instanceType, err)
+	}
+	remote := httptest.NewServer(apiRouter)
+	defer remote.Close()
+
+	replicationConfig := func(endpoint string) []byte {
+		return []byte(fmt.Sprintf(`<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix></Prefix><Destination><Bucket>arn:aws:s3:::%s</Bucket><Endpoint>%s</Endpoint><AccessKey>%s</AccessKey><SecretKey>%s</SecretKey></Destination></Rule></ReplicationConfiguration>`,
+			remoteBucket, endpoint, credentials.AccessKey, credentials.SecretKey))
+	}
+
+	execRequest := func(method, url string, data []byte, header http.Header) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(method, url, int64(len(data)), bytes.NewReader(data),
+			credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	headStatus := func(object string) string {
+		rec := execRequest("HEAD", getHeadObjectURL("", bucketName, object), nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		return rec.Header().Get(amzReplicationStatus)
+	}
+
+	nextTask := func() replicationTask {
+		select {
+		case task := <-globalBucketReplicationSys.queue:
+			return task
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: Expected a queued replication task", instanceType)
+		}
+		return replicationTask{}
+	}
+
+	// Objects written before the configuration are not replicated.
+	data := []byte("hello, world")
+	if rec := execRequest("PUT", getPutObjectURL("", bucketName, "before"), data, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if status := headStatus("before"); status != "" {
+		t.Fatalf("%s: Expected no replication status, but instead found `%s`", instanceType, status)
+	}
+
+	if rec := execRequest("PUT", getBucketReplicationURL("", bucketName), replicationConfig(remote.URL), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	// New objects are pending until the queued task uploads them.
+	header := http.Header{"X-Amz-Meta-Color": []string{"blue"}}
+	if rec := execRequest("PUT", getPutObjectURL("", bucketName, "object"), data, header); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if status := headStatus("object"); status != string(replication.Pending) {
+		t.Fatalf("%s: Expected the replication status to be `%s`, but instead found `%s`", instanceType, replication.Pending, status)
+	}
+
+	task := nextTask()
+	if err := globalBucketReplicationSys.replicateObject(context.Background(), obj, task.bucket, task.object); err != nil {
+		t.Fatalf("%s: Unable to replicate object: %v", instanceType, err)
+	}
+	if status := headStatus("object"); status != string(replication.Completed) {
+		t.Fatalf("%s: Expected the replication status to be `%s`, but instead found `%s`", instanceType, replication.Completed, status)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), remoteBucket, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Expected the object to be replicated: %v", instanceType, err)
+	}
+	if objInfo.Size != int64(len(data)) || objInfo.UserDefined["X-Amz-Meta-Color"] != "blue" {
+		t.Fatalf("%s: Unexpected replicated object %v", instanceType, objInfo)
+	}
+
+	// Removals are replicated as well.
+	if rec := execRequest("DELETE", getDeleteObjectURL("", bucketName, "object"), nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	task = nextTask()
+	if !task.delete {
+		t.Fatalf("%s: Expected a queued removal", instanceType)
+	}
+	if err = globalBucketReplicationSys.replicateDelete(context.Background(), task.bucket, task.object.Name); err != nil {
+		t.Fatalf("%s: Unable to replicate removal: %v", instanceType, err)
+	}
+	if _, err = obj.GetObjectInfo(context.Background(), remoteBucket, "object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: Expected the replicated object to be removed, but instead found `%v`", instanceType, err)
+	}
+
+	// Uploads to an unreachable target fail and are retried by a resync.
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	config, err := replication.ParseConfig(bytes.NewReader(replicationConfig(unreachable.URL)))
+	if err != nil {
+		t.Fatalf("%s: Unable to parse replication configuration: %v", instanceType, err)
+	}
+	globalBucketReplicationSys.Set(bucketName, *config)
+
+	if rec := execRequest("PUT", getPutObjectURL("", bucketName, "unreachable"), data, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	task = nextTask()
+	if err = globalBucketReplicationSys.replicateObject(context.Background(), obj, task.bucket, task.object); err != nil {
+		t.Fatalf("%s: Unable to record replication status: %v", instanceType, err)
+	}
+	if status := headStatus("unreachable"); status != string(replication.Failed) {
+		t.Fatalf("%s: Expected the replication status to be `%s`, but instead found `%s`", instanceType, replication.Failed, status)
+	}
+
+	globalBucketReplicationSys.resync(context.Background(), obj)
+	if task = nextTask(); task.object.Name != "unreachable" {
+		t.Fatalf("%s: Expected `unreachable` to be queued again, but instead found `%s`", instanceType, task.object.Name)
+	}
+}
+
+// Wrapper for calling replication status update tests for both XL multiple disks and single node setup.
+func TestReplicationStatusUpdate(t *testing.T) {
+	ExecObjectLayerTest(t, testReplicationStatusUpdate)
+}
+
+// The replication status is recorded only if the object is still the
+// replicated one.
+func testReplicationStatusUpdate(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatalf("%s: Unable to create bucket: %v", instanceType, err)
+	}
+
+	pending := map[string]string{amzReplicationStatus: string(replication.Pending)}
+	srcInfo, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), pending, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Unable to put object: %v", instanceType, err)
+	}
+	if _, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), 4, "", ""), pending, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Unable to overwrite object: %v", instanceType, err)
+	}
+
+	opts := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool {
+		return oi.ETag != srcInfo.ETag || !oi.ModTime.Equal(srcInfo.ModTime)
+	}}
+	completed := map[string]string{amzReplicationStatus: string(replication.Completed)}
+	err = obj.UpdateObjectMetadata(context.Background(), bucket, object, completed, opts)
+	if _, ok := err.(PreconditionFailed); !ok {
+		t.Fatalf("%s: Expected PreconditionFailed, but instead found `%v`", instanceType, err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Unable to get object info: %v", instanceType, err)
+	}
+	if status := objInfo.UserDefined[amzReplicationStatus]; status != string(replication.Pending) {
+		t.Fatalf("%s: Expected the replication status to be `%s`, but instead found `%s`", instanceType, replication.Pending, status)
+	}
+
+	srcInfo = objInfo
+	if err = obj.UpdateObjectMetadata(context.Background(), bucket, object, completed, opts); err != nil {
+		t.Fatalf("%s: Unable to update object metadata: %v", instanceType, err)
+	}
+	if objInfo, err = obj.GetObjectInfo(context.Background(), bucket, object, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Unable to get object info: %v", instanceType, err)
+	}
+	if status := objInfo.UserDefined[amzReplicationStatus]; status != string(replication.Completed) {
+		t.Fatalf("%s: Expected the replication status to be `%s`, but instead found `%s`", instanceType, replication.Completed, status)
+	}
+}
@@ -0,0 +1,276 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/versioning"
+	"github.com/skyrings/skyring-common/tools/uuid"
+)
+
+const (
+	// Bucket versioning configuration file.
+	bucketVersioningConfig = "versioning.xml"
+
+	// Version ID of objects written while versioning is not enabled.
+	nullVersionID = "null"
+
+	// Response headers carrying the version ID and the delete marker state.
+	amzVersionID    = "x-amz-version-id"
+	amzDeleteMarker = "x-amz-delete-marker"
+
+	// Response header carrying the version ID of a copy source.
+	amzCopySourceVersionID = "x-amz-copy-source-version-id"
+)
+
+// isValidVersionID - checks if the given version ID is either the
+// "null" version ID or a version ID generated by this server.
+func isValidVersionID(versionID string) bool {
+	if versionID == nullVersionID {
+		return true
+	}
+	_, err := uuid.Parse(versionID)
+	return err == nil
+}
+
+// BucketVersioningSys - bucket versioning subsystem.
+type BucketVersioningSys struct {
+	sync.RWMutex
+	bucketVersioningMap map[string]versioning.Versioning
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the versioning
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification
+// we should delete the corresponding versioning configuration during sys.refresh()
+func (sys *BucketVersioningSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketVersioningMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketVersioningMap, bucket)
+		}
+	}
+}
+
+// Set - sets versioning configuration to given bucket name.
+func (sys *BucketVersioningSys) Set(bucketName string, config versioning.Versioning) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketVersioningMap[bucketName] = config
+}
+
+// Get - returns versioning configuration of given bucket name.
+func (sys *BucketVersioningSys) Get(bucketName string) (config versioning.Versioning, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketVersioningMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes versioning configuration for given bucket name.
+func (sys *BucketVersioningSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketVersioningMap, bucketName)
+}
+
+// Enabled - returns true if versioning is enabled on given bucket name.
+func (sys *BucketVersioningSys) Enabled(bucketName string) bool {
+	config, _ := sys.Get(bucketName)
+	return config.Enabled()
+}
+
+// Suspended - returns true if versioning is suspended on given bucket name.
+func (sys *BucketVersioningSys) Suspended(bucketName string) bool {
+	config, _ := sys.Get(bucketName)
+	return config.Suspended()
+}
+
+// Refresh BucketVersioningSys.
+func (sys *BucketVersioningSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getVersioningConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes bucket versioning system from versioning.xml of all buckets.
+func (sys *BucketVersioningSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Nothing to load if versioning is not supported by the object layer.
+	if !objAPI.IsVersioningSupported() {
+		return nil
+	}
+
+	defer func() {
+		// Refresh BucketVersioningSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing bucket versioning needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case _ = <-retryTimerCh:
+			// Load BucketVersioningSys once during boot.
+			if err := sys.refresh(objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for bucket versioning subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// NewBucketVersioningSys - creates new bucket versioning system.
+func NewBucketVersioningSys() *BucketVersioningSys {
+	return &BucketVersioningSys{
+		bucketVersioningMap: make(map[string]versioning.Versioning),
+	}
+}
+
+// setVersioningOpts - sets the versioning state of given bucket name and
+// the requested version ID on the given object options.
+func setVersioningOpts(opts *ObjectOptions, bucketName, versionID string) {
+	opts.VersionID = versionID
+	opts.Versioned = globalBucketVersioningSys.Enabled(bucketName)
+	opts.VersionSuspended = globalBucketVersioningSys.Suspended(bucketName)
+}
+
+// getRequestVersionID - validates the given version ID against what the
+// object layer is able to serve. Object layers without versioning support
+// only know the "null" version which always refers to the current object.
+func getRequestVersionID(objAPI ObjectLayer, versionID string) (string, APIErrorCode) {
+	if versionID == "" {
+		return "", ErrNone
+	}
+	if !objAPI.IsVersioningSupported() {
+		if versionID != nullVersionID {
+			return "", ErrNoSuchVersion
+		}
+		return "", ErrNone
+	}
+	if !isValidVersionID(versionID) {
+		return "", ErrNoSuchVersion
+	}
+	return versionID, ErrNone
+}
+
+// setVersionHeaders - sets the version ID and delete marker response
+// headers of given object. Objects in buckets which never had versioning
+// configured carry no version ID.
+func setVersionHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	versionID := objInfo.VersionID
+	if versionID == "" {
+		if _, ok := globalBucketVersioningSys.Get(objInfo.Bucket); !ok && !objInfo.DeleteMarker {
+			return
+		}
+		versionID = nullVersionID
+	}
+	w.Header().Set(amzVersionID, versionID)
+	if objInfo.DeleteMarker {
+		w.Header().Set(amzDeleteMarker, "true")
+	}
+}
+
+// getVersioningConfig - get versioning config for given bucket name.
+func getVersioningConfig(objAPI ObjectLayer, bucketName string) (*versioning.Versioning, error) {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return versioning.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *versioning.Versioning) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	return objAPI.DeleteObject(ctx, minioMetaBucket, configFile)
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -234,6 +235,137 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	for _, v := range s.Notify.Subscriptions {
+		if err := v.Validate(s.Region); err != nil {
+			return fmt.Errorf("subscriptions: %s", err)
+		}
+		if !s.isNotificationTargetEnabled(v.ARN.TargetID) {
+			return fmt.Errorf("subscriptions: target '%s' is not enabled", v.ARN.TargetID)
+		}
+	}
+
+	if err := s.LDAPServerConfig.Validate(); err != nil {
+		return fmt.Errorf("ldapserverconfig: %s", err)
+	}
+
+	return nil
+}
+
+// notificationTargetFns - creates target of each type from its arguments.
+// Arguments of a type are kept in the notifier map whose JSON name is the
+// type, and have Enable, QueueDir and QueueLimit fields.
+var notificationTargetFns = map[string]func(id string, args interface{}) (event.Target, error){
+	"amqp": func(id string, args interface{}) (event.Target, error) {
+		return target.NewAMQPTarget(id, args.(target.AMQPArgs))
+	},
+	"elasticsearch": func(id string, args interface{}) (event.Target, error) {
+		return target.NewElasticsearchTarget(id, args.(target.ElasticsearchArgs))
+	},
+	"kafka": func(id string, args interface{}) (event.Target, error) {
+		return target.NewKafkaTarget(id, args.(target.KafkaArgs))
+	},
+	"mqtt": func(id string, args interface{}) (event.Target, error) {
+		return target.NewMQTTTarget(id, args.(target.MQTTArgs))
+	},
+	"mysql": func(id string, args interface{}) (event.Target, error) {
+		return target.NewMySQLTarget(id, args.(target.MySQLArgs))
+	},
+	"nats": func(id string, args interface{}) (event.Target, error) {
+		return target.NewNATSTarget(id, args.(target.NATSArgs))
+	},
+	"nsq": func(id string, args interface{}) (event.Target, error) {
+		return target.NewNSQTarget(id, args.(target.NSQArgs))
+	},
+	"postgresql": func(id string, args interface{}) (event.Target, error) {
+		return target.NewPostgreSQLTarget(id, args.(target.PostgreSQLArgs))
+	},
+	"redis": func(id string, args interface{}) (event.Target, error) {
+		return target.NewRedisTarget(id, args.(target.RedisArgs))
+	},
+	"webhook": func(id string, args interface{}) (event.Target, error) {
+		return target.NewWebhookTarget(id, args.(target.WebhookArgs)), nil
+	},
+}
+
+// getTargetArgsMap - returns the map of target arguments by ID of target
+// type name in notifier.
+func (n *notifier) getTargetArgsMap(name string) (reflect.Value, bool) {
+	if _, ok := notificationTargetFns[name]; !ok {
+		return reflect.Value{}, false
+	}
+
+	v := reflect.ValueOf(n).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("json") == name {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// getNotificationTargetArgs - returns the arguments of target by ID.
+func (s *serverConfig) getNotificationTargetArgs(id event.TargetID) (reflect.Value, bool) {
+	argsMap, ok := s.Notify.getTargetArgsMap(id.Name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	args := argsMap.MapIndex(reflect.ValueOf(id.ID))
+	return args, args.IsValid()
+}
+
+// isNotificationTargetEnabled - returns whether the notification target
+// is configured and enabled.
+func (s *serverConfig) isNotificationTargetEnabled(id event.TargetID) bool {
+	args, ok := s.getNotificationTargetArgs(id)
+	return ok && args.FieldByName("Enable").Bool()
+}
+
+// getNotificationTargetIDs - returns IDs of all targets in serverConfig,
+// enabled or not.
+func (s *serverConfig) getNotificationTargetIDs() []event.TargetID {
+	targetIDs := []event.TargetID{}
+	for name := range notificationTargetFns {
+		argsMap, _ := s.Notify.getTargetArgsMap(name)
+		for _, id := range argsMap.MapKeys() {
+			targetIDs = append(targetIDs, event.TargetID{ID: id.String(), Name: name})
+		}
+	}
+
+	return targetIDs
+}
+
+// setNotificationTarget - adds or replaces target by ID with target
+// arguments in JSON data.
+func (s *serverConfig) setNotificationTarget(id event.TargetID, data []byte) error {
+	argsMap, ok := s.Notify.getTargetArgsMap(id.Name)
+	if !ok || id.ID == "" {
+		return errInvalidArgument
+	}
+
+	args := reflect.New(argsMap.Type().Elem())
+	if err := json.Unmarshal(data, args.Interface()); err != nil {
+		return err
+	}
+
+	if argsMap.IsNil() {
+		argsMap.Set(reflect.MakeMap(argsMap.Type()))
+	}
+	argsMap.SetMapIndex(reflect.ValueOf(id.ID), args.Elem())
+
+	return nil
+}
+
+// removeNotificationTarget - removes target by ID.
+func (s *serverConfig) removeNotificationTarget(id event.TargetID) error {
+	if _, ok := s.getNotificationTargetArgs(id); !ok {
+		return errNoSuchNotificationTarget
+	}
+
+	argsMap, _ := s.Notify.getTargetArgsMap(id.Name)
+	argsMap.SetMapIndex(reflect.ValueOf(id.ID), reflect.Value{})
+
 	return nil
 }
 
@@ -292,14 +424,20 @@ func (s *serverConfig) loadFromEnvs() {
 			s.Policy.OPA.AuthToken = os.Getenv("MINIO_IAM_OPA_AUTHTOKEN")
 		}
 	}
+
+	lookupLDAPConfigEnvs(&s.LDAPServerConfig)
+	if err := s.LDAPServerConfig.Validate(); err != nil {
+		logger.FatalIf(err, "Unable to setup the LDAP identity provider")
+	}
 }
 
 // TestNotificationTargets tries to establish connections to all notification
 // targets when enabled. This is a good way to make sure all configurations
-// set by the user can work.
+// set by the user can work. Targets with a queue directory are skipped, as
+// their events are queued until they are reachable.
 func (s *serverConfig) TestNotificationTargets() error {
 	for k, v := range s.Notify.AMQP {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewAMQPTarget(k, v)
@@ -310,7 +448,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.Elasticsearch {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewElasticsearchTarget(k, v)
@@ -321,7 +459,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.Kafka {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewKafkaTarget(k, v)
@@ -332,7 +470,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.MQTT {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewMQTTTarget(k, v)
@@ -343,7 +481,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.MySQL {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewMySQLTarget(k, v)
@@ -354,7 +492,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.NATS {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewNATSTarget(k, v)
@@ -365,7 +503,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.NSQ {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewNSQTarget(k, v)
@@ -376,7 +514,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.PostgreSQL {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewPostgreSQLTarget(k, v)
@@ -387,7 +525,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 	}
 
 	for k, v := range s.Notify.Redis {
-		if !v.Enable {
+		if !v.Enable || v.QueueDir != "" {
 			continue
 		}
 		t, err := target.NewRedisTarget(k, v)
@@ -549,6 +687,8 @@ func (s *serverConfig) loadToCachedConfigs() {
 		globalIAMValidators = getAuthValidators(s)
 	}
 
+	globalLDAPConfig = s.LDAPServerConfig
+
 	if globalPolicyOPA == nil {
 		if s.Policy.OPA.URL != nil && s.Policy.OPA.URL.String() != "" {
 			globalPolicyOPA = iampolicy.NewOpa(iampolicy.OpaArgs{
@@ -614,6 +754,20 @@ func loadConfig(objAPI ObjectLayer) error {
 	return nil
 }
 
+// loadNotificationTarget - reloads server config and replaces the target by
+// target ID in globalNotificationSys.
+func loadNotificationTarget(objAPI ObjectLayer, targetID event.TargetID) error {
+	if err := loadConfig(objAPI); err != nil {
+		return err
+	}
+
+	globalServerConfigMu.RLock()
+	config := globalServerConfig
+	globalServerConfigMu.RUnlock()
+
+	return globalNotificationSys.LoadTarget(config, targetID)
+}
+
 // getAuthValidators - returns ValidatorList which contains
 // enabled providers in server config.
 // A new authentication provider is added like below
@@ -628,153 +782,107 @@ func getAuthValidators(config *serverConfig) *validator.Validators {
 	return validators
 }
 
+// newNotificationTarget - creates notification target by newTarget. Events
+// of targets with a queue directory are saved in the directory first and
+// replayed to the target in order, so they are not lost while the target
+// is not reachable.
+func newNotificationTarget(id event.TargetID, queueDir string, queueLimit uint64, newTarget func() (event.Target, error)) (event.Target, error) {
+	if queueDir == "" {
+		return newTarget()
+	}
+
+	return target.NewQueueTarget(id, queueDir, queueLimit, newTarget, func(err error) {
+		logger.LogOnceIf(context.Background(), err, id)
+	})
+}
+
+// getNotificationTargetFn - returns function creating target by ID in
+// serverConfig whether enabled or not, and the queue directory and limit
+// of the target.
+func (s *serverConfig) getNotificationTargetFn(id event.TargetID) (newTarget func() (event.Target, error), queueDir string, queueLimit uint64, err error) {
+	args, ok := s.getNotificationTargetArgs(id)
+	if !ok {
+		return nil, "", 0, errNoSuchNotificationTarget
+	}
+
+	newTarget = func() (event.Target, error) {
+		return notificationTargetFns[id.Name](id.ID, args.Interface())
+	}
+
+	return newTarget, args.FieldByName("QueueDir").String(), args.FieldByName("QueueLimit").Uint(), nil
+}
+
+// testNotificationTarget - checks whether enabled target by ID is
+// reachable. Targets with a queue directory are not checked as their
+// events are queued while they are not reachable.
+func (s *serverConfig) testNotificationTarget(id event.TargetID) error {
+	newTarget, queueDir, _, err := s.getNotificationTargetFn(id)
+	if err != nil {
+		return err
+	}
+
+	if !s.isNotificationTargetEnabled(id) || queueDir != "" {
+		return nil
+	}
+
+	t, err := newTarget()
+	if err != nil {
+		return fmt.Errorf("%s(%s): %s", id.Name, id.ID, err.Error())
+	}
+
+	return t.Close()
+}
+
 // getNotificationTargets - returns TargetList which contains enabled targets in serverConfig.
 // A new notification target is added like below
 // * Add a new target in pkg/event/target package.
 // * Add newly added target configuration to serverConfig.Notify.<TARGET_NAME>.
-// * Handle the configuration in this function to create/add into TargetList.
+// * Add the target constructor to notificationTargetFns.
 func getNotificationTargets(config *serverConfig) *event.TargetList {
 	targetList := event.NewTargetList()
 	if config == nil {
 		return targetList
 	}
-	for id, args := range config.Notify.AMQP {
-		if args.Enable {
-			newTarget, err := target.NewAMQPTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.Elasticsearch {
-		if args.Enable {
-			newTarget, err := target.NewElasticsearchTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-
-			}
-		}
-	}
-
-	for id, args := range config.Notify.Kafka {
-		if args.Enable {
-			newTarget, err := target.NewKafkaTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.MQTT {
-		if args.Enable {
-			newTarget, err := target.NewMQTTTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.MySQL {
-		if args.Enable {
-			newTarget, err := target.NewMySQLTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.NATS {
-		if args.Enable {
-			newTarget, err := target.NewNATSTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.NSQ {
-		if args.Enable {
-			newTarget, err := target.NewNSQTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.PostgreSQL {
-		if args.Enable {
-			newTarget, err := target.NewPostgreSQLTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-		}
-	}
-
-	for id, args := range config.Notify.Redis {
-		if args.Enable {
-			newTarget, err := target.NewRedisTarget(id, args)
-			if err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
-			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
+
+	for _, id := range config.getNotificationTargetIDs() {
+		if !config.isNotificationTargetEnabled(id) {
+			continue
 		}
-	}
 
-	for id, args := range config.Notify.Webhook {
-		if args.Enable {
-			newTarget := target.NewWebhookTarget(id, args)
-			if err := targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
-				continue
-			}
+		newTargetFn, queueDir, queueLimit, err := config.getNotificationTargetFn(id)
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		newTarget, err := newNotificationTarget(id, queueDir, queueLimit, newTargetFn)
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
 		}
 	}
 
 	return targetList
 }
+
+// getNotificationSubscriptions - returns rules map of server wide
+// subscriptions to targets in target list.
+func getNotificationSubscriptions(config *serverConfig, targetList *event.TargetList) event.RulesMap {
+	rulesMap := make(event.RulesMap)
+	if config == nil {
+		return rulesMap
+	}
+
+	for _, subscription := range config.Notify.Subscriptions {
+		if !targetList.Exists(subscription.ARN.TargetID) {
+			logger.LogIf(context.Background(), fmt.Errorf("subscription target %s not found", subscription.ARN.TargetID))
+			continue
+		}
+		rulesMap.Add(subscription.ToRulesMap())
+	}
+
+	return rulesMap
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/event"
 	"github.com/scriptburn/minio/pkg/event/target"
 )
 
@@ -237,6 +238,15 @@ func TestValidateConfig(t *testing.T) {
 
 		// Test 28 - Test NSQ
 		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "notify": { "nsq": { "1": { "enable": true, "nsqdAddress": "", "topic": ""} }}}`, false},
+
+		// Test 29 - Test subscription to an enabled target
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "notify": { "webhook": { "1": { "enable": true, "endpoint": "http://localhost:8080"} }, "subscriptions": [{"arn": "arn:minio:sqs::1:webhook", "events": ["s3:BucketCreated", "s3:BucketRemoved"]}]}}`, true},
+
+		// Test 30 - Test subscription to a target which is not configured
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "notify": { "subscriptions": [{"arn": "arn:minio:sqs::1:webhook", "events": ["s3:BucketCreated"]}]}}`, false},
+
+		// Test 31 - Test subscription without events
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "notify": { "webhook": { "1": { "enable": true, "endpoint": "http://localhost:8080"} }, "subscriptions": [{"arn": "arn:minio:sqs::1:webhook", "events": []}]}}`, false},
 	}
 
 	for i, testCase := range testCases {
@@ -364,3 +374,35 @@ func TestConfigDiff(t *testing.T) {
 		}
 	}
 }
+
+func TestNotificationTargetConfig(t *testing.T) {
+	config := &serverConfig{}
+	webhookID := event.TargetID{ID: "1", Name: "webhook"}
+
+	if err := config.setNotificationTarget(event.TargetID{ID: "1", Name: "unknown"}, []byte(`{}`)); err != errInvalidArgument {
+		t.Fatalf("Expected errInvalidArgument for unknown target type, got %v", err)
+	}
+	if err := config.setNotificationTarget(webhookID, []byte(`{"enable":true,"endpoint":"http://localhost:8080","queueDir":"/tmp/events","queueLimit":10}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if targetIDs := config.getNotificationTargetIDs(); len(targetIDs) != 1 || targetIDs[0] != webhookID {
+		t.Fatalf("Expected target IDs [%v], got %v", webhookID, targetIDs)
+	}
+	if !config.isNotificationTargetEnabled(webhookID) {
+		t.Fatalf("Expected target %v to be enabled", webhookID)
+	}
+	if _, queueDir, queueLimit, err := config.getNotificationTargetFn(webhookID); err != nil || queueDir != "/tmp/events" || queueLimit != 10 {
+		t.Fatalf("Unexpected queue directory %v and limit %v of target %v: %v", queueDir, queueLimit, webhookID, err)
+	}
+
+	if err := config.removeNotificationTarget(webhookID); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.removeNotificationTarget(webhookID); err != errNoSuchNotificationTarget {
+		t.Fatalf("Expected errNoSuchNotificationTarget, got %v", err)
+	}
+	if _, _, _, err := config.getNotificationTargetFn(webhookID); err != errNoSuchNotificationTarget {
+		t.Fatalf("Expected errNoSuchNotificationTarget, got %v", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/scriptburn/minio/cmd/crypto"
 	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/event"
 	"github.com/scriptburn/minio/pkg/event/target"
 	"github.com/scriptburn/minio/pkg/iam/policy"
 	"github.com/scriptburn/minio/pkg/iam/validator"
@@ -849,6 +850,9 @@ type notifier struct {
 	PostgreSQL    map[string]target.PostgreSQLArgs    `json:"postgresql"`
 	Redis         map[string]target.RedisArgs         `json:"redis"`
 	Webhook       map[string]target.WebhookArgs       `json:"webhook"`
+
+	// Server wide subscriptions of targets to events of all buckets.
+	Subscriptions []event.Subscription `json:"subscriptions,omitempty"`
 }
 
 // serverConfigV32 is just like version '31' with added nsq notifer.
@@ -935,4 +939,7 @@ type serverConfigV33 struct {
 
 		// Add new external policy enforcements here.
 	} `json:"policy"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
 }
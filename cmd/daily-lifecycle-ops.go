@@ -0,0 +1,178 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+)
+
+const (
+	// Interval between two lifecycle sweeps.
+	bgLifecycleInterval = 24 * time.Hour
+
+	// Interval at which nodes check whether a lifecycle sweep is due.
+	bgLifecycleCheckInterval = time.Hour
+
+	// Maximum delay of the first check after the server starts, spreads
+	// the checks of servers started together.
+	bgLifecycleStartJitter = 5 * time.Minute
+
+	// Lock held while deciding which node runs a lifecycle sweep.
+	bgLifecycleLockFile = "lifecycle-sweep.lock"
+)
+
+// Time of the last lifecycle sweep, shared by all nodes so that sweeps
+// are not delayed by server restarts.
+var bgLifecycleSweepFile = path.Join(minioConfigPrefix, "lifecycle-sweep.json")
+
+// initDailyLifecycle - starts the routine applying the lifecycle
+// expiration rules of all buckets once a day.
+func initDailyLifecycle() {
+	go startDailyLifecycle()
+}
+
+func startDailyLifecycle() {
+	timer := time.NewTimer(time.Duration(globalRandomSource.Float64() * float64(bgLifecycleStartJitter)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-timer.C:
+			timer.Reset(bgLifecycleCheckInterval)
+
+			objAPI := newObjectLayerFn()
+			if objAPI == nil {
+				continue
+			}
+
+			ctx := context.Background()
+			if claimLifecycleSweep(ctx, objAPI) {
+				lifecycleRound(ctx, objAPI)
+			}
+		}
+	}
+}
+
+// claimLifecycleSweep - returns true if this node has to run the
+// lifecycle sweep.
+func claimLifecycleSweep(ctx context.Context, objAPI ObjectLayer) bool {
+	return claimBackgroundRound(ctx, objAPI, bgLifecycleLockFile, bgLifecycleSweepFile, bgLifecycleInterval)
+}
+
+// lifecycleRound - walks the objects of all buckets having a lifecycle
+// configuration and removes the ones whose expiration rule matches.
+func lifecycleRound(ctx context.Context, objAPI ObjectLayer) {
+	for bucket, lc := range globalLifecycleSys.List() {
+		for _, prefix := range getExpirationPrefixes(lc) {
+			marker := ""
+			for {
+				res, err := objAPI.ListObjects(ctx, bucket, prefix, marker, "", maxObjectList)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					break
+				}
+
+				now := UTCNow()
+				for _, obj := range res.Objects {
+					if lc.ComputeAction(obj.Name, obj.ModTime, now) != lifecycle.DeleteAction {
+						continue
+					}
+					if err = expireObject(ctx, objAPI, bucket, obj.Name); err != nil {
+						logger.LogIf(ctx, err)
+					}
+				}
+
+				if !res.IsTruncated {
+					break
+				}
+				marker = res.NextMarker
+			}
+		}
+	}
+}
+
+// getExpirationPrefixes - returns the prefixes of the enabled expiration
+// rules, prefixes covered by a shorter one are dropped so that no object
+// is visited twice.
+func getExpirationPrefixes(lc lifecycle.Lifecycle) []string {
+	var prefixes []string
+	for _, rule := range lc.Rules {
+		if rule.Status == lifecycle.Enabled && rule.Expiration != nil {
+			prefixes = append(prefixes, rule.GetPrefix())
+		}
+	}
+	sort.Strings(prefixes)
+
+	var result []string
+	for _, prefix := range prefixes {
+		if len(result) > 0 && strings.HasPrefix(prefix, result[len(result)-1]) {
+			continue
+		}
+		result = append(result, prefix)
+	}
+	return result
+}
+
+// expireObject - removes an expired object, on versioned buckets a delete
+// marker becomes the current version of the object.
+func expireObject(ctx context.Context, objAPI ObjectLayer, bucket, object string) error {
+	var opts ObjectOptions
+	if objAPI.IsVersioningSupported() {
+		setVersioningOpts(&opts, bucket, "")
+	}
+
+	// Objects protected by object lock are kept until they are released.
+	if err := enforceObjectLock(ctx, objAPI, nil, bucket, object, opts); err != nil {
+		if _, ok := err.(ObjectLocked); ok {
+			return nil
+		}
+		return err
+	}
+
+	size := getQuotaObjectSize(ctx, objAPI, bucket, object, opts)
+	objInfo := ObjectInfo{Bucket: bucket, Name: object}
+	if objAPI.IsVersioningSupported() {
+		deletedInfo, err := objAPI.DeleteObjectVersion(ctx, bucket, object, opts)
+		if err != nil {
+			return err
+		}
+		objInfo = deletedInfo
+	} else if err := objAPI.DeleteObject(ctx, bucket, object); err != nil {
+		return err
+	}
+	freeBucketUsage(bucket, size)
+	replicateDeleteAsync(bucket, object)
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  getObjectRemovedEventName(objInfo, opts.VersionID),
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [ILM-EXPIRY]",
+	})
+
+	return nil
+}
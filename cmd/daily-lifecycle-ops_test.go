@@ -0,0 +1,133 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scriptburn/minio/pkg/lifecycle"
+	"github.com/scriptburn/minio/pkg/replication"
+)
+
+func TestGetExpirationPrefixes(t *testing.T) {
+	testCases := []struct {
+		data             string
+		expectedPrefixes []string
+	}{
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><Filter><Prefix>logs/old/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><Filter><Prefix>tmp/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			[]string{"logs/", "tmp/"}},
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><Filter><Prefix></Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			[]string{""}},
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Disabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><Filter><Prefix>tmp/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			nil},
+	}
+
+	for i, testCase := range testCases {
+		lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if prefixes := getExpirationPrefixes(*lc); !reflect.DeepEqual(prefixes, testCase.expectedPrefixes) {
+			t.Fatalf("Test %d: expected: %v, got: %v", i+1, testCase.expectedPrefixes, prefixes)
+		}
+	}
+}
+
+// Wrapper for calling lifecycle sweep tests for both XL multiple disks and single node setup.
+func TestLifecycleRound(t *testing.T) {
+	ExecObjectLayerTest(t, testLifecycleRound)
+}
+
+func testLifecycleRound(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucketName := "lifecycle-bucket"
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s: Failed to make bucket: %v", instanceType, err)
+	}
+
+	for _, object := range []string{"logs/a", "logs/b/c", "data/a"} {
+		data := []byte("hello")
+		if _, err := obj.PutObject(context.Background(), bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: Failed to put object %s: %v", instanceType, object, err)
+		}
+	}
+
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Date>2019-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatalf("%s: Failed to parse lifecycle configuration: %v", instanceType, err)
+	}
+	globalLifecycleSys.Set(bucketName, *lc)
+	defer globalLifecycleSys.Remove(bucketName)
+
+	config, err := replication.ParseConfig(strings.NewReader(`<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix>logs/b/</Prefix><Destination><Bucket>arn:aws:s3:::backup</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatalf("%s: Failed to parse replication configuration: %v", instanceType, err)
+	}
+	globalBucketReplicationSys.Set(bucketName, *config)
+	defer globalBucketReplicationSys.Remove(bucketName)
+
+	lifecycleRound(context.Background(), obj)
+
+	// Expired objects are removed from the replication target too.
+	select {
+	case task := <-globalBucketReplicationSys.queue:
+		if !task.delete || task.object.Name != "logs/b/c" {
+			t.Fatalf("%s: Expected removal of logs/b/c to be replicated, found %v", instanceType, task)
+		}
+	default:
+		t.Fatalf("%s: Expected removal of logs/b/c to be replicated", instanceType)
+	}
+
+	result, err := obj.ListObjects(context.Background(), bucketName, "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("%s: Failed to list objects: %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "data/a" {
+		t.Fatalf("%s: Expected only data/a to be left, found %v", instanceType, result.Objects)
+	}
+}
+
+// Wrapper for calling lifecycle sweep claim tests for both XL multiple disks and single node setup.
+func TestClaimLifecycleSweep(t *testing.T) {
+	ExecObjectLayerTest(t, testClaimLifecycleSweep)
+}
+
+func testClaimLifecycleSweep(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	if !claimLifecycleSweep(ctx, obj) {
+		t.Fatalf("%s: Expected the first sweep to be claimed", instanceType)
+	}
+
+	// A restarted or another node does not sweep again within the interval.
+	if claimLifecycleSweep(ctx, obj) {
+		t.Fatalf("%s: Expected the sweep not to be claimed twice within the interval", instanceType)
+	}
+
+	data, err := UTCNow().Add(-bgLifecycleInterval).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, obj, bgLifecycleSweepFile, data); err != nil {
+		t.Fatalf("%s: Failed to save last sweep time: %v", instanceType, err)
+	}
+	if !claimLifecycleSweep(ctx, obj) {
+		t.Fatalf("%s: Expected the sweep to be claimed after the interval", instanceType)
+	}
+}
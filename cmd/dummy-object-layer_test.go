@@ -21,8 +21,14 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
 	"github.com/scriptburn/minio/pkg/madmin"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 type DummyObjectLayer struct{}
@@ -83,6 +89,14 @@ func (api *DummyObjectLayer) DeleteObject(ctx context.Context, bucket, object st
 	return
 }
 
+func (api *DummyObjectLayer) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	return
+}
+
 func (api *DummyObjectLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
 	return
 }
@@ -151,6 +165,78 @@ func (api *DummyObjectLayer) DeleteBucketPolicy(context.Context, string) (err er
 	return
 }
 
+func (api *DummyObjectLayer) SetBucketLifecycle(context.Context, string, *lifecycle.Lifecycle) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetBucketLifecycle(context.Context, string) (lifecycle *lifecycle.Lifecycle, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteBucketLifecycle(context.Context, string) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) SetBucketObjectLockConfig(context.Context, string, *objectlock.Config) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetBucketObjectLockConfig(context.Context, string) (config *objectlock.Config, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) SetBucketCORS(context.Context, string, *cors.Config) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetBucketCORS(context.Context, string) (config *cors.Config, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteBucketCORS(context.Context, string) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) SetBucketSSEConfig(context.Context, string, *sse.BucketSSEConfig) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetBucketSSEConfig(context.Context, string) (config *sse.BucketSSEConfig, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteBucketSSEConfig(context.Context, string) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) SetBucketReplication(context.Context, string, *replication.Config) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetBucketReplication(context.Context, string) (config *replication.Config, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteBucketReplication(context.Context, string) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (tags *tagging.Tagging, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (err error) {
+	return
+}
+
 func (api *DummyObjectLayer) IsNotificationSupported() (b bool) {
 	return
 }
@@ -162,6 +248,10 @@ func (api *DummyObjectLayer) IsEncryptionSupported() (b bool) {
 	return
 }
 
+func (api *DummyObjectLayer) IsVersioningSupported() (b bool) {
+	return
+}
+
 func (api *DummyObjectLayer) IsCompressionSupported() (b bool) {
 	return
 }
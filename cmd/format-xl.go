@@ -786,6 +786,11 @@ func makeFormatXLMetaVolumes(disk StorageAPI) error {
 			return err
 		}
 	}
+	if err := disk.MakeVol(minioMetaVersionsBucket); err != nil {
+		if !IsErrIgnored(err, initMetaVolIgnoredErrs...) {
+			return err
+		}
+	}
 	return nil
 }
 
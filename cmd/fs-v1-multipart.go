@@ -232,6 +232,12 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = meta
 
+	// Remember the object path, lifecycle rules abort uploads by prefix.
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	fsMeta.Meta[lifecycleUploadPathKey] = pathJoin(bucket, object)
+
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -662,6 +668,10 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+
+	// The upload path is only needed while the upload is incomplete.
+	delete(fsMeta.Meta, lifecycleUploadPathKey)
+
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
@@ -742,6 +752,22 @@ func (fs *FSObjects) AbortMultipartUpload(ctx context.Context, bucket, object, u
 	return nil
 }
 
+// isUploadExpiredByLifecycle - returns true if the upload in the given
+// upload directory has to be aborted as per its bucket lifecycle.
+func (fs *FSObjects) isUploadExpiredByLifecycle(uploadIDDir string, modTime time.Time) bool {
+	fsMetaBuf, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
+	if err != nil {
+		return false
+	}
+
+	var fsMeta fsMetaV1
+	if err = json.Unmarshal(fsMetaBuf, &fsMeta); err != nil {
+		return false
+	}
+
+	return isUploadExpiredByLifecycle(fsMeta.Meta, modTime)
+}
+
 // Removes multipart uploads if any older than `expiry` duration
 // on all buckets for every `cleanupInterval`, this function is
 // blocking and should be run in a go-routine.
@@ -769,7 +795,11 @@ func (fs *FSObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupIn
 					if err != nil {
 						continue
 					}
-					if now.Sub(fi.ModTime()) > expiry {
+					expired := now.Sub(fi.ModTime()) > expiry
+					if !expired && !globalLifecycleSys.IsEmpty() {
+						expired = fs.isUploadExpiredByLifecycle(pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID), fi.ModTime())
+					}
+					if expired {
 						fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID))
 						// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 						fsRemoveDir(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry))
@@ -31,11 +31,17 @@ import (
 	"time"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
 	"github.com/scriptburn/minio/pkg/lock"
 	"github.com/scriptburn/minio/pkg/madmin"
 	"github.com/scriptburn/minio/pkg/mimedb"
 	"github.com/scriptburn/minio/pkg/mountinfo"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // Default etag is used for pre-existing objects.
@@ -1002,6 +1008,12 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 	return nil
 }
 
+// DeleteObjectVersion - deletes a version of an object. Valid only for XL
+func (fs *FSObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return ObjectInfo{}, NotImplemented{}
+}
+
 // Returns function "listDir" of the type listDirFunc.
 // isLeaf - is used by listDir function to check if an entry
 // is a leaf or non-leaf entry.
@@ -1264,6 +1276,12 @@ func (fs *FSObjects) ListObjectsHeal(ctx context.Context, bucket, prefix, marker
 	return loi, NotImplemented{}
 }
 
+// ListObjectVersions - list all versions of objects. Valid only for XL
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return result, NotImplemented{}
+}
+
 // ListBucketsHeal - list all buckets to be healed. Valid only for XL
 func (fs *FSObjects) ListBucketsHeal(ctx context.Context) ([]BucketInfo, error) {
 	logger.LogIf(ctx, NotImplemented{})
@@ -1285,6 +1303,153 @@ func (fs *FSObjects) DeleteBucketPolicy(ctx context.Context, bucket string) erro
 	return removePolicyConfig(ctx, fs, bucket)
 }
 
+// SetBucketLifecycle sets lifecycle on bucket
+func (fs *FSObjects) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	return saveLifecycleConfig(ctx, fs, bucket, lifecycle)
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (fs *FSObjects) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return getLifecycleConfig(fs, bucket)
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (fs *FSObjects) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return removeLifecycleConfig(ctx, fs, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (fs *FSObjects) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (fs *FSObjects) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(fs, bucket)
+}
+
+// SetBucketCORS sets CORS configuration on bucket
+func (fs *FSObjects) SetBucketCORS(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveCORSConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketCORS will get CORS configuration on bucket
+func (fs *FSObjects) GetBucketCORS(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getCORSConfig(fs, bucket)
+}
+
+// DeleteBucketCORS deletes CORS configuration on bucket
+func (fs *FSObjects) DeleteBucketCORS(ctx context.Context, bucket string) error {
+	return removeCORSConfig(ctx, fs, bucket)
+}
+
+// SetBucketSSEConfig sets server side encryption configuration on bucket
+func (fs *FSObjects) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.BucketSSEConfig) error {
+	return saveBucketSSEConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketSSEConfig will get server side encryption configuration on bucket
+func (fs *FSObjects) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.BucketSSEConfig, error) {
+	return getBucketSSEConfig(fs, bucket)
+}
+
+// DeleteBucketSSEConfig deletes server side encryption configuration on bucket
+func (fs *FSObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, fs, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (fs *FSObjects) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (fs *FSObjects) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(fs, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (fs *FSObjects) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, fs, bucket)
+}
+
+// PutObjectTags - replaces the tags of an object, tags are kept URL
+// encoded in `fs.json` along with the user defined metadata.
+func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
+	return fs.UpdateObjectMetadata(ctx, bucket, object, map[string]string{amzObjectTagging: tags}, opts)
+}
+
+// UpdateObjectMetadata - updates the given metadata keys of an object
+// in `fs.json`, keys with an empty value are removed.
+func (fs *FSObjects) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error {
+	// Lock the object before updating its metadata.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
+		return toObjectErr(err, bucket)
+	}
+
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	if err == errFileNotFound {
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	// Default metadata is kept for pre-existing data without `fs.json`.
+	fsMeta := fs.defaultFsJSON(object)
+	if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil && err != io.EOF {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if opts.CheckPrecondFn != nil && opts.CheckPrecondFn(fsMeta.ToObjectInfo(bucket, object, fi)) {
+		return PreconditionFailed{Bucket: bucket, Object: object}
+	}
+
+	for k, v := range metadata {
+		if v == "" {
+			delete(fsMeta.Meta, k)
+		} else {
+			fsMeta.Meta[k] = v
+		}
+	}
+
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	return nil
+}
+
+// GetObjectTags - returns the tags of an object.
+func (fs *FSObjects) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tagging.Tagging, error) {
+	objInfo, err := fs.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return getObjectTags(objInfo)
+}
+
+// DeleteObjectTags - removes all the tags of an object.
+func (fs *FSObjects) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return fs.PutObjectTags(ctx, bucket, object, "", opts)
+}
+
 // ListObjectsV2 lists all blobs in bucket filtered by prefix
 func (fs *FSObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
 	marker := continuationToken
@@ -1322,6 +1487,11 @@ func (fs *FSObjects) IsEncryptionSupported() bool {
 	return true
 }
 
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (fs *FSObjects) IsVersioningSupported() bool {
+	return false
+}
+
 // IsCompressionSupported returns whether compression is applicable for this layer.
 func (fs *FSObjects) IsCompressionSupported() bool {
 	return true
@@ -20,8 +20,14 @@ import (
 	"context"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
 	"github.com/scriptburn/minio/pkg/madmin"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // GatewayUnsupported list of unsupported call stubs for gateway.
@@ -81,6 +87,103 @@ func (a GatewayUnsupported) DeleteBucketPolicy(ctx context.Context, bucket strin
 	return NotImplemented{}
 }
 
+// SetBucketLifecycle sets lifecycle on bucket
+func (a GatewayUnsupported) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (a GatewayUnsupported) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (a GatewayUnsupported) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (a GatewayUnsupported) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (a GatewayUnsupported) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// SetBucketCORS sets CORS configuration on bucket
+func (a GatewayUnsupported) SetBucketCORS(ctx context.Context, bucket string, config *cors.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketCORS will get CORS configuration on bucket
+func (a GatewayUnsupported) GetBucketCORS(ctx context.Context, bucket string) (*cors.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketCORS deletes CORS configuration on bucket
+func (a GatewayUnsupported) DeleteBucketCORS(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketSSEConfig sets server side encryption configuration on bucket
+func (a GatewayUnsupported) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.BucketSSEConfig) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketSSEConfig will get server side encryption configuration on bucket
+func (a GatewayUnsupported) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.BucketSSEConfig, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketSSEConfig deletes server side encryption configuration on bucket
+func (a GatewayUnsupported) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (a GatewayUnsupported) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (a GatewayUnsupported) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (a GatewayUnsupported) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// PutObjectTags - Not implemented stub.
+func (a GatewayUnsupported) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetObjectTags - Not implemented stub.
+func (a GatewayUnsupported) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tagging.Tagging, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteObjectTags - Not implemented stub.
+func (a GatewayUnsupported) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return NotImplemented{}
+}
+
+// UpdateObjectMetadata - Not implemented stub.
+func (a GatewayUnsupported) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
 // ReloadFormat - Not implemented stub.
 func (a GatewayUnsupported) ReloadFormat(ctx context.Context, dryRun bool) error {
 	return NotImplemented{}
@@ -122,6 +225,16 @@ func (a GatewayUnsupported) CopyObject(ctx context.Context, srcBucket string, sr
 	return objInfo, NotImplemented{}
 }
 
+// DeleteObjectVersion - Not implemented stub
+func (a GatewayUnsupported) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
+// ListObjectVersions - Not implemented stub
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	return result, NotImplemented{}
+}
+
 // RefreshBucketPolicy refreshes cache policy with what's on disk.
 func (a GatewayUnsupported) RefreshBucketPolicy(ctx context.Context, bucket string) error {
 	return NotImplemented{}
@@ -142,6 +255,11 @@ func (a GatewayUnsupported) IsEncryptionSupported() bool {
 	return false
 }
 
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (a GatewayUnsupported) IsVersioningSupported() bool {
+	return false
+}
+
 // IsCompressionSupported returns whether compression is applicable for this layer.
 func (a GatewayUnsupported) IsCompressionSupported() bool {
 	return false
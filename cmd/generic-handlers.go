@@ -21,15 +21,16 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/minio/minio-go/pkg/set"
 
 	humanize "github.com/dustin/go-humanize"
-	"github.com/rs/cors"
 	"github.com/scriptburn/minio/cmd/crypto"
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
 	"github.com/scriptburn/minio/pkg/dns"
 	"github.com/scriptburn/minio/pkg/handlers"
 	"github.com/scriptburn/minio/pkg/sys"
@@ -381,38 +382,97 @@ type resourceHandler struct {
 	handler http.Handler
 }
 
-// List of default allowable HTTP methods.
-var defaultAllowableHTTPMethods = []string{
-	http.MethodGet,
-	http.MethodPut,
-	http.MethodHead,
-	http.MethodPost,
-	http.MethodDelete,
-	http.MethodOptions,
+type corsHandler struct {
+	handler http.Handler
 }
 
-// setCorsHandler handler for CORS (Cross Origin Resource Sharing)
+// setCorsHandler handler for CORS (Cross Origin Resource Sharing), cross
+// origin requests are only allowed as per the CORS configuration of the
+// bucket they are addressed to.
 func setCorsHandler(h http.Handler) http.Handler {
-	commonS3Headers := []string{
-		"Date",
-		"ETag",
-		"Server",
-		"Connection",
-		"Accept-Ranges",
-		"Content-Range",
-		"Content-Encoding",
-		"Content-Length",
-		"Content-Type",
-		"x-amz-request-id",
-	}
-	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   defaultAllowableHTTPMethods,
-		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   commonS3Headers,
-		AllowCredentials: true,
-	})
-	return c.Handler(h)
+	return corsHandler{h}
+}
+
+// getRequestBucketName - returns the bucket name of path style and
+// virtual host style requests.
+func getRequestBucketName(r *http.Request) string {
+	if globalDomainName != "" {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if strings.HasSuffix(host, "."+globalDomainName) {
+			return strings.TrimSuffix(host, "."+globalDomainName)
+		}
+	}
+
+	bucket, _ := urlPath2BucketObjectName(r.URL.Path)
+	return bucket
+}
+
+func (h corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// Not a cross origin request.
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	method := r.Method
+	var headers []string
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	if preflight {
+		method = r.Header.Get("Access-Control-Request-Method")
+		for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, header)
+			}
+		}
+	}
+
+	var rule cors.Rule
+	config, ok := globalBucketCORSSys.Get(getRequestBucketName(r))
+	if ok {
+		// Responses depend on the origin of the request.
+		w.Header().Add("Vary", "Origin")
+		rule, ok = config.Match(origin, method, headers)
+	}
+
+	if !ok {
+		if preflight {
+			writeErrorResponse(w, ErrCORSForbidden, r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		// Cross origin request is served without CORS headers,
+		// browsers do not expose the response to the origin.
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if rule.AllowsAnyOrigin() {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if preflight {
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(rule.AllowedMethods, ", "))
+		if len(headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(rule.ExposeHeaders, ", "))
+	}
+	h.handler.ServeHTTP(w, r)
 }
 
 // setIgnoreResourcesHandler -
@@ -455,14 +515,9 @@ func ignoreNotImplementedObjectResources(req *http.Request) bool {
 // List of not implemented bucket queries
 var notimplementedBucketResourceNames = map[string]bool{
 	"acl":            true,
-	"cors":           true,
-	"lifecycle":      true,
 	"logging":        true,
-	"replication":    true,
 	"tagging":        true,
-	"versions":       true,
 	"requestPayment": true,
-	"versioning":     true,
 	"website":        true,
 	"inventory":      true,
 	"metrics":        true,
@@ -474,7 +529,6 @@ var notimplementedObjectResourceNames = map[string]bool{
 	"torrent": true,
 	"acl":     true,
 	"policy":  true,
-	"tagging": true,
 	"restore": true,
 }
 
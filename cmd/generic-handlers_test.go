@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/scriptburn/minio/cmd/crypto"
+	"github.com/scriptburn/minio/pkg/cors"
 )
 
 // Tests getRedirectLocation function for all its criteria.
@@ -228,3 +229,90 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+var corsHandlerTests = []struct {
+	Method          string
+	Path            string
+	Header          http.Header
+	ExpectedCode    int
+	ExpectedOrigin  string
+	ExpectedMethods string
+	ExpectedExpose  string
+}{
+	// Not a cross origin request.
+	{"GET", "/cors-bucket/object", http.Header{}, http.StatusOK, "", "", ""}, // 1
+	// Allowed cross origin request.
+	{"GET", "/cors-bucket/object", http.Header{"Origin": []string{"https://app.example.com"}}, http.StatusOK, "https://app.example.com", "", "ETag"}, // 2
+	// Cross origin request without matching rule is served without CORS headers.
+	{"GET", "/cors-bucket/object", http.Header{"Origin": []string{"https://evil.com"}}, http.StatusOK, "", "", ""}, // 3
+	// Bucket without CORS configuration.
+	{"GET", "/other-bucket/object", http.Header{"Origin": []string{"https://app.example.com"}}, http.StatusOK, "", "", ""}, // 4
+	// Allowed preflight request.
+	{"OPTIONS", "/cors-bucket/object", http.Header{
+		"Origin":                         []string{"https://app.example.com"},
+		"Access-Control-Request-Method":  []string{"PUT"},
+		"Access-Control-Request-Headers": []string{"Content-Type"},
+	}, http.StatusOK, "https://app.example.com", "GET, PUT", ""}, // 5
+	// Preflight request with a method which is not allowed.
+	{"OPTIONS", "/cors-bucket/object", http.Header{
+		"Origin":                        []string{"https://app.example.com"},
+		"Access-Control-Request-Method": []string{"DELETE"},
+	}, http.StatusForbidden, "", "", ""}, // 6
+	// Preflight request with a header which is not allowed.
+	{"OPTIONS", "/cors-bucket/object", http.Header{
+		"Origin":                         []string{"https://app.example.com"},
+		"Access-Control-Request-Method":  []string{"PUT"},
+		"Access-Control-Request-Headers": []string{"Authorization"},
+	}, http.StatusForbidden, "", "", ""}, // 7
+	// Preflight request to a bucket without CORS configuration.
+	{"OPTIONS", "/other-bucket/object", http.Header{
+		"Origin":                        []string{"https://app.example.com"},
+		"Access-Control-Request-Method": []string{"GET"},
+	}, http.StatusForbidden, "", "", ""}, // 8
+	// Rule allowing any origin.
+	{"GET", "/public-bucket", http.Header{"Origin": []string{"https://evil.com"}}, http.StatusOK, "*", "", ""}, // 9
+}
+
+func TestCorsHandler(t *testing.T) {
+	globalBucketCORSSys.Set("cors-bucket", cors.Config{
+		Rules: []cors.Rule{{
+			AllowedOrigins: []string{"https://*.example.com"},
+			AllowedMethods: []string{"GET", "PUT"},
+			AllowedHeaders: []string{"Content-*"},
+			ExposeHeaders:  []string{"ETag"},
+		}},
+	})
+	globalBucketCORSSys.Set("public-bucket", cors.Config{
+		Rules: []cors.Rule{{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET"},
+		}},
+	})
+	defer globalBucketCORSSys.Remove("cors-bucket")
+	defer globalBucketCORSSys.Remove("public-bucket")
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	for i, test := range corsHandlerTests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.Method, test.Path, nil)
+		r.Header = test.Header
+
+		h := setCorsHandler(okHandler)
+		h.ServeHTTP(w, r)
+
+		if w.Code != test.ExpectedCode {
+			t.Errorf("Test %d: expected HTTP %d, got HTTP %d", i+1, test.ExpectedCode, w.Code)
+		}
+		if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != test.ExpectedOrigin {
+			t.Errorf("Test %d: expected allowed origin %q, got %q", i+1, test.ExpectedOrigin, origin)
+		}
+		if methods := w.Header().Get("Access-Control-Allow-Methods"); methods != test.ExpectedMethods {
+			t.Errorf("Test %d: expected allowed methods %q, got %q", i+1, test.ExpectedMethods, methods)
+		}
+		if expose := w.Header().Get("Access-Control-Expose-Headers"); expose != test.ExpectedExpose {
+			t.Errorf("Test %d: expected exposed headers %q, got %q", i+1, test.ExpectedExpose, expose)
+		}
+	}
+}
@@ -146,6 +146,36 @@ var (
 	globalPolicySys       *PolicySys
 	globalIAMSys          *IAMSys
 
+	// globalBucketVersioningSys bucket versioning system, always
+	// allocated so that layers without versioning see no configuration.
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// globalLifecycleSys bucket lifecycle system, always allocated
+	// so that layers without lifecycle support see no configuration.
+	globalLifecycleSys = NewLifecycleSys()
+
+	// globalBucketObjectLockSys bucket object lock system, always
+	// allocated so that layers without object lock see no configuration.
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+
+	// globalBucketCORSSys bucket CORS system, always allocated so that
+	// layers without CORS support see no configuration.
+	globalBucketCORSSys = NewBucketCORSSys()
+
+	// globalBucketSSEConfigSys bucket encryption configuration system,
+	// always allocated so that layers without encryption support see
+	// no configuration.
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// globalBucketReplicationSys bucket replication system, always
+	// allocated so that layers without replication support see no
+	// configuration.
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// globalBucketQuotaSys bucket quota system, always allocated so
+	// that layers without quota support see no quota.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -250,10 +280,10 @@ var (
 	globalCompressMimeTypes  = []string{"text/csv", "text/plain", "application/json"}
 
 	// Some standard object extensions which we strictly dis-allow for compression.
-	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z"}
+	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".zst", ".sz", ".lz4"}
 
 	// Some standard content-types which we strictly dis-allow for compression.
-	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon"}
+	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon", "application/zstd", "application/x-snappy-framed", "application/x-lz4"}
 
 	// Authorization validators list.
 	globalIAMValidators *validator.Validators
@@ -261,6 +291,9 @@ var (
 	// OPA policy system.
 	globalPolicyOPA *iampolicy.Opa
 
+	// LDAP identity provider configuration.
+	globalLDAPConfig ldapServerConfig
+
 	// Deployment ID - unique per deployment
 	globalDeploymentID string
 
@@ -0,0 +1,266 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/scriptburn/minio/cmd/logger"
+)
+
+// LDAP identity provider environment variables.
+const (
+	envLDAPServerAddr         = "MINIO_IAM_LDAP_SERVER_ADDR"
+	envLDAPTLSSkipVerify      = "MINIO_IAM_LDAP_TLS_SKIP_VERIFY"
+	envLDAPServerInsecure     = "MINIO_IAM_LDAP_SERVER_INSECURE"
+	envLDAPServerStartTLS     = "MINIO_IAM_LDAP_SERVER_STARTTLS"
+	envLDAPLookupBindDN       = "MINIO_IAM_LDAP_LOOKUP_BIND_DN"
+	envLDAPLookupBindPassword = "MINIO_IAM_LDAP_LOOKUP_BIND_PASSWORD"
+	envLDAPUserDNSearchBaseDN = "MINIO_IAM_LDAP_USER_DN_SEARCH_BASE_DN"
+	envLDAPUserDNSearchFilter = "MINIO_IAM_LDAP_USER_DN_SEARCH_FILTER"
+	envLDAPGroupSearchBaseDN  = "MINIO_IAM_LDAP_GROUP_SEARCH_BASE_DN"
+	envLDAPGroupSearchFilter  = "MINIO_IAM_LDAP_GROUP_SEARCH_FILTER"
+)
+
+var (
+	errLDAPNotConfigured  = errors.New("LDAP identity provider is not configured")
+	errLDAPUserNotFound   = errors.New("LDAP user not found")
+	errLDAPEmptyPassword  = errors.New("LDAP password must not be empty")
+	errLDAPUserNotUnique  = errors.New("LDAP user DN search filter matches multiple entries")
+	errLDAPInvalidDNInput = errors.New("LDAP distinguished name is invalid")
+)
+
+// ldapServerConfig - LDAP identity provider configuration, users are
+// looked up by the user DN search filter and authenticated by binding
+// with their DN and password.
+type ldapServerConfig struct {
+	// Address of the LDAP server as host:port.
+	ServerAddr string `json:"serverAddr"`
+
+	// TLS options, LDAP over TLS is used by default.
+	TLSSkipVerify  bool `json:"tlsSkipVerify"`
+	ServerInsecure bool `json:"serverInsecure"`
+	ServerStartTLS bool `json:"serverStartTLS"`
+
+	// Credentials used to look up users and groups, lookups are
+	// done with an anonymous bind if not set.
+	LookupBindDN       string `json:"lookupBindDN"`
+	LookupBindPassword string `json:"lookupBindPassword"`
+
+	// User DN search, '%s' in the filter is replaced by the username.
+	UserDNSearchBaseDN string `json:"userDNSearchBaseDN"`
+	UserDNSearchFilter string `json:"userDNSearchFilter"`
+
+	// Group search, '%s' in the filter is replaced by the username
+	// and '%d' by the user DN. Groups are not looked up if not set.
+	GroupSearchBaseDN string `json:"groupSearchBaseDN"`
+	GroupSearchFilter string `json:"groupSearchFilter"`
+}
+
+// IsEnabled - returns whether LDAP identity provider is configured.
+func (l ldapServerConfig) IsEnabled() bool {
+	return l.ServerAddr != ""
+}
+
+// Validate - validates LDAP identity provider configuration.
+func (l ldapServerConfig) Validate() error {
+	if !l.IsEnabled() {
+		return nil
+	}
+	if l.ServerInsecure && l.ServerStartTLS {
+		return errors.New("LDAP serverInsecure and serverStartTLS cannot be enabled together")
+	}
+	if l.UserDNSearchBaseDN == "" || l.UserDNSearchFilter == "" {
+		return errors.New("LDAP userDNSearchBaseDN and userDNSearchFilter must be set")
+	}
+	if !strings.Contains(l.UserDNSearchFilter, "%s") {
+		return errors.New("LDAP userDNSearchFilter must contain '%s' for the username")
+	}
+	if (l.GroupSearchBaseDN == "") != (l.GroupSearchFilter == "") {
+		return errors.New("LDAP groupSearchBaseDN and groupSearchFilter must be set together")
+	}
+	return nil
+}
+
+// lookupLDAPConfigEnvs - overrides LDAP identity provider configuration
+// from environment variables if set.
+func lookupLDAPConfigEnvs(l *ldapServerConfig) {
+	lookupString := func(env string, value *string) {
+		if v, ok := os.LookupEnv(env); ok {
+			*value = v
+		}
+	}
+	lookupBool := func(env string, value *bool) {
+		if v, ok := os.LookupEnv(env); ok {
+			bf, err := ParseBoolFlag(v)
+			logger.FatalIf(err, "Unable to parse %s", env)
+			*value = bool(bf)
+		}
+	}
+
+	lookupString(envLDAPServerAddr, &l.ServerAddr)
+	lookupBool(envLDAPTLSSkipVerify, &l.TLSSkipVerify)
+	lookupBool(envLDAPServerInsecure, &l.ServerInsecure)
+	lookupBool(envLDAPServerStartTLS, &l.ServerStartTLS)
+	lookupString(envLDAPLookupBindDN, &l.LookupBindDN)
+	lookupString(envLDAPLookupBindPassword, &l.LookupBindPassword)
+	lookupString(envLDAPUserDNSearchBaseDN, &l.UserDNSearchBaseDN)
+	lookupString(envLDAPUserDNSearchFilter, &l.UserDNSearchFilter)
+	lookupString(envLDAPGroupSearchBaseDN, &l.GroupSearchBaseDN)
+	lookupString(envLDAPGroupSearchFilter, &l.GroupSearchFilter)
+}
+
+// connect - connects to the LDAP server with configured TLS options.
+func (l ldapServerConfig) connect() (*ldap.Conn, error) {
+	if l.ServerInsecure {
+		return ldap.Dial("tcp", l.ServerAddr)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: l.TLSSkipVerify,
+		RootCAs:            globalRootCAs,
+	}
+	if l.ServerStartTLS {
+		conn, err := ldap.Dial("tcp", l.ServerAddr)
+		if err != nil {
+			return nil, err
+		}
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	return ldap.DialTLS("tcp", l.ServerAddr, tlsConfig)
+}
+
+// lookupBind - binds with the lookup credentials, anonymously if not set.
+func (l ldapServerConfig) lookupBind(conn *ldap.Conn) error {
+	if l.LookupBindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(l.LookupBindDN, l.LookupBindPassword)
+}
+
+// search - returns DNs of entries matching given filter under baseDN.
+func (l ldapServerConfig) search(conn *ldap.Conn, baseDN, filter string) ([]string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{"dn"},
+		nil,
+	)
+
+	searchResult, err := conn.Search(searchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var dns []string
+	for _, entry := range searchResult.Entries {
+		dns = append(dns, entry.DN)
+	}
+	return dns, nil
+}
+
+// Authenticate - authenticates given user with the LDAP server, returns
+// DN of the user and DNs of the groups the user is a member of.
+func (l ldapServerConfig) Authenticate(username, password string) (userDN string, groupDNs []string, err error) {
+	if !l.IsEnabled() {
+		return "", nil, errLDAPNotConfigured
+	}
+
+	// An empty password results in an unauthenticated bind, which
+	// LDAP servers may treat as a success.
+	if password == "" {
+		return "", nil, errLDAPEmptyPassword
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	if err = l.lookupBind(conn); err != nil {
+		return "", nil, err
+	}
+
+	filter := strings.Replace(l.UserDNSearchFilter, "%s", ldap.EscapeFilter(username), -1)
+	userDNs, err := l.search(conn, l.UserDNSearchBaseDN, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	switch len(userDNs) {
+	case 0:
+		return "", nil, errLDAPUserNotFound
+	case 1:
+		userDN = userDNs[0]
+	default:
+		return "", nil, errLDAPUserNotUnique
+	}
+
+	// Authenticate the user by binding with its DN and password.
+	if err = conn.Bind(userDN, password); err != nil {
+		return "", nil, err
+	}
+
+	if l.GroupSearchFilter == "" {
+		return userDN, nil, nil
+	}
+
+	// Users may not be allowed to search for groups, look up groups
+	// with the lookup credentials.
+	if err = l.lookupBind(conn); err != nil {
+		return "", nil, err
+	}
+
+	filter = strings.NewReplacer("%s", ldap.EscapeFilter(username), "%d", ldap.EscapeFilter(userDN)).
+		Replace(l.GroupSearchFilter)
+	groupDNs, err = l.search(conn, l.GroupSearchBaseDN, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return userDN, groupDNs, nil
+}
+
+// normalizeLDAPDN - returns a normalized form of given DN to compare
+// DNs returned by the LDAP server with DNs policies are mapped to.
+func normalizeLDAPDN(dn string) (string, error) {
+	parsedDN, err := ldap.ParseDN(dn)
+	if err != nil || len(parsedDN.RDNs) == 0 {
+		return "", errLDAPInvalidDNInput
+	}
+
+	var rdns []string
+	for _, rdn := range parsedDN.RDNs {
+		var attrs []string
+		for _, attr := range rdn.Attributes {
+			attrs = append(attrs, fmt.Sprintf("%s=%s", strings.ToLower(attr.Type), strings.ToLower(attr.Value)))
+		}
+		rdns = append(rdns, strings.Join(attrs, "+"))
+	}
+	return strings.Join(rdns, ","), nil
+}
@@ -0,0 +1,340 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/gorilla/mux"
+	"github.com/scriptburn/minio/pkg/iam/policy"
+)
+
+// ldapServerStub - minimal in-process LDAP server supporting simple
+// binds and searches, search results are looked up by the filter.
+type ldapServerStub struct {
+	listener  net.Listener
+	passwords map[string]string
+	results   map[string][]string
+}
+
+func newLDAPServerStub(t *testing.T, passwords map[string]string, results map[string][]string) *ldapServerStub {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &ldapServerStub{listener, passwords, results}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return stub
+}
+
+func (stub *ldapServerStub) Close() {
+	stub.listener.Close()
+}
+
+func (stub *ldapServerStub) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value
+		request := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			name, _ := request.Children[1].Value.(string)
+			password := request.Children[2].Data.String()
+			resultCode := ldap.LDAPResultSuccess
+			if name != "" && stub.passwords[name] != password {
+				resultCode = ldap.LDAPResultInvalidCredentials
+			}
+			responses = append(responses, ldapStubResult(ldap.ApplicationBindResponse, resultCode))
+		case ldap.ApplicationSearchRequest:
+			filter, err := ldap.DecompileFilter(request.Children[6])
+			if err != nil {
+				return
+			}
+			for _, dn := range stub.results[filter] {
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+				entry.AppendChild(ber.NewSequence("Attributes"))
+				responses = append(responses, entry)
+			}
+			responses = append(responses, ldapStubResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			// Unbind and unsupported operations close the connection.
+			return
+		}
+
+		for _, response := range responses {
+			envelope := ber.NewSequence("LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			envelope.AppendChild(response)
+			if _, err = conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func ldapStubResult(tag ber.Tag, resultCode int) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, resultCode, "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return result
+}
+
+const (
+	testLDAPAliceDN      = "uid=alice,ou=people,dc=example,dc=com"
+	testLDAPBobDN        = "uid=bob,ou=people,dc=example,dc=com"
+	testLDAPDevelopersDN = "cn=developers,ou=groups,dc=example,dc=com"
+)
+
+func newTestLDAPServer(t *testing.T) (*ldapServerStub, ldapServerConfig) {
+	stub := newLDAPServerStub(t,
+		map[string]string{
+			testLDAPAliceDN: "alice123",
+			testLDAPBobDN:   "bob123",
+		},
+		map[string][]string{
+			"(uid=alice)": {testLDAPAliceDN},
+			"(uid=bob)":   {testLDAPBobDN},
+			"(uid=eve)":   {testLDAPAliceDN, testLDAPBobDN},
+			"(&(objectclass=groupOfNames)(member=" + testLDAPAliceDN + "))": {testLDAPDevelopersDN},
+		},
+	)
+
+	return stub, ldapServerConfig{
+		ServerAddr:         stub.listener.Addr().String(),
+		ServerInsecure:     true,
+		UserDNSearchBaseDN: "ou=people,dc=example,dc=com",
+		UserDNSearchFilter: "(uid=%s)",
+		GroupSearchBaseDN:  "ou=groups,dc=example,dc=com",
+		GroupSearchFilter:  "(&(objectclass=groupOfNames)(member=%d))",
+	}
+}
+
+func TestLDAPServerConfigValidate(t *testing.T) {
+	testCases := []struct {
+		config    ldapServerConfig
+		expectErr bool
+	}{
+		// Not configured.
+		{ldapServerConfig{}, false},
+		{ldapServerConfig{ServerAddr: "ldap:636", UserDNSearchBaseDN: "dc=example", UserDNSearchFilter: "(uid=%s)"}, false},
+		// Missing user DN search filter.
+		{ldapServerConfig{ServerAddr: "ldap:636", UserDNSearchBaseDN: "dc=example"}, true},
+		// User DN search filter without username.
+		{ldapServerConfig{ServerAddr: "ldap:636", UserDNSearchBaseDN: "dc=example", UserDNSearchFilter: "(uid=alice)"}, true},
+		// Group search filter without group search base DN.
+		{ldapServerConfig{ServerAddr: "ldap:636", UserDNSearchBaseDN: "dc=example", UserDNSearchFilter: "(uid=%s)", GroupSearchFilter: "(member=%d)"}, true},
+		// Insecure and StartTLS together.
+		{ldapServerConfig{ServerAddr: "ldap:389", ServerInsecure: true, ServerStartTLS: true, UserDNSearchBaseDN: "dc=example", UserDNSearchFilter: "(uid=%s)"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.config.Validate()
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Case %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	stub, config := newTestLDAPServer(t)
+	defer stub.Close()
+
+	testCases := []struct {
+		username         string
+		password         string
+		expectedUserDN   string
+		expectedGroupDNs []string
+		expectErr        bool
+	}{
+		{"alice", "alice123", testLDAPAliceDN, []string{testLDAPDevelopersDN}, false},
+		{"bob", "bob123", testLDAPBobDN, nil, false},
+		// Wrong password.
+		{"alice", "bob123", "", nil, true},
+		// Empty password must not result in an unauthenticated bind.
+		{"alice", "", "", nil, true},
+		// Unknown user.
+		{"mallory", "mallory123", "", nil, true},
+		// Filter matching multiple users.
+		{"eve", "alice123", "", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		userDN, groupDNs, err := config.Authenticate(testCase.username, testCase.password)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("Case %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if userDN != testCase.expectedUserDN {
+			t.Errorf("Case %d: expected user DN %s, got %s", i+1, testCase.expectedUserDN, userDN)
+		}
+		if strings.Join(groupDNs, ";") != strings.Join(testCase.expectedGroupDNs, ";") {
+			t.Errorf("Case %d: expected group DNs %v, got %v", i+1, testCase.expectedGroupDNs, groupDNs)
+		}
+	}
+}
+
+func TestNormalizeLDAPDN(t *testing.T) {
+	testCases := []struct {
+		dn         string
+		expectedDN string
+		expectErr  bool
+	}{
+		{"CN=Developers, OU=Groups, DC=Example, DC=com", "cn=developers,ou=groups,dc=example,dc=com", false},
+		{"uid=alice,ou=people,dc=example,dc=com", "uid=alice,ou=people,dc=example,dc=com", false},
+		{"", "", true},
+		{"not-a-dn", "", true},
+	}
+
+	for i, testCase := range testCases {
+		dn, err := normalizeLDAPDN(testCase.dn)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("Case %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if dn != testCase.expectedDN {
+			t.Errorf("Case %d: expected %s, got %s", i+1, testCase.expectedDN, dn)
+		}
+	}
+}
+
+func TestAssumeRoleWithLDAPIdentityHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for STS handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	defer func(iamSys *IAMSys) {
+		globalIAMSys = iamSys
+	}(globalIAMSys)
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	stub, config := newTestLDAPServer(t)
+	defer stub.Close()
+
+	globalLDAPConfig = config
+	defer func() {
+		globalLDAPConfig = ldapServerConfig{}
+	}()
+
+	// Policies are mapped to the group of alice, bob has no policies.
+	if err = globalIAMSys.SetLDAPPolicy("CN=Developers,OU=Groups,DC=Example,DC=com", []string{"readonly"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetLDAPPolicy("cn=testers,ou=groups,dc=example,dc=com", []string{"no-such-policy"}); err != errNoSuchPolicy {
+		t.Fatalf("Expected %v, got %v", errNoSuchPolicy, err)
+	}
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	execAssumeRoleWithLDAP := func(username, password string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set("Action", "AssumeRoleWithLDAPIdentity")
+		form.Set("Version", stsAPIVersion)
+		form.Set("LDAPUsername", username)
+		form.Set("LDAPPassword", password)
+		req, err := http.NewRequest("POST", "http://127.0.0.1:9000/", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		username           string
+		password           string
+		expectedStatusCode int
+	}{
+		{"alice", "wrongpassword", http.StatusForbidden},
+		{"mallory", "mallory123", http.StatusForbidden},
+		// No policies mapped to bob or its groups.
+		{"bob", "bob123", http.StatusForbidden},
+		{"alice", "alice123", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		rec := execAssumeRoleWithLDAP(testCase.username, testCase.password)
+		if rec.Code != testCase.expectedStatusCode {
+			t.Fatalf("Case %d: expected status code %d, got %d: %s", i+1, testCase.expectedStatusCode, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := execAssumeRoleWithLDAP("alice", "alice123")
+	var resp AssumeRoleWithLDAPResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	tempCred := resp.Result.Credentials
+	if tempCred.SessionToken == "" {
+		t.Fatal("Expected a session token in temporary credentials")
+	}
+
+	// Temporary credentials are allowed the policies of the group.
+	args := iampolicy.Args{
+		AccountName: tempCred.AccessKey,
+		Action:      iampolicy.GetObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "myobject",
+	}
+	if !globalIAMSys.IsAllowed(args) {
+		t.Fatal("Expected GetObject to be allowed for temporary credentials")
+	}
+	args.Action = iampolicy.PutObjectAction
+	if globalIAMSys.IsAllowed(args) {
+		t.Fatal("Expected PutObject to be denied for temporary credentials")
+	}
+
+	// LDAP policies are persisted.
+	iamSys := NewIAMSys()
+	if err = iamSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if policies := iamSys.ListLDAPPolicies()[testLDAPDevelopersDN]; len(policies) != 1 || policies[0] != "readonly" {
+		t.Fatalf("Unexpected LDAP policies after reload %v", policies)
+	}
+}
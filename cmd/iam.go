@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -45,19 +46,54 @@ const (
 	// IAM sts directory.
 	iamConfigSTSPrefix = iamConfigPrefix + "/sts/"
 
+	// IAM groups directory.
+	iamConfigGroupsPrefix = iamConfigPrefix + "/groups/"
+
+	// IAM service accounts directory.
+	iamConfigServiceAccountsPrefix = iamConfigPrefix + "/service-accounts/"
+
 	// IAM identity file which captures identity credentials.
 	iamIdentityFile = "identity.json"
 
 	// IAM policy file which provides policies for each users.
 	iamPolicyFile = "policy.json"
+
+	// IAM inline policy file which provides inline policy document for each users,
+	// for service accounts it provides the session policy.
+	iamInlinePolicyFile = "inline-policy.json"
+
+	// IAM group members file which captures members of each group.
+	iamGroupMembersFile = "members.json"
+
+	// Current version of group members file.
+	iamGroupMembersVersion = 1
+
+	// IAM LDAP policies file which maps LDAP user and group DNs to
+	// canned policies.
+	iamLDAPPoliciesFile = iamConfigPrefix + "/ldap-policies.json"
 )
 
+// groupInfo - members of a group, persisted in group members file.
+type groupInfo struct {
+	Version int      `json:"version"`
+	Members []string `json:"members"`
+}
+
 // IAMSys - config system.
 type IAMSys struct {
 	sync.RWMutex
 	iamUsersMap        map[string]auth.Credentials
 	iamPolicyMap       map[string]string
+	iamInlinePolicyMap map[string]iampolicy.Policy
 	iamCannedPolicyMap map[string]iampolicy.Policy
+	iamGroupsMap       map[string]groupInfo
+	iamGroupPolicyMap  map[string][]string
+	// Session policies of service accounts and temporary credentials.
+	iamSessionPolicyMap map[string]iampolicy.Policy
+	// Groups of each user, derived from iamGroupsMap.
+	iamUserGroupMemberships map[string]set.StringSet
+	// Canned policies of LDAP user and group DNs.
+	iamLDAPPolicyMap map[string][]string
 }
 
 // Load - loads iam subsystem
@@ -196,8 +232,9 @@ func (sys *IAMSys) SetCannedPolicy(policyName string, p iampolicy.Policy) error
 	return nil
 }
 
-// SetUserPolicy - sets policy to given user name.
-func (sys *IAMSys) SetUserPolicy(accessKey, policyName string) error {
+// SetUserPolicy - sets canned policies to given user name, replacing the
+// policies set before. No policy names remove all policies of the user.
+func (sys *IAMSys) SetUserPolicy(accessKey string, policyNames []string) error {
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil {
 		return errServerNotInitialized
@@ -210,16 +247,36 @@ func (sys *IAMSys) SetUserPolicy(accessKey, policyName string) error {
 		return errNoSuchUser
 	}
 
-	if _, ok := sys.iamCannedPolicyMap[policyName]; !ok {
-		return errNoSuchPolicy
+	for _, policyName := range policyNames {
+		if _, ok := sys.iamCannedPolicyMap[policyName]; !ok {
+			return errNoSuchPolicy
+		}
+	}
+
+	var err error
+	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamPolicyFile)
+	if len(policyNames) == 0 {
+		if globalEtcdClient != nil {
+			err = deleteConfigEtcd(context.Background(), globalEtcdClient, configFile)
+		} else {
+			err = deleteConfig(context.Background(), objectAPI, configFile)
+		}
+		if _, ok := err.(ObjectNotFound); err != nil && !ok {
+			return err
+		}
+
+		delete(sys.iamPolicyMap, accessKey)
+		return nil
 	}
 
+	// Policy names are saved comma separated, which is compatible
+	// with a single policy name saved by previous versions.
+	policyName := strings.Join(policyNames, ",")
 	data, err := json.Marshal(policyName)
 	if err != nil {
 		return err
 	}
 
-	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamPolicyFile)
 	if globalEtcdClient != nil {
 		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
 	} else {
@@ -233,6 +290,89 @@ func (sys *IAMSys) SetUserPolicy(accessKey, policyName string) error {
 	return nil
 }
 
+// SetUserInlinePolicy - sets inline policy document to given user name.
+func (sys *IAMSys) SetUserInlinePolicy(accessKey string, p iampolicy.Policy) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if p.IsEmpty() {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.iamUsersMap[accessKey]; !ok {
+		return errNoSuchUser
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamInlinePolicyFile)
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, configFile, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	sys.iamInlinePolicyMap[accessKey] = p
+	return nil
+}
+
+// GetUserInlinePolicy - returns inline policy document of given user name.
+func (sys *IAMSys) GetUserInlinePolicy(accessKey string) (iampolicy.Policy, error) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if _, ok := sys.iamUsersMap[accessKey]; !ok {
+		return iampolicy.Policy{}, errNoSuchUser
+	}
+
+	p, ok := sys.iamInlinePolicyMap[accessKey]
+	if !ok {
+		return iampolicy.Policy{}, errNoSuchPolicy
+	}
+
+	return p, nil
+}
+
+// DeleteUserInlinePolicy - removes inline policy document of given user name.
+func (sys *IAMSys) DeleteUserInlinePolicy(accessKey string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.iamInlinePolicyMap[accessKey]; !ok {
+		return errNoSuchPolicy
+	}
+
+	var err error
+	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamInlinePolicyFile)
+	if globalEtcdClient != nil {
+		err = deleteConfigEtcd(context.Background(), globalEtcdClient, configFile)
+	} else {
+		err = deleteConfig(context.Background(), objectAPI, configFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(sys.iamInlinePolicyMap, accessKey)
+	return nil
+}
+
 // DeleteUser - set user credentials.
 func (sys *IAMSys) DeleteUser(accessKey string) error {
 	objectAPI := newObjectLayerFn()
@@ -242,14 +382,17 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 
 	var err error
 	pFile := pathJoin(iamConfigUsersPrefix, accessKey, iamPolicyFile)
+	ipFile := pathJoin(iamConfigUsersPrefix, accessKey, iamInlinePolicyFile)
 	iFile := pathJoin(iamConfigUsersPrefix, accessKey, iamIdentityFile)
 	if globalEtcdClient != nil {
 		// It is okay to ingnore errors when deleting policy.json for the user.
 		_ = deleteConfigEtcd(context.Background(), globalEtcdClient, pFile)
+		_ = deleteConfigEtcd(context.Background(), globalEtcdClient, ipFile)
 		err = deleteConfigEtcd(context.Background(), globalEtcdClient, iFile)
 	} else {
 		// It is okay to ingnore errors when deleting policy.json for the user.
 		_ = deleteConfig(context.Background(), objectAPI, pFile)
+		_ = deleteConfig(context.Background(), objectAPI, ipFile)
 		err = deleteConfig(context.Background(), objectAPI, iFile)
 	}
 
@@ -264,6 +407,26 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 
 	delete(sys.iamUsersMap, accessKey)
 	delete(sys.iamPolicyMap, accessKey)
+	delete(sys.iamInlinePolicyMap, accessKey)
+
+	// Remove the user from all of its groups.
+	for _, group := range sys.iamUserGroupMemberships[accessKey].ToSlice() {
+		gi := sys.iamGroupsMap[group]
+		gi.Members = removeFromStringSlice(gi.Members, accessKey)
+		if gerr := sys.saveGroupInfo(objectAPI, group, gi); gerr != nil && err == nil {
+			err = gerr
+		}
+	}
+	delete(sys.iamUserGroupMemberships, accessKey)
+
+	// Remove service accounts and temporary credentials of the user.
+	for derivedUser, cred := range sys.iamUsersMap {
+		if cred.ParentUser == accessKey {
+			if derr := sys.deleteDerivedUser(objectAPI, derivedUser); derr != nil && err == nil {
+				err = derr
+			}
+		}
+	}
 
 	return err
 }
@@ -280,15 +443,13 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 
 	// If OPA is not set we honor any policy claims for this
 	// temporary user which match with pre-configured canned
-	// policies for this server.
+	// policies for this server, multiple policy names are
+	// comma separated.
 	if globalPolicyOPA == nil && policyName != "" {
-		p, ok := sys.iamCannedPolicyMap[policyName]
-		if !ok {
-			return errInvalidArgument
-		}
-		if p.IsEmpty() {
-			delete(sys.iamPolicyMap, accessKey)
-			return nil
+		for _, name := range strings.Split(policyName, ",") {
+			if _, ok := sys.iamCannedPolicyMap[name]; !ok {
+				return errInvalidArgument
+			}
 		}
 
 		data, err := json.Marshal(policyName)
@@ -329,6 +490,162 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 	return nil
 }
 
+// SetTempUserWithParent - set temporary credentials derived from the
+// parent user of the credentials, optional session policy restricts
+// permissions inherited from the parent user.
+func (sys *IAMSys) SetTempUserWithParent(cred auth.Credentials, sessionPolicy *iampolicy.Policy) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if !cred.IsTemp() || cred.ParentUser == "" {
+		return errInvalidArgument
+	}
+
+	if sessionPolicy != nil && sessionPolicy.IsEmpty() {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	parent, ok := sys.iamUsersMap[cred.ParentUser]
+	if !ok {
+		return errNoSuchUser
+	}
+
+	// Credentials derived from a parent user cannot be parents.
+	if parent.ParentUser != "" {
+		return errInvalidArgument
+	}
+
+	if sessionPolicy != nil {
+		data, err := json.Marshal(sessionPolicy)
+		if err != nil {
+			return err
+		}
+
+		configFile := pathJoin(iamConfigSTSPrefix, cred.AccessKey, iamInlinePolicyFile)
+		if globalEtcdClient != nil {
+			err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+		} else {
+			err = saveConfig(context.Background(), objectAPI, configFile, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return err
+	}
+
+	configFile := pathJoin(iamConfigSTSPrefix, cred.AccessKey, iamIdentityFile)
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, configFile, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	sys.iamUsersMap[cred.AccessKey] = cred
+	if sessionPolicy != nil {
+		sys.iamSessionPolicyMap[cred.AccessKey] = *sessionPolicy
+	}
+
+	return nil
+}
+
+// SetLDAPPolicy - sets canned policies to given LDAP user or group DN,
+// replacing the policies set before. No policy names remove all policies
+// of the DN.
+func (sys *IAMSys) SetLDAPPolicy(dn string, policyNames []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	normalizedDN, err := normalizeLDAPDN(dn)
+	if err != nil {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	for _, policyName := range policyNames {
+		if _, ok := sys.iamCannedPolicyMap[policyName]; !ok {
+			return errNoSuchPolicy
+		}
+	}
+
+	ldapPolicyMap := make(map[string][]string, len(sys.iamLDAPPolicyMap)+1)
+	for k, v := range sys.iamLDAPPolicyMap {
+		ldapPolicyMap[k] = v
+	}
+	if len(policyNames) == 0 {
+		delete(ldapPolicyMap, normalizedDN)
+	} else {
+		ldapPolicyMap[normalizedDN] = policyNames
+	}
+
+	data, err := json.Marshal(ldapPolicyMap)
+	if err != nil {
+		return err
+	}
+
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, iamLDAPPoliciesFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, iamLDAPPoliciesFile, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	sys.iamLDAPPolicyMap = ldapPolicyMap
+	return nil
+}
+
+// ListLDAPPolicies - lists canned policies of all LDAP user and group DNs.
+func (sys *IAMSys) ListLDAPPolicies() map[string][]string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	ldapPolicyMap := make(map[string][]string, len(sys.iamLDAPPolicyMap))
+	for k, v := range sys.iamLDAPPolicyMap {
+		ldapPolicyMap[k] = v
+	}
+	return ldapPolicyMap
+}
+
+// LDAPPolicyNames - returns names of canned policies mapped to given LDAP
+// user DN and the DNs of its groups.
+func (sys *IAMSys) LDAPPolicyNames(userDN string, groupDNs []string) []string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	policyNames := set.NewStringSet()
+	for _, dn := range append([]string{userDN}, groupDNs...) {
+		normalizedDN, err := normalizeLDAPDN(dn)
+		if err != nil {
+			continue
+		}
+		for _, policyName := range sys.iamLDAPPolicyMap[normalizedDN] {
+			// Skip canned policies removed after being mapped.
+			if _, ok := sys.iamCannedPolicyMap[policyName]; ok {
+				policyNames.Add(policyName)
+			}
+		}
+	}
+
+	return policyNames.ToSlice()
+}
+
 // ListUsers - list all users.
 func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 	objectAPI := newObjectLayerFn()
@@ -342,9 +659,17 @@ func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 	defer sys.RUnlock()
 
 	for k, v := range sys.iamUsersMap {
+		// Credentials derived from a parent user are not listed.
+		if v.ParentUser != "" {
+			continue
+		}
+
+		_, hasInlinePolicy := sys.iamInlinePolicyMap[k]
 		users[k] = madmin.UserInfo{
-			PolicyName: sys.iamPolicyMap[k],
-			Status:     madmin.AccountStatus(v.Status),
+			PolicyName:      sys.iamPolicyMap[k],
+			Status:          madmin.AccountStatus(v.Status),
+			MemberOf:        sys.iamUserGroupMemberships[k].ToSlice(),
+			HasInlinePolicy: hasInlinePolicy,
 		}
 	}
 
@@ -370,6 +695,12 @@ func (sys *IAMSys) SetUserStatus(accessKey string, status madmin.AccountStatus)
 		return errNoSuchUser
 	}
 
+	// Service accounts and temporary credentials are derived from
+	// their parent user and have no user identity of their own.
+	if cred.ParentUser != "" {
+		return errIAMActionNotAllowed
+	}
+
 	uinfo := madmin.UserInfo{
 		SecretKey: cred.SecretKey,
 		Status:    status,
@@ -407,6 +738,15 @@ func (sys *IAMSys) SetUser(accessKey string, uinfo madmin.UserInfo) error {
 		return errServerNotInitialized
 	}
 
+	// Service accounts and temporary credentials can not be turned
+	// into users.
+	sys.RLock()
+	cred, ok := sys.iamUsersMap[accessKey]
+	sys.RUnlock()
+	if ok && cred.ParentUser != "" {
+		return errIAMActionNotAllowed
+	}
+
 	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamIdentityFile)
 	data, err := json.Marshal(uinfo)
 	if err != nil {
@@ -441,6 +781,12 @@ func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 	defer sys.RUnlock()
 
 	cred, ok = sys.iamUsersMap[accessKey]
+	if ok && cred.ParentUser != "" {
+		// Service accounts and temporary credentials are revoked
+		// when their parent user is disabled, removed or expired.
+		parent, found := sys.iamUsersMap[cred.ParentUser]
+		ok = found && parent.IsValid()
+	}
 	return cred, ok && cred.IsValid()
 }
 
@@ -449,52 +795,496 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	sys.RLock()
 	defer sys.RUnlock()
 
+	// Service accounts and temporary credentials of a parent user are
+	// allowed what the parent user is allowed, restricted further by
+	// their session policy if any.
+	if cred, ok := sys.iamUsersMap[args.AccountName]; ok && cred.ParentUser != "" {
+		args.ConditionValues = getIdentityConditionValues(args.ConditionValues, cred.ParentUser, args.AccountName, args.Claims)
+		parentArgs := args
+		parentArgs.AccountName = cred.ParentUser
+		if !sys.isAllowed(parentArgs) {
+			return false
+		}
+
+		if p, found := sys.iamSessionPolicyMap[args.AccountName]; found {
+			return p.IsAllowed(args)
+		}
+		return true
+	}
+
+	// Identity of the requester is available to policies for policy
+	// variables and identity condition keys.
+	args.ConditionValues = getIdentityConditionValues(args.ConditionValues, args.AccountName, args.AccountName, args.Claims)
+	return sys.isAllowed(args)
+}
+
+// isAllowed - checks given policy args is allowed by policies of the
+// account, caller must hold the lock.
+func (sys *IAMSys) isAllowed(args iampolicy.Args) bool {
 	// If opa is configured, use OPA always.
 	if globalPolicyOPA != nil {
 		return globalPolicyOPA.IsAllowed(args)
 	}
 
-	// If policy is available for given user, check the policy.
-	if name, found := sys.iamPolicyMap[args.AccountName]; found {
-		p, ok := sys.iamCannedPolicyMap[name]
-		return ok && p.IsAllowed(args)
+	// If policies are available for given user or its groups, they are
+	// evaluated together - any explicit deny wins, otherwise any allow
+	// grants the request.
+	names := sys.policyNames(args.AccountName)
+	inlinePolicy, hasInlinePolicy := sys.iamInlinePolicyMap[args.AccountName]
+	if len(names) > 0 || hasInlinePolicy {
+		var combinedPolicy iampolicy.Policy
+		for _, name := range names {
+			if p, ok := sys.iamCannedPolicyMap[name]; ok {
+				combinedPolicy = combinedPolicy.Merge(p)
+			}
+		}
+		if hasInlinePolicy {
+			combinedPolicy = combinedPolicy.Merge(inlinePolicy)
+		}
+		return combinedPolicy.IsAllowed(args)
 	}
 
 	// As policy is not available and OPA is not configured, return the owner value.
 	return args.IsOwner
 }
 
-var defaultContextTimeout = 5 * time.Minute
+// policyNames - returns names of the policies of given user and its
+// groups, caller must hold the lock.
+func (sys *IAMSys) policyNames(accessKey string) []string {
+	var names []string
+	if policyName, found := sys.iamPolicyMap[accessKey]; found {
+		names = append(names, strings.Split(policyName, ",")...)
+	}
 
-// Similar to reloadUsers but updates users, policies maps from etcd server,
-func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
-	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
-	defer cancel()
+	for _, group := range sys.iamUserGroupMemberships[accessKey].ToSlice() {
+		names = append(names, sys.iamGroupPolicyMap[group]...)
+	}
+
+	return names
+}
+
+// saveGroupInfo - persists members of a group and updates the
+// membership maps, caller must hold the lock.
+func (sys *IAMSys) saveGroupInfo(objectAPI ObjectLayer, group string, gi groupInfo) error {
+	data, err := json.Marshal(gi)
 	if err != nil {
 		return err
 	}
-	// No users are created yet.
-	if r.Count == 0 {
-		return nil
-	}
 
-	users := set.NewStringSet()
-	for _, kv := range r.Kvs {
-		// Extract user by stripping off the `prefix` value as suffix,
-		// then strip off the remaining basename to obtain the prefix
-		// value, usually in the following form.
-		//
-		//  key := "config/iam/users/newuser/identity.json"
-		//  prefix := "config/iam/users/"
-		//  v := trim(trim(key, prefix), base(key)) == "newuser"
-		//
-		user := path.Clean(strings.TrimSuffix(strings.TrimPrefix(string(kv.Key), prefix), path.Base(string(kv.Key))))
-		if !users.Contains(user) {
-			users.Add(user)
-		}
+	configFile := pathJoin(iamConfigGroupsPrefix, group, iamGroupMembersFile)
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, configFile, data)
 	}
-
+	if err != nil {
+		return err
+	}
+
+	if old, ok := sys.iamGroupsMap[group]; ok {
+		for _, member := range old.Members {
+			if memberships, found := sys.iamUserGroupMemberships[member]; found {
+				memberships.Remove(group)
+			}
+		}
+	}
+	sys.iamGroupsMap[group] = gi
+	addGroupMemberships(sys.iamUserGroupMemberships, group, gi.Members)
+
+	return nil
+}
+
+// AddUsersToGroup - adds users to a group, creating the group if it
+// does not exist. Group without members is created when no users are
+// given.
+func (sys *IAMSys) AddUsersToGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Group name is a directory of the group config files.
+	if group == "" || strings.Contains(group, slashSeparator) {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	for _, member := range members {
+		if _, ok := sys.iamUsersMap[member]; !ok {
+			return errNoSuchUser
+		}
+	}
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		gi = groupInfo{Version: iamGroupMembersVersion}
+	}
+
+	uniqMembers := set.CreateStringSet(gi.Members...)
+	for _, member := range members {
+		uniqMembers.Add(member)
+	}
+	gi.Members = uniqMembers.ToSlice()
+
+	return sys.saveGroupInfo(objectAPI, group, gi)
+}
+
+// RemoveUsersFromGroup - removes users from a group.
+func (sys *IAMSys) RemoveUsersFromGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+
+	for _, member := range members {
+		gi.Members = removeFromStringSlice(gi.Members, member)
+	}
+
+	return sys.saveGroupInfo(objectAPI, group, gi)
+}
+
+// DeleteGroup - deletes a group along with its policies, only groups
+// without members can be deleted.
+func (sys *IAMSys) DeleteGroup(group string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+
+	if len(gi.Members) > 0 {
+		return errGroupNotEmpty
+	}
+
+	var err error
+	pFile := pathJoin(iamConfigGroupsPrefix, group, iamPolicyFile)
+	mFile := pathJoin(iamConfigGroupsPrefix, group, iamGroupMembersFile)
+	if globalEtcdClient != nil {
+		// It is okay to ignore errors when deleting policy.json for the group.
+		_ = deleteConfigEtcd(context.Background(), globalEtcdClient, pFile)
+		err = deleteConfigEtcd(context.Background(), globalEtcdClient, mFile)
+	} else {
+		// It is okay to ignore errors when deleting policy.json for the group.
+		_ = deleteConfig(context.Background(), objectAPI, pFile)
+		err = deleteConfig(context.Background(), objectAPI, mFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(sys.iamGroupsMap, group)
+	delete(sys.iamGroupPolicyMap, group)
+
+	return nil
+}
+
+// SetGroupPolicy - attaches canned policies to a group, replacing the
+// policies attached before. No policy names detach all policies.
+func (sys *IAMSys) SetGroupPolicy(group string, policyNames []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.iamGroupsMap[group]; !ok {
+		return errNoSuchGroup
+	}
+
+	for _, policyName := range policyNames {
+		if _, ok := sys.iamCannedPolicyMap[policyName]; !ok {
+			return errNoSuchPolicy
+		}
+	}
+
+	var err error
+	configFile := pathJoin(iamConfigGroupsPrefix, group, iamPolicyFile)
+	if len(policyNames) == 0 {
+		if globalEtcdClient != nil {
+			err = deleteConfigEtcd(context.Background(), globalEtcdClient, configFile)
+		} else {
+			err = deleteConfig(context.Background(), objectAPI, configFile)
+		}
+		if _, ok := err.(ObjectNotFound); err != nil && !ok {
+			return err
+		}
+
+		delete(sys.iamGroupPolicyMap, group)
+		return nil
+	}
+
+	data, err := json.Marshal(policyNames)
+	if err != nil {
+		return err
+	}
+
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, configFile, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	sys.iamGroupPolicyMap[group] = policyNames
+	return nil
+}
+
+// GetGroupDescription - returns members and policies of a group.
+func (sys *IAMSys) GetGroupDescription(group string) (madmin.GroupDesc, error) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return madmin.GroupDesc{}, errNoSuchGroup
+	}
+
+	return madmin.GroupDesc{
+		Name:     group,
+		Members:  gi.Members,
+		Policies: sys.iamGroupPolicyMap[group],
+	}, nil
+}
+
+// ListGroups - lists names of all groups.
+func (sys *IAMSys) ListGroups() []string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	groups := []string{}
+	for group := range sys.iamGroupsMap {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+// addGroupMemberships - adds group to memberships of its members.
+func addGroupMemberships(memberships map[string]set.StringSet, group string, members []string) {
+	for _, member := range members {
+		if _, ok := memberships[member]; !ok {
+			memberships[member] = set.NewStringSet()
+		}
+		memberships[member].Add(group)
+	}
+}
+
+// removeFromStringSlice - returns slice without the given value.
+func removeFromStringSlice(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// NewServiceAccount - creates a service account with new credentials
+// for given parent user, optional session policy restricts permissions
+// inherited from the parent user.
+func (sys *IAMSys) NewServiceAccount(parentUser string, sessionPolicy *iampolicy.Policy) (auth.Credentials, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	if sessionPolicy != nil && sessionPolicy.IsEmpty() {
+		return auth.Credentials{}, errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	parent, ok := sys.iamUsersMap[parentUser]
+	if !ok {
+		return auth.Credentials{}, errNoSuchUser
+	}
+
+	// Credentials derived from a parent user cannot have service accounts.
+	if parent.ParentUser != "" {
+		return auth.Credentials{}, errInvalidArgument
+	}
+
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	cred.ParentUser = parentUser
+
+	if sessionPolicy != nil {
+		data, err := json.Marshal(sessionPolicy)
+		if err != nil {
+			return auth.Credentials{}, err
+		}
+
+		configFile := pathJoin(iamConfigServiceAccountsPrefix, cred.AccessKey, iamInlinePolicyFile)
+		if globalEtcdClient != nil {
+			err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+		} else {
+			err = saveConfig(context.Background(), objectAPI, configFile, data)
+		}
+		if err != nil {
+			return auth.Credentials{}, err
+		}
+	}
+
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	configFile := pathJoin(iamConfigServiceAccountsPrefix, cred.AccessKey, iamIdentityFile)
+	if globalEtcdClient != nil {
+		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	} else {
+		err = saveConfig(context.Background(), objectAPI, configFile, data)
+	}
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sys.iamUsersMap[cred.AccessKey] = cred
+	if sessionPolicy != nil {
+		sys.iamSessionPolicyMap[cred.AccessKey] = *sessionPolicy
+	}
+
+	return cred, nil
+}
+
+// ListServiceAccounts - lists access keys of service accounts of given
+// parent user.
+func (sys *IAMSys) ListServiceAccounts(parentUser string) []string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	serviceAccounts := []string{}
+	for accessKey, cred := range sys.iamUsersMap {
+		if cred.ParentUser == parentUser && cred.IsServiceAccount() {
+			serviceAccounts = append(serviceAccounts, accessKey)
+		}
+	}
+	sort.Strings(serviceAccounts)
+
+	return serviceAccounts
+}
+
+// GetServiceAccountParent - returns parent user of given service account.
+func (sys *IAMSys) GetServiceAccountParent(accessKey string) (string, error) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if !ok || !cred.IsServiceAccount() {
+		return "", errNoSuchServiceAccount
+	}
+
+	return cred.ParentUser, nil
+}
+
+// DeleteServiceAccount - deletes given service account.
+func (sys *IAMSys) DeleteServiceAccount(accessKey string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if !ok || !cred.IsServiceAccount() {
+		return errNoSuchServiceAccount
+	}
+
+	return sys.deleteDerivedUser(objectAPI, accessKey)
+}
+
+// deleteDerivedUser - deletes config files and removes given service
+// account or temporary credentials of a parent user, caller must hold
+// the lock.
+func (sys *IAMSys) deleteDerivedUser(objectAPI ObjectLayer, accessKey string) error {
+	prefix := iamConfigServiceAccountsPrefix
+	if sys.iamUsersMap[accessKey].IsTemp() {
+		prefix = iamConfigSTSPrefix
+	}
+
+	var err error
+	pFile := pathJoin(prefix, accessKey, iamInlinePolicyFile)
+	iFile := pathJoin(prefix, accessKey, iamIdentityFile)
+	if globalEtcdClient != nil {
+		// It is okay to ignore errors when deleting session policy.
+		_ = deleteConfigEtcd(context.Background(), globalEtcdClient, pFile)
+		err = deleteConfigEtcd(context.Background(), globalEtcdClient, iFile)
+	} else {
+		// It is okay to ignore errors when deleting session policy.
+		_ = deleteConfig(context.Background(), objectAPI, pFile)
+		err = deleteConfig(context.Background(), objectAPI, iFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(sys.iamUsersMap, accessKey)
+	delete(sys.iamSessionPolicyMap, accessKey)
+
+	return nil
+}
+
+var defaultContextTimeout = 5 * time.Minute
+
+// Similar to reloadUsers but updates users, policies maps from etcd server,
+func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string,
+	inlinePolicyMap map[string]iampolicy.Policy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	// No users are created yet.
+	if r.Count == 0 {
+		return nil
+	}
+
+	users := set.NewStringSet()
+	for _, kv := range r.Kvs {
+		// Extract user by stripping off the `prefix` value as suffix,
+		// then strip off the remaining basename to obtain the prefix
+		// value, usually in the following form.
+		//
+		//  key := "config/iam/users/newuser/identity.json"
+		//  prefix := "config/iam/users/"
+		//  v := trim(trim(key, prefix), base(key)) == "newuser"
+		//
+		user := path.Clean(strings.TrimSuffix(strings.TrimPrefix(string(kv.Key), prefix), path.Base(string(kv.Key))))
+		if !users.Contains(user) {
+			users.Add(user)
+		}
+	}
+
 	// Reload config and policies for all users.
 	for _, user := range users.ToSlice() {
 		idFile := pathJoin(prefix, user, iamIdentityFile)
@@ -530,6 +1320,17 @@ func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policy
 			}
 			policyMap[user] = policyName
 		}
+		ipdata, iperr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, user, iamInlinePolicyFile))
+		if iperr != nil && iperr != errConfigNotFound {
+			return iperr
+		}
+		if iperr == nil {
+			var p iampolicy.Policy
+			if err = json.Unmarshal(ipdata, &p); err != nil {
+				return err
+			}
+			inlinePolicyMap[user] = p
+		}
 	}
 	return nil
 }
@@ -609,7 +1410,8 @@ func reloadPolicies(objectAPI ObjectLayer, prefix string, cannedPolicyMap map[st
 }
 
 // reloadUsers reads an updates users, policies from object layer into user and policy maps.
-func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string) error {
+func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string,
+	inlinePolicyMap map[string]iampolicy.Policy) error {
 	marker := ""
 	for {
 		var lo ListObjectsInfo
@@ -655,6 +1457,17 @@ func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.
 				}
 				policyMap[path.Base(prefix)] = policyName
 			}
+			ipdata, iperr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamInlinePolicyFile))
+			if iperr != nil && iperr != errConfigNotFound {
+				return iperr
+			}
+			if iperr == nil {
+				var p iampolicy.Policy
+				if err = json.Unmarshal(ipdata, &p); err != nil {
+					return err
+				}
+				inlinePolicyMap[path.Base(prefix)] = p
+			}
 		}
 		if !lo.IsTruncated {
 			break
@@ -663,6 +1476,100 @@ func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.
 	return nil
 }
 
+// Similar to reloadGroups but updates groups, group policies maps from etcd server.
+func reloadEtcdGroups(prefix string, groupsMap map[string]groupInfo, groupPolicyMap map[string][]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	// No groups are created yet.
+	if r.Count == 0 {
+		return nil
+	}
+
+	groups := set.NewStringSet()
+	for _, kv := range r.Kvs {
+		// Extract group by stripping off the `prefix` value as suffix,
+		// then strip off the remaining basename to obtain the prefix
+		// value, usually in the following form.
+		//
+		//  key := "config/iam/groups/newgroup/members.json"
+		//  prefix := "config/iam/groups/"
+		//  v := trim(trim(key, prefix), base(key)) == "newgroup"
+		//
+		group := path.Clean(strings.TrimSuffix(strings.TrimPrefix(string(kv.Key), prefix), path.Base(string(kv.Key))))
+		groups.Add(group)
+	}
+
+	// Reload members and policies for all groups.
+	for _, group := range groups.ToSlice() {
+		mdata, merr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, group, iamGroupMembersFile))
+		pdata, perr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, group, iamPolicyFile))
+		if err = loadGroup(group, mdata, merr, pdata, perr, groupsMap, groupPolicyMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reloadGroups reads and updates groups, group policies from object layer into groups and group policy maps.
+func reloadGroups(objectAPI ObjectLayer, prefix string, groupsMap map[string]groupInfo, groupPolicyMap map[string][]string) error {
+	marker := ""
+	for {
+		var lo ListObjectsInfo
+		var err error
+		lo, err = objectAPI.ListObjects(context.Background(), minioMetaBucket, prefix, marker, "/", 1000)
+		if err != nil {
+			return err
+		}
+		marker = lo.NextMarker
+		for _, prefix := range lo.Prefixes {
+			mdata, merr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamGroupMembersFile))
+			pdata, perr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamPolicyFile))
+			if err = loadGroup(path.Base(prefix), mdata, merr, pdata, perr, groupsMap, groupPolicyMap); err != nil {
+				return err
+			}
+		}
+		if !lo.IsTruncated {
+			break
+		}
+	}
+	return nil
+}
+
+// loadGroup - loads members and policies of a group read from its
+// config files.
+func loadGroup(group string, mdata []byte, merr error, pdata []byte, perr error,
+	groupsMap map[string]groupInfo, groupPolicyMap map[string][]string) error {
+	if merr != nil && merr != errConfigNotFound {
+		return merr
+	}
+	if perr != nil && perr != errConfigNotFound {
+		return perr
+	}
+	// Policies of a removed group are ignored.
+	if merr == errConfigNotFound {
+		return nil
+	}
+
+	var gi groupInfo
+	if err := json.Unmarshal(mdata, &gi); err != nil {
+		return err
+	}
+	groupsMap[group] = gi
+
+	if perr == nil {
+		var policyNames []string
+		if err := json.Unmarshal(pdata, &policyNames); err != nil {
+			return err
+		}
+		groupPolicyMap[group] = policyNames
+	}
+	return nil
+}
+
 // Set default canned policies only if not already overridden by users.
 func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	_, ok := policies["writeonly"]
@@ -679,34 +1586,91 @@ func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	}
 }
 
+// Similar to reloadLDAPPolicies but reads LDAP policies from etcd server.
+func reloadEtcdLDAPPolicies(configFile string, ldapPolicyMap map[string][]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+
+	data, err := readConfigEtcd(ctx, globalEtcdClient, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, &ldapPolicyMap)
+}
+
+// reloadLDAPPolicies reads canned policies of LDAP user and group DNs.
+func reloadLDAPPolicies(objectAPI ObjectLayer, configFile string, ldapPolicyMap map[string][]string) error {
+	data, err := readConfig(context.Background(), objectAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, &ldapPolicyMap)
+}
+
 // Refresh IAMSys.
 func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 	iamUsersMap := make(map[string]auth.Credentials)
 	iamPolicyMap := make(map[string]string)
+	iamInlinePolicyMap := make(map[string]iampolicy.Policy)
 	iamCannedPolicyMap := make(map[string]iampolicy.Policy)
+	iamGroupsMap := make(map[string]groupInfo)
+	iamGroupPolicyMap := make(map[string][]string)
+	iamSessionPolicyMap := make(map[string]iampolicy.Policy)
+	iamLDAPPolicyMap := make(map[string][]string)
 
 	if globalEtcdClient != nil {
 		if err := reloadEtcdPolicies(iamConfigPoliciesPrefix, iamCannedPolicyMap); err != nil {
 			return err
 		}
-		if err := reloadEtcdUsers(iamConfigUsersPrefix, iamUsersMap, iamPolicyMap); err != nil {
+		if err := reloadEtcdUsers(iamConfigUsersPrefix, iamUsersMap, iamPolicyMap, iamInlinePolicyMap); err != nil {
+			return err
+		}
+		if err := reloadEtcdUsers(iamConfigSTSPrefix, iamUsersMap, iamPolicyMap, iamSessionPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadEtcdGroups(iamConfigGroupsPrefix, iamGroupsMap, iamGroupPolicyMap); err != nil {
 			return err
 		}
-		if err := reloadEtcdUsers(iamConfigSTSPrefix, iamUsersMap, iamPolicyMap); err != nil {
+		if err := reloadEtcdUsers(iamConfigServiceAccountsPrefix, iamUsersMap, iamPolicyMap, iamSessionPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadEtcdLDAPPolicies(iamLDAPPoliciesFile, iamLDAPPolicyMap); err != nil {
 			return err
 		}
 	} else {
 		if err := reloadPolicies(objAPI, iamConfigPoliciesPrefix, iamCannedPolicyMap); err != nil {
 			return err
 		}
-		if err := reloadUsers(objAPI, iamConfigUsersPrefix, iamUsersMap, iamPolicyMap); err != nil {
+		if err := reloadUsers(objAPI, iamConfigUsersPrefix, iamUsersMap, iamPolicyMap, iamInlinePolicyMap); err != nil {
+			return err
+		}
+		if err := reloadUsers(objAPI, iamConfigSTSPrefix, iamUsersMap, iamPolicyMap, iamSessionPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadGroups(objAPI, iamConfigGroupsPrefix, iamGroupsMap, iamGroupPolicyMap); err != nil {
 			return err
 		}
-		if err := reloadUsers(objAPI, iamConfigSTSPrefix, iamUsersMap, iamPolicyMap); err != nil {
+		if err := reloadUsers(objAPI, iamConfigServiceAccountsPrefix, iamUsersMap, iamPolicyMap, iamSessionPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadLDAPPolicies(objAPI, iamLDAPPoliciesFile, iamLDAPPolicyMap); err != nil {
 			return err
 		}
 	}
 
+	iamUserGroupMemberships := make(map[string]set.StringSet)
+	for group, gi := range iamGroupsMap {
+		addGroupMemberships(iamUserGroupMemberships, group, gi.Members)
+	}
+
 	// Sets default canned policies, if none set.
 	setDefaultCannedPolicies(iamCannedPolicyMap)
 
@@ -715,7 +1679,13 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 
 	sys.iamUsersMap = iamUsersMap
 	sys.iamPolicyMap = iamPolicyMap
+	sys.iamInlinePolicyMap = iamInlinePolicyMap
 	sys.iamCannedPolicyMap = iamCannedPolicyMap
+	sys.iamGroupsMap = iamGroupsMap
+	sys.iamGroupPolicyMap = iamGroupPolicyMap
+	sys.iamSessionPolicyMap = iamSessionPolicyMap
+	sys.iamUserGroupMemberships = iamUserGroupMemberships
+	sys.iamLDAPPolicyMap = iamLDAPPolicyMap
 
 	return nil
 }
@@ -725,6 +1695,13 @@ func NewIAMSys() *IAMSys {
 	return &IAMSys{
 		iamUsersMap:        make(map[string]auth.Credentials),
 		iamPolicyMap:       make(map[string]string),
+		iamInlinePolicyMap: make(map[string]iampolicy.Policy),
 		iamCannedPolicyMap: make(map[string]iampolicy.Policy),
+		iamGroupsMap:       make(map[string]groupInfo),
+		iamGroupPolicyMap:  make(map[string][]string),
+
+		iamSessionPolicyMap:     make(map[string]iampolicy.Policy),
+		iamUserGroupMemberships: make(map[string]set.StringSet),
+		iamLDAPPolicyMap:        make(map[string][]string),
 	}
 }
@@ -40,6 +40,12 @@ func testAuthenticate(authType string, t *testing.T) {
 	}
 	globalServerConfig.SetCredential(cred)
 
+	defer func(iamSys *IAMSys) {
+		globalIAMSys = iamSys
+	}(globalIAMSys)
+	globalIAMSys = NewIAMSys()
+	globalIAMSys.Init(obj)
+
 	// Define test cases.
 	testCases := []struct {
 		accessKey   string
@@ -34,10 +34,18 @@ var (
 		},
 		[]string{"request_type"},
 	)
+	bucketSoftQuotaExceeded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "minio_bucket_quota_soft_exceeded_total",
+			Help: "Total number of writes going over the soft quota of a bucket",
+		},
+		[]string{"bucket"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(httpRequestsDuration)
+	prometheus.MustRegister(bucketSoftQuotaExceeded)
 	prometheus.MustRegister(newMinioCollector())
 }
 
@@ -132,6 +140,28 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		totalDisks = s.Backend.OfflineDisks + s.Backend.OnlineDisks
 	}
 
+	// Usage and quota of the buckets having a quota
+	for bucket, q := range globalBucketQuotaSys.List() {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "usage_bytes"),
+				"Total size of the objects in a bucket having a quota",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(globalBucketQuotaSys.Usage(bucket)),
+			bucket,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "quota_bytes"),
+				"Quota of a bucket",
+				[]string{"bucket", "type"}, nil),
+			prometheus.GaugeValue,
+			float64(q.Quota),
+			bucket, string(q.Type),
+		)
+	}
+
 	// Total disk usage by current Minio server instance
 	ch <- prometheus.MustNewConstMetric(
 		prometheus.NewDesc(
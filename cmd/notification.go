@@ -32,9 +32,16 @@ import (
 	"time"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
 	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+	"github.com/scriptburn/minio/pkg/madmin"
 	xnet "github.com/scriptburn/minio/pkg/net"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/versioning"
 )
 
 // NotificationSys - notification system.
@@ -43,6 +50,7 @@ type NotificationSys struct {
 	targetList                 *event.TargetList
 	bucketRulesMap             map[string]event.RulesMap
 	bucketRemoteTargetRulesMap map[string]map[event.TargetID]event.RulesMap
+	serverRulesMap             event.RulesMap
 	peerRPCClientMap           map[xnet.Host]*PeerRPCClient
 }
 
@@ -182,6 +190,20 @@ func (sys *NotificationSys) LoadCredentials() []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadNotificationTarget - calls LoadNotificationTarget RPC call on all peers.
+func (sys *NotificationSys) LoadNotificationTarget(targetID event.TargetID) []NotificationPeerErr {
+	var idx = 0
+	ng := WithNPeers(len(sys.peerRPCClientMap))
+	for addr, client := range sys.peerRPCClientMap {
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.LoadNotificationTarget(targetID)
+		}, idx, addr)
+		idx++
+	}
+	return ng.Wait()
+}
+
 // StartProfiling - start profiling on remote peers, by initiating a remote RPC.
 func (sys *NotificationSys) StartProfiling(profiler string) []NotificationPeerErr {
 	var idx = 0
@@ -376,6 +398,239 @@ func (sys *NotificationSys) RemoveBucketPolicy(ctx context.Context, bucketName s
 	}()
 }
 
+// SetBucketVersioning - calls SetBucketVersioning RPC call on all peers.
+func (sys *NotificationSys) SetBucketVersioning(ctx context.Context, bucketName string, config *versioning.Versioning) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketVersioning(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketLifecycle - calls SetBucketLifecycle RPC call on all peers.
+func (sys *NotificationSys) SetBucketLifecycle(ctx context.Context, bucketName string, bucketLifecycle *lifecycle.Lifecycle) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketLifecycle(bucketName, bucketLifecycle); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketLifecycle - calls RemoveBucketLifecycle RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketLifecycle(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketLifecycle(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketObjectLockConfig - calls SetBucketObjectLockConfig RPC call on all peers.
+func (sys *NotificationSys) SetBucketObjectLockConfig(ctx context.Context, bucketName string, config *objectlock.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketObjectLockConfig(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketCORS - calls SetBucketCORS RPC call on all peers.
+func (sys *NotificationSys) SetBucketCORS(ctx context.Context, bucketName string, config *cors.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketCORS(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketCORS - calls RemoveBucketCORS RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketCORS(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketCORS(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketSSEConfig - calls SetBucketSSEConfig RPC call on all peers.
+func (sys *NotificationSys) SetBucketSSEConfig(ctx context.Context, bucketName string, config *sse.BucketSSEConfig) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketSSEConfig(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketSSEConfig - calls RemoveBucketSSEConfig RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketSSEConfig(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketSSEConfig(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketQuota - calls SetBucketQuota RPC call on all peers.
+func (sys *NotificationSys) SetBucketQuota(ctx context.Context, bucketName string, q madmin.BucketQuota) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketQuota(bucketName, q); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketQuota - calls RemoveBucketQuota RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketQuota(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketQuota(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// UpdateBucketUsage - calls UpdateBucketUsage RPC call on all peers and
+// waits for the calls to return.
+func (sys *NotificationSys) UpdateBucketUsage(ctx context.Context, changes map[string]int64) {
+	var wg sync.WaitGroup
+	for addr, client := range sys.peerRPCClientMap {
+		wg.Add(1)
+		go func(addr xnet.Host, client *PeerRPCClient) {
+			defer wg.Done()
+			if err := client.UpdateBucketUsage(changes); err != nil {
+				logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+				logger.LogIf(ctx, err)
+			}
+		}(addr, client)
+	}
+	wg.Wait()
+}
+
+// SetBucketReplication - calls SetBucketReplication RPC call on all peers.
+func (sys *NotificationSys) SetBucketReplication(ctx context.Context, bucketName string, config *replication.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketReplication(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketReplication - calls RemoveBucketReplication RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketReplication(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketReplication(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
 // PutBucketNotification - calls PutBucketNotification RPC call on all peers.
 func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketName string, rulesMap event.RulesMap) {
 	go func() {
@@ -459,7 +714,7 @@ func (sys *NotificationSys) initListeners(ctx context.Context, objAPI ObjectLaye
 	}
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
@@ -548,7 +803,8 @@ func (sys *NotificationSys) refresh(objAPI ObjectLayer) error {
 			return err
 		}
 	}
-	return nil
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{})
+	return sys.initListeners(ctx, objAPI, "")
 }
 
 // Init - initializes notification system from notification.xml and listener.json of all buckets.
@@ -582,6 +838,108 @@ func (sys *NotificationSys) Init(objAPI ObjectLayer) error {
 	}
 }
 
+// LoadTarget - replaces target by target ID with the target in config, the
+// target is removed if it is not in config or not enabled. The current
+// target is kept if the new target can not be created, unless the new
+// target is queued.
+func (sys *NotificationSys) LoadTarget(config *serverConfig, targetID event.TargetID) error {
+	var newTarget event.Target
+	if config.isNotificationTargetEnabled(targetID) {
+		newTargetFn, queueDir, queueLimit, err := config.getNotificationTargetFn(targetID)
+		if err != nil {
+			return err
+		}
+
+		// The current target is closed first as it may replay the same
+		// queue directory.
+		if queueDir != "" {
+			sys.removeTarget(targetID)
+		}
+
+		if newTarget, err = newNotificationTarget(targetID, queueDir, queueLimit, newTargetFn); err != nil {
+			return err
+		}
+	}
+
+	sys.removeTarget(targetID)
+
+	if newTarget != nil {
+		if err := sys.targetList.Add(newTarget); err != nil {
+			return err
+		}
+	}
+
+	// Subscriptions of removed targets are dropped.
+	serverRulesMap := getNotificationSubscriptions(config, sys.targetList)
+
+	sys.Lock()
+	sys.serverRulesMap = serverRulesMap
+	sys.Unlock()
+
+	return nil
+}
+
+// removeTarget - closes and removes target by target ID.
+func (sys *NotificationSys) removeTarget(targetID event.TargetID) {
+	for terr := range sys.targetList.Remove(targetID) {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("targetID", terr.ID.Name)
+		ctx := logger.SetReqInfo(context.Background(), reqInfo)
+		logger.LogIf(ctx, terr.Err)
+	}
+}
+
+// GetTargetStatus - returns whether target by target ID is loaded and
+// online. Only queued targets know about their underlying target being
+// unreachable, other targets are online once loaded.
+func (sys *NotificationSys) GetTargetStatus(targetID event.TargetID) (online bool) {
+	t, ok := sys.targetList.Get(targetID)
+	if !ok {
+		return false
+	}
+
+	if t, ok := t.(interface{ IsOnline() bool }); ok {
+		return t.IsOnline()
+	}
+
+	return true
+}
+
+// GetTargetsStatus - returns whether each target is online in all
+// servers, targets are offline if a peer can not be reached.
+func (sys *NotificationSys) GetTargetsStatus(targetIDs []event.TargetID) []bool {
+	online := make([]bool, len(targetIDs))
+	for i, targetID := range targetIDs {
+		online[i] = sys.GetTargetStatus(targetID)
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for addr, client := range sys.peerRPCClientMap {
+		wg.Add(1)
+		go func(addr xnet.Host, client *PeerRPCClient) {
+			defer wg.Done()
+			peerOnline, err := client.GetTargetStatus(targetIDs)
+			if err == nil && len(peerOnline) != len(targetIDs) {
+				err = fmt.Errorf("expected status of %d targets, got %d", len(targetIDs), len(peerOnline))
+			}
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("remotePeer", addr.String())
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for i := range online {
+				online[i] = online[i] && err == nil && peerOnline[i]
+			}
+		}(addr, client)
+	}
+	wg.Wait()
+
+	return online
+}
+
 // AddRulesMap - adds rules map for bucket name.
 func (sys *NotificationSys) AddRulesMap(bucketName string, rulesMap event.RulesMap) {
 	sys.Lock()
@@ -661,6 +1019,8 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 		errs = append(errs, terr)
 		if sys.RemoteTargetExist(bucketName, terr.ID) {
 			sys.RemoveRemoteTarget(bucketName, terr.ID)
+		} else if sys.RemoteTargetExist("", terr.ID) {
+			sys.RemoveRemoteTarget("", terr.ID)
 		}
 	}
 
@@ -670,7 +1030,11 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	objInfo := args.ToObjectInfo()
+	targetIDSet := sys.bucketRulesMap[args.BucketName].MatchObject(args.EventName, objInfo)
+	targetIDSet = targetIDSet.Union(sys.serverRulesMap.MatchObject(args.EventName, objInfo))
+	// Listeners of all buckets are added to empty bucket name.
+	targetIDSet = targetIDSet.Union(sys.bucketRulesMap[""].MatchObject(args.EventName, objInfo))
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
@@ -766,6 +1130,7 @@ func NewNotificationSys(config *serverConfig, endpoints EndpointList) *Notificat
 		targetList:                 targetList,
 		bucketRulesMap:             make(map[string]event.RulesMap),
 		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+		serverRulesMap:             getNotificationSubscriptions(config, targetList),
 		peerRPCClientMap:           peerRPCClientMap,
 	}
 }
@@ -781,6 +1146,32 @@ type eventArgs struct {
 	UserAgent    string
 }
 
+// ToObjectInfo - returns object attributes matched by notification rules.
+func (args eventArgs) ToObjectInfo() event.ObjectInfo {
+	objInfo := event.ObjectInfo{
+		Name:         args.Object.Name,
+		Size:         args.Object.Size,
+		ContentType:  args.Object.ContentType,
+		UserMetadata: make(map[string]string),
+	}
+
+	if args.Object.IsCompressed() {
+		objInfo.Size = args.Object.GetActualSize()
+	}
+
+	for key, value := range args.Object.UserDefined {
+		if lkey := strings.ToLower(key); strings.HasPrefix(lkey, "x-amz-meta-") {
+			objInfo.UserMetadata[strings.TrimPrefix(lkey, "x-amz-meta-")] = value
+		}
+	}
+
+	if tags, err := getObjectTags(args.Object); err == nil {
+		objInfo.Tags = tags.ToMap()
+	}
+
+	return objInfo
+}
+
 // ToEvent - converts to notification event.
 func (args eventArgs) ToEvent() event.Event {
 	getOriginEndpoint := func() string {
@@ -837,6 +1228,10 @@ func (args eventArgs) ToEvent() event.Event {
 		},
 	}
 
+	if args.Object.VersionID != "" {
+		newEvent.S3.Object.VersionID = args.Object.VersionID
+	}
+
 	if args.EventName != event.ObjectRemovedDelete {
 		newEvent.S3.Object.ETag = args.Object.ETag
 		newEvent.S3.Object.Size = args.Object.Size
@@ -895,6 +1290,16 @@ func saveNotificationConfig(ctx context.Context, objAPI ObjectLayer, bucketName
 	return saveConfig(ctx, objAPI, configFile, data)
 }
 
+// getListenerConfigFile - returns path to listener.json of given bucket,
+// listeners of all buckets are saved in config prefix.
+func getListenerConfigFile(bucketName string) string {
+	if bucketName == "" {
+		return path.Join(minioConfigPrefix, bucketListenerConfig)
+	}
+
+	return path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+}
+
 // SaveListener - saves HTTP client currently listening for events to listener.json.
 func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name, pattern string, targetID event.TargetID, addr xnet.Host) error {
 	// listener.json is available/applicable only in DistXL mode.
@@ -905,7 +1310,7 @@ func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name
 	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName})
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
@@ -956,7 +1361,7 @@ func RemoveListener(objAPI ObjectLayer, bucketName string, targetID event.Target
 	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName})
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
@@ -92,6 +92,27 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete listener config, if present - ignore any errors.
 	removeListenerConfig(ctx, objAPI, bucket)
+
+	// Delete bucket versioning config, if present - ignore any errors.
+	removeVersioningConfig(ctx, objAPI, bucket)
+
+	// Delete bucket lifecycle config, if present - ignore any errors.
+	removeLifecycleConfig(ctx, objAPI, bucket)
+
+	// Delete bucket object lock config, if present - ignore any errors.
+	removeObjectLockConfig(ctx, objAPI, bucket)
+
+	// Delete bucket CORS config, if present - ignore any errors.
+	removeCORSConfig(ctx, objAPI, bucket)
+
+	// Delete bucket encryption config, if present - ignore any errors.
+	removeBucketSSEConfig(ctx, objAPI, bucket)
+
+	// Delete bucket replication config, if present - ignore any errors.
+	removeBucketReplicationConfig(ctx, objAPI, bucket)
+
+	// Delete bucket quota config, if present - ignore any errors.
+	removeBucketQuotaConfig(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
@@ -22,13 +22,27 @@ import (
 	"net/http"
 
 	"github.com/minio/minio-go/pkg/encrypt"
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
 	"github.com/scriptburn/minio/pkg/madmin"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // ObjectOptions represents object options for ObjectLayer operations
 type ObjectOptions struct {
 	ServerSideEncryption encrypt.ServerSide
+	VersionID            string // Version ID to operate on, empty refers to the current version.
+	Versioned            bool   // Bucket has versioning enabled.
+	VersionSuspended     bool   // Bucket has versioning suspended.
+
+	// CheckPrecondFn is called by UpdateObjectMetadata with the current
+	// object while holding the object lock, the update fails with
+	// PreconditionFailed if it returns true.
+	CheckPrecondFn func(ObjectInfo) bool
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -68,6 +82,8 @@ type ObjectLayer interface {
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error)
 	DeleteObject(ctx context.Context, bucket, object string) error
+	DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error)
 
 	// Multipart operations.
 	ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error)
@@ -92,10 +108,44 @@ type ObjectLayer interface {
 	GetBucketPolicy(context.Context, string) (*policy.Policy, error)
 	DeleteBucketPolicy(context.Context, string) error
 
+	// Lifecycle operations
+	SetBucketLifecycle(context.Context, string, *lifecycle.Lifecycle) error
+	GetBucketLifecycle(context.Context, string) (*lifecycle.Lifecycle, error)
+	DeleteBucketLifecycle(context.Context, string) error
+
+	// Object lock operations
+	SetBucketObjectLockConfig(context.Context, string, *objectlock.Config) error
+	GetBucketObjectLockConfig(context.Context, string) (*objectlock.Config, error)
+
+	// CORS operations
+	SetBucketCORS(context.Context, string, *cors.Config) error
+	GetBucketCORS(context.Context, string) (*cors.Config, error)
+	DeleteBucketCORS(context.Context, string) error
+
+	// Bucket encryption operations
+	SetBucketSSEConfig(context.Context, string, *sse.BucketSSEConfig) error
+	GetBucketSSEConfig(context.Context, string) (*sse.BucketSSEConfig, error)
+	DeleteBucketSSEConfig(context.Context, string) error
+
+	// Bucket replication operations
+	SetBucketReplication(context.Context, string, *replication.Config) error
+	GetBucketReplication(context.Context, string) (*replication.Config, error)
+	DeleteBucketReplication(context.Context, string) error
+
+	// Object tagging operations
+	PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error
+	GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tagging.Tagging, error)
+	DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error
+
+	// UpdateObjectMetadata updates the given metadata keys of an object in
+	// place, keys with an empty value are removed.
+	UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error
+
 	// Supported operations check
 	IsNotificationSupported() bool
 	IsListenBucketSupported() bool
 	IsEncryptionSupported() bool
+	IsVersioningSupported() bool
 
 	// Compression support check.
 	IsCompressionSupported() bool
@@ -52,6 +52,8 @@ const (
 	minioMetaMultipartBucket = minioMetaBucket + "/" + mpartMetaPrefix
 	// Minio Tmp meta prefix.
 	minioMetaTmpBucket = minioMetaBucket + "/tmp"
+	// Minio noncurrent object versions meta prefix.
+	minioMetaVersionsBucket = minioMetaBucket + "/versions"
 	// DNS separator (period), used for bucket name validation.
 	dnsDelimiter = "."
 )
@@ -61,7 +63,8 @@ const (
 func isMinioMetaBucketName(bucket string) bool {
 	return bucket == minioMetaBucket ||
 		bucket == minioMetaMultipartBucket ||
-		bucket == minioMetaTmpBucket
+		bucket == minioMetaTmpBucket ||
+		bucket == minioMetaVersionsBucket
 }
 
 // IsValidBucketName verifies that a bucket name is in accordance with
@@ -579,6 +582,11 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, cleanUpFns ...func())
 	return fn, off, length, nil
 }
 
+// ObjectSize - returns the actual size of the object being read.
+func (g *GetObjectReader) ObjectSize() int64 {
+	return getReplicationObjectSize(g.ObjInfo)
+}
+
 // Close - calls the cleanup actions in reverse order
 func (g *GetObjectReader) Close() error {
 	// sync.Once is used here to ensure that Close() is
@@ -239,10 +239,14 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 		deleteObject = cache.DeleteObject
 	}
 	// Proceed to delete the object.
+	size := getQuotaObjectSize(ctx, obj, bucket, object, ObjectOptions{})
 	if err = deleteObject(ctx, bucket, object); err != nil {
 		return err
 	}
 
+	freeBucketUsage(bucket, size)
+	replicateDeleteAsync(bucket, object)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
 
@@ -261,3 +265,47 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 
 	return nil
 }
+
+// getObjectRemovedEventName - returns the event name of removing the
+// version of an object, placing a delete marker is notified separately
+// from removing a version.
+func getObjectRemovedEventName(objInfo ObjectInfo, versionID string) event.Name {
+	if objInfo.DeleteMarker && versionID == "" {
+		return event.ObjectRemovedDeleteMarkerCreated
+	}
+	return event.ObjectRemovedDelete
+}
+
+// deleteObjectVersion is a convenient wrapper to delete an object version
+// or to place a delete marker, this function also notifies the removal.
+func deleteObjectVersion(ctx context.Context, obj ObjectLayer, bucket, object string, opts ObjectOptions, r *http.Request) (objInfo ObjectInfo, err error) {
+	// Proceed to delete the object version.
+	size := getQuotaObjectSize(ctx, obj, bucket, object, opts)
+	if objInfo, err = obj.DeleteObjectVersion(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
+	freeBucketUsage(bucket, size)
+
+	// Removing a specific version does not change the remote target
+	// which only keeps the latest content of the object.
+	if opts.VersionID == "" {
+		replicateDeleteAsync(bucket, object)
+	}
+
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  getObjectRemovedEventName(objInfo, opts.VersionID),
+		BucketName: bucket,
+		Object:     objInfo,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       host,
+		Port:       port,
+	})
+
+	return objInfo, nil
+}
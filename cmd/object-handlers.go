@@ -46,6 +46,7 @@ import (
 	"github.com/scriptburn/minio/pkg/ioutil"
 	"github.com/scriptburn/minio/pkg/policy"
 	"github.com/scriptburn/minio/pkg/s3select"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // supportedHeadGetReqParams - supported request parameters for GET and HEAD presigned request.
@@ -181,8 +182,18 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 			Start:          offset,
 			End:            offset + length,
 		}
+		if length < 0 {
+			// Read till the end of the object.
+			rs.End = -1
+		}
 
-		return getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, ObjectOptions{})
+		rc, err = getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, ObjectOptions{})
+		if err == errInvalidRange && offset > 0 && length < 0 {
+			// A scan range starting past the end of the
+			// object has no records.
+			return goioutil.NopCloser(strings.NewReader("")), nil
+		}
+		return rc, err
 	}
 
 	if err = s3Select.Open(getObject); err != nil {
@@ -249,8 +260,9 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	bucket := vars["bucket"]
 	object := vars["object"]
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
-		writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
+	versionID, s3Error := getRequestVersionID(objectAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
 
@@ -260,10 +272,17 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket, versionID)
+
+	// Reading a specific version needs its own permission.
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if versionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
 
 	// Check for auth type to return S3 compatible error.
 	// type to return the correct error (NoSuchKey vs AccessDenied)
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, bucket, object); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGET.html
@@ -285,7 +304,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 				IsOwner:         false,
 			}) {
 				getObjectInfo := objectAPI.GetObjectInfo
-				if api.CacheAPI() != nil {
+				if api.CacheAPI() != nil && versionID == "" {
 					getObjectInfo = api.CacheAPI().GetObjectInfo
 				}
 
@@ -300,7 +319,8 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	// Object versions other than the current one are never cached.
+	if api.CacheAPI() != nil && versionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -323,6 +343,9 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 
 	gr, err := getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
 	if err != nil {
+		if _, ok := err.(MethodNotAllowed); ok {
+			setVersionHeaders(w, ObjectInfo{Bucket: bucket, VersionID: versionID, DeleteMarker: true})
+		}
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -360,6 +383,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	setVersionHeaders(w, objInfo)
 
 	setHeadGetRespHeaders(w, r.URL.Query())
 
@@ -429,13 +453,14 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	bucket := vars["bucket"]
 	object := vars["object"]
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
-		writeErrorResponseHeadersOnly(w, ErrNoSuchVersion)
+	versionID, s3Error := getRequestVersionID(objectAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponseHeadersOnly(w, s3Error)
 		return
 	}
 
 	getObjectInfo := objectAPI.GetObjectInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && versionID == "" {
 		getObjectInfo = api.CacheAPI().GetObjectInfo
 	}
 
@@ -444,8 +469,15 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket, versionID)
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	// Reading a specific version needs its own permission.
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if versionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, bucket, object); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectHEAD.html
@@ -495,6 +527,9 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 
 	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
+		if _, ok := err.(MethodNotAllowed); ok {
+			setVersionHeaders(w, ObjectInfo{Bucket: bucket, VersionID: versionID, DeleteMarker: true})
+		}
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
@@ -534,6 +569,7 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersionHeaders(w, objInfo)
 
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.URL.Query())
@@ -661,25 +697,21 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
-	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" {
-		// Check if versionId header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
-			return
-		}
+	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" && srcVersionID == "" {
+		srcVersionID = vid
+	}
+	// Any version other than "null" is only served by object layers
+	// which support versioning.
+	srcVersionID, s3Error := getRequestVersionID(objectAPI, srcVersionID)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
 	}
 
 	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
@@ -689,7 +721,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
+	// Copying from a specific version needs its own permission.
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if srcVersionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -699,10 +737,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(w, ErrInvalidMetadataDirective, r.URL, guessIsBrowserReq(r))
 		return
 	}
+
+	// Check if tagging directive is valid.
+	if !isTaggingDirectiveValid(r.Header) {
+		writeErrorResponse(w, ErrInvalidTaggingDirective, r.URL, guessIsBrowserReq(r))
+		return
+	}
 	// This request header needs to be set prior to setting ObjectOptions
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	setBucketEncryptionHeader(r.Header, dstBucket)
 
 	var srcOpts, dstOpts ObjectOptions
 	srcOpts, err := copySrcEncryptionOpts(ctx, r, srcBucket, srcObject)
@@ -723,6 +768,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	srcOpts.VersionID = srcVersionID
+	getOpts.VersionID = srcVersionID
+	setVersioningOpts(&dstOpts, dstBucket, "")
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -732,10 +780,22 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	// Deny overwriting an object protected by object lock.
+	if err = enforceObjectLock(ctx, objectAPI, r, dstBucket, dstObject, dstOpts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
+	// Copying an object onto itself on a versioned bucket or from an
+	// older version creates a new version, it is not a metadata update.
+	if dstOpts.Versioned || srcVersionID != "" {
+		cpSrcDstSame = false
+	}
+
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && srcVersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -784,6 +844,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		length = actualSize
 	}
 
+	// Deny copying beyond the hard quota of the destination bucket,
+	// a metadata update does not write any data.
+	var replacedSize int64
+	if !srcInfo.metadataOnly {
+		replacedSize = getQuotaObjectSize(ctx, objectAPI, dstBucket, dstObject, dstOpts)
+		if err = enforceBucketQuota(dstBucket, actualSize, replacedSize); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// Check if the destination bucket is on a remote site, this code only gets executed
 	// when federation is enabled, ie when globalDNSConfig is non 'nil'.
 	//
@@ -954,12 +1025,35 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	srcInfo.PutObjReader = pReader
 
+	srcTags := srcInfo.UserDefined[amzObjectTagging]
+
 	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo.UserDefined)
 	if err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Tags of the source object are copied unless x-amz-tagging-directive
+	// says REPLACE, irrespective of x-amz-metadata-directive.
+	delete(srcInfo.UserDefined, amzObjectTagging)
+	if isTaggingReplace(r.Header) {
+		if err = extractObjectTags(r.Header, srcInfo.UserDefined); err != nil {
+			writeErrorResponse(w, ErrInvalidTag, r.URL, guessIsBrowserReq(r))
+			return
+		}
+	} else if srcTags != "" {
+		srcInfo.UserDefined[amzObjectTagging] = srcTags
+	}
+
+	// Retention and legal hold of the source object are never copied,
+	// the destination gets them from the request or its bucket.
+	removeObjectLockMetadata(srcInfo.UserDefined)
+	if s3Error = setObjectLockMetadata(r, dstBucket, dstObject, srcInfo.UserDefined); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setReplicationStatus(srcInfo.UserDefined, r.Header, dstBucket, dstObject)
+
 	// Store the preserved compression metadata.
 	for k, v := range compressMetadata {
 		srcInfo.UserDefined[k] = v
@@ -1022,9 +1116,19 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	response := generateCopyObjectResponse(getDecryptedETag(r.Header, objInfo, false), objInfo.ModTime)
 	encodedSuccessResponse := encodeResponse(response)
 
+	setVersionHeaders(w, objInfo)
+	if srcVersionID != "" {
+		w.Header().Set(amzCopySourceVersionID, srcVersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	if !srcInfo.metadataOnly {
+		updateBucketUsage(dstBucket, objInfo, replacedSize)
+	}
+	replicateObjectAsync(dstBucket, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -1131,6 +1235,11 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err = extractObjectTags(r.Header, metadata); err != nil {
+		writeErrorResponse(w, ErrInvalidTag, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if rAuthType == authTypeStreamingSigned {
 		if contentEncoding, ok := metadata["content-encoding"]; ok {
 			contentEncoding = trimAwsChunkedContentEncoding(contentEncoding)
@@ -1161,10 +1270,16 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	reader = r.Body
 
 	// Check if put is allowed
-	if s3Err = isPutAllowed(rAuthType, bucket, object, r); s3Err != ErrNone {
+	if s3Err = isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectAction); s3Err != ErrNone {
+		writeErrorResponse(w, s3Err, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Err = setObjectLockMetadata(r, bucket, object, metadata); s3Err != ErrNone {
 		writeErrorResponse(w, s3Err, r.URL, guessIsBrowserReq(r))
 		return
 	}
+	setReplicationStatus(metadata, r.Header, bucket, object)
 
 	switch rAuthType {
 	case authTypeStreamingSigned:
@@ -1182,12 +1297,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 
 	case authTypePresigned, authTypeSigned:
-		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Err != ErrNone {
+		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion(), serviceS3); s3Err != ErrNone {
 			writeErrorResponse(w, s3Err, r.URL, guessIsBrowserReq(r))
 			return
 		}
 		if !skipContentSha256Cksum(r) {
-			sha256hex = getContentSha256Cksum(r)
+			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
 	}
 
@@ -1235,6 +1350,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	setBucketEncryptionHeader(r.Header, bucket)
 	// get gateway encryption options
 	var opts ObjectOptions
 	opts, err = putEncryptionOpts(ctx, r, bucket, object, nil)
@@ -1242,6 +1358,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket, "")
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -1251,6 +1368,20 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Deny overwriting an object protected by object lock.
+	if err = enforceObjectLock(ctx, objectAPI, r, bucket, object, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Deny writing beyond the hard quota of the bucket, the size of a
+	// compressed object is only known once written.
+	replacedSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, opts)
+	if err = enforceBucketQuota(bucket, actualSize, replacedSize); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	var objectEncryptionKey []byte
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(r.Header) && !hasSuffix(object, slashSeparator) { // handle SSE requests
@@ -1304,8 +1435,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	setVersionHeaders(w, objInfo)
+
 	writeSuccessResponseHeadersOnly(w)
 
+	updateBucketUsage(bucket, objInfo, replacedSize)
+	replicateObjectAsync(bucket, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -1364,6 +1500,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	setBucketEncryptionHeader(r.Header, bucket)
 
 	// get gateway encryption options
 	var opts ObjectOptions
@@ -1412,6 +1549,17 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if err = extractObjectTags(r.Header, metadata); err != nil {
+		writeErrorResponse(w, ErrInvalidTag, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := setObjectLockMetadata(r, bucket, object, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setReplicationStatus(metadata, r.Header, bucket, object)
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1480,25 +1628,21 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
-	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" {
-		// Check if X-Amz-Copy-Source-Version-Id header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
-			return
-		}
+	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" && srcVersionID == "" {
+		srcVersionID = vid
+	}
+	// Any version other than "null" is only served by object layers
+	// which support versioning.
+	srcVersionID, s3Error := getRequestVersionID(objectAPI, srcVersionID)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
 	}
 
 	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
@@ -1508,7 +1652,13 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
+	// Copying from a specific version needs its own permission.
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if srcVersionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1544,6 +1694,8 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	srcOpts.VersionID = srcVersionID
+	getOpts.VersionID = srcVersionID
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -1554,7 +1706,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && srcVersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -1738,6 +1890,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	response := generateCopyObjectPartResponse(partInfo.ETag, partInfo.LastModified)
 	encodedSuccessResponse := encodeResponse(response)
 
+	if srcVersionID != "" {
+		w.Header().Set(amzCopySourceVersionID, srcVersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
@@ -1829,7 +1985,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		s3Error   APIErrorCode
 	)
 	reader = r.Body
-	if s3Error = isPutAllowed(rAuthType, bucket, object, r); s3Error != ErrNone {
+	if s3Error = isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectAction); s3Error != ErrNone {
 		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1848,13 +2004,13 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 	case authTypePresigned, authTypeSigned:
-		if s3Error = reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Error != ErrNone {
+		if s3Error = reqSignatureV4Verify(r, globalServerConfig.GetRegion(), serviceS3); s3Error != ErrNone {
 			writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 			return
 		}
 
 		if !skipContentSha256Cksum(r) {
-			sha256hex = getContentSha256Cksum(r)
+			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
 	}
 
@@ -2257,6 +2413,21 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	setVersioningOpts(&opts, bucket, "")
+
+	// Deny overwriting an object protected by object lock.
+	if err = enforceObjectLock(ctx, objectAPI, r, bucket, object, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Deny completing the upload beyond the hard quota of the bucket.
+	replacedSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, opts)
+	if err = enforceBucketQuotaMultipart(ctx, objectAPI, bucket, object, uploadID, completeParts, replacedSize); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 	if api.CacheAPI() != nil {
 		completeMultiPartUpload = api.CacheAPI().CompleteMultipartUpload
@@ -2286,10 +2457,14 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 	// Set etag.
 	w.Header().Set("ETag", "\""+objInfo.ETag+"\"")
+	setVersionHeaders(w, objInfo)
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	updateBucketUsage(bucket, objInfo, replacedSize)
+	replicateObjectAsync(bucket, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -2327,13 +2502,20 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object); s3Error != ErrNone {
+	// Permanently removing a specific version needs its own permission.
+	var deleteObjectAction policy.Action = policy.DeleteObjectAction
+	if r.URL.Query().Get("versionId") != "" {
+		deleteObjectAction = policy.DeleteObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, deleteObjectAction, bucket, object); s3Error != ErrNone {
 		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
-		writeErrorResponse(w, ErrNoSuchVersion, r.URL, guessIsBrowserReq(r))
+	versionID, s3Error := getRequestVersionID(objectAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
 		return
 	}
 
@@ -2357,6 +2539,33 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		}
 	}
 
+	var opts ObjectOptions
+	setVersioningOpts(&opts, bucket, versionID)
+
+	// Deny removing an object version protected by object lock.
+	if err := enforceObjectLock(ctx, objectAPI, r, bucket, object, opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Deleting a version or deleting on a bucket with versioning configured
+	// either removes the version or places a delete marker.
+	if opts.VersionID != "" || opts.Versioned || opts.VersionSuspended {
+		objInfo, err := deleteObjectVersion(ctx, objectAPI, bucket, object, opts, r)
+		if err != nil {
+			switch toAPIErrorCode(ctx, err) {
+			case ErrNoSuchBucket:
+				// When bucket doesn't exist specially handle it.
+				writeErrorResponse(w, ErrNoSuchBucket, r.URL, guessIsBrowserReq(r))
+				return
+			}
+			// Ignore delete object errors while replying to client, since we are suppposed to reply only 204.
+		}
+		setVersionHeaders(w, objInfo)
+		writeSuccessNoContent(w)
+		return
+	}
+
 	// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
 	if err := deleteObject(ctx, objectAPI, api.CacheAPI(), bucket, object, r); err != nil {
 		switch toAPIErrorCode(ctx, err) {
@@ -2369,3 +2578,149 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	}
 	writeSuccessNoContent(w)
 }
+
+// PutObjectTaggingHandler - This HTTP handler replaces the tags of an
+// object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTtagging.html
+func (api objectAPIHandlers) PutObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectTagging")
+
+	defer logger.AuditLog(w, r, "PutObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutObjectTagging always needs Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if r.ContentLength > maxObjectTaggingSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	tags, err := tagging.ParseTagging(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrInvalidTag, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	opts := ObjectOptions{VersionID: versionID}
+	if err = objAPI.PutObjectTags(ctx, bucket, object, tags.String(), opts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetObjectTaggingHandler - This HTTP handler returns the tags of an
+// object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETtagging.html
+func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectTagging")
+
+	defer logger.AuditLog(w, r, "GetObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	tags, err := objAPI.GetObjectTags(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	tags.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Write object tags to client.
+	writeSuccessResponseXML(w, encodeResponse(tags))
+}
+
+// DeleteObjectTaggingHandler - This HTTP handler removes the tags of an
+// object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETEtagging.html
+func (api objectAPIHandlers) DeleteObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteObjectTagging")
+
+	defer logger.AuditLog(w, r, "DeleteObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	versionID, s3Error := getRequestVersionID(objAPI, r.URL.Query().Get("versionId"))
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err := objAPI.DeleteObjectTags(ctx, bucket, object, ObjectOptions{VersionID: versionID}); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if versionID != "" {
+		w.Header().Set(amzVersionID, versionID)
+	}
+
+	// Success.
+	writeSuccessNoContent(w)
+}
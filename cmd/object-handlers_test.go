@@ -32,6 +32,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
@@ -40,6 +41,8 @@ import (
 	"github.com/scriptburn/minio/cmd/crypto"
 	"github.com/scriptburn/minio/pkg/auth"
 	ioutilx "github.com/scriptburn/minio/pkg/ioutil"
+	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // Type to capture different modifications to API request to simulate failure cases.
@@ -1108,6 +1111,98 @@ func testAPIPutObjectStreamSigV4Handler(obj ObjectLayer, instanceType, bucketNam
 	}
 }
 
+// Wrapper for calling SelectObjectContent API handler tests for both XL multiple disks and FS single drive setup.
+func TestAPISelectObjectContentHandler(t *testing.T) {
+	globalPolicySys = NewPolicySys()
+
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPISelectObjectContentHandler, []string{"PutObject", "SelectObjectContent"})
+}
+
+func testAPISelectObjectContentHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	parquetData, err := ioutil.ReadFile("../pkg/s3select/testdata.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestXML := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>`
+
+	testCases := []struct {
+		objectName      string
+		data            []byte
+		expression      string
+		input           string
+		expectedRecords string
+	}{
+		{"data.csv", []byte("id,name\n1,a\n2,bb\n3,ccc\n"), "SELECT name FROM S3Object WHERE id = '2'",
+			"<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>", "bb\n"},
+		{"data.json", []byte(`{"id":1}` + "\n" + `{"id":2}` + "\n"), "SELECT id FROM S3Object WHERE id > 1",
+			"<JSON><Type>LINES</Type></JSON>", "2\n"},
+		{"data.parquet", parquetData, "SELECT two, three FROM S3Object",
+			"<Parquet></Parquet>", "bar,false\n"},
+	}
+
+	// Objects are stored compressed by server.
+	globalIsCompressionEnabled = true
+	oldExtensions := globalCompressExtensions
+	globalCompressExtensions = append([]string{".parquet"}, oldExtensions...)
+	defer func() {
+		globalIsCompressionEnabled = false
+		globalCompressExtensions = oldExtensions
+	}()
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for Put Object: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be 200, but instead found %d", i+1, instanceType, rec.Code)
+		}
+
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+		}
+		if !objInfo.IsCompressed() {
+			t.Fatalf("Test %d: %s: Expected object to be stored compressed", i+1, instanceType)
+		}
+
+		body := []byte(fmt.Sprintf(requestXML, testCase.expression, testCase.input))
+		rec = httptest.NewRecorder()
+		req, err = newTestSignedRequestV4("POST", makeTestTargetURL("", bucketName, testCase.objectName, url.Values{"select": {""}, "select-type": {"2"}}),
+			int64(len(body)), bytes.NewReader(body), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for Select Object Content: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be 200, but instead found %d", i+1, instanceType, rec.Code)
+		}
+
+		if !bytes.Contains(rec.Body.Bytes(), []byte(testCase.expectedRecords)) {
+			t.Fatalf("Test %d: %s: Expected records %q, but got response %q", i+1, instanceType, testCase.expectedRecords, rec.Body.String())
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectHandler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -3962,3 +4057,189 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling object tagging HTTP handler tests for both XL multiple disks and single node setup.
+func TestAPIObjectTaggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIObjectTaggingHandlers, []string{"PutObjectTagging", "GetObjectTagging", "DeleteObjectTagging", "GetObject", "PutObject"})
+}
+
+func testAPIObjectTaggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "tagged-object"
+	data := []byte("hello")
+
+	// Tags are accepted in x-amz-tagging header of PutObject.
+	testCases := []struct {
+		tags               string
+		expectedRespStatus int
+	}{
+		{"pii=true&pii=false", http.StatusBadRequest},
+		{"pii=true", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{"X-Amz-Tagging": testCase.tags})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	taggingData := []byte(`<Tagging><TagSet><Tag><Key>retention</Key><Value>short</Value></Tag><Tag><Key>pii</Key><Value>false</Value></Tag></TagSet></Tagging>`)
+
+	taggingTestCases := []struct {
+		method             string
+		objectName         string
+		data               []byte
+		expectedRespStatus int
+		expectedErrCode    string
+		expectedTags       map[string]string
+	}{
+		// Test case - 1.
+		// Tags set by PutObject are returned.
+		{"GET", objectName, nil, http.StatusOK, "", map[string]string{"pii": "true"}},
+		// Test case - 2.
+		// Duplicate tag keys are rejected.
+		{"PUT", objectName, []byte(`<Tagging><TagSet><Tag><Key>pii</Key><Value>true</Value></Tag><Tag><Key>pii</Key><Value>false</Value></Tag></TagSet></Tagging>`), http.StatusBadRequest, "InvalidTag", nil},
+		// Test case - 3.
+		// Non existent object.
+		{"PUT", "non-existent-object", taggingData, http.StatusNotFound, "NoSuchKey", nil},
+		// Test case - 4.
+		// Tags are replaced.
+		{"PUT", objectName, taggingData, http.StatusOK, "", nil},
+		// Test case - 5.
+		// Replaced tags are returned.
+		{"GET", objectName, nil, http.StatusOK, "", map[string]string{"pii": "false", "retention": "short"}},
+		// Test case - 6.
+		// Tags are removed.
+		{"DELETE", objectName, nil, http.StatusNoContent, "", nil},
+		// Test case - 7.
+		// No tags are left.
+		{"GET", objectName, nil, http.StatusOK, "", map[string]string{}},
+		// Test case - 8.
+		// Non existent object.
+		{"GET", "non-existent-object", nil, http.StatusNotFound, "NoSuchKey", nil},
+	}
+
+	for i, testCase := range taggingTestCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getObjectTaggingURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		if testCase.expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, rec.Body.String())
+			}
+			if errorResponse.Code != testCase.expectedErrCode {
+				t.Fatalf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedErrCode, errorResponse.Code)
+			}
+			continue
+		}
+
+		if testCase.method == "GET" {
+			tags, err := tagging.ParseTagging(rec.Body)
+			if err != nil {
+				t.Fatalf("Test %d: %s: Unable to parse object tags: %v", i+1, instanceType, err)
+			}
+			if !reflect.DeepEqual(tags.ToMap(), testCase.expectedTags) {
+				t.Fatalf("Test %d: %s: Expected tags %v, but instead found %v", i+1, instanceType, testCase.expectedTags, tags.ToMap())
+			}
+		}
+	}
+
+	// Anonymous GetObject is allowed by the bucket policy only for
+	// objects tagged public=yes.
+	bucketPolicy, err := policy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::`+bucketName+`/*"],"Condition":{"StringEquals":{"s3:ExistingObjectTag/public":["yes"]}}}]}`), bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to parse bucket policy: %v", instanceType, err)
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	defer globalPolicySys.Remove(bucketName)
+
+	for i, tags := range []string{"public=no", "public=yes"} {
+		if err = obj.PutObjectTags(context.Background(), bucketName, objectName, tags, ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: %s: Failed to set object tags: %v", i+1, instanceType, err)
+		}
+
+		rec := httptest.NewRecorder()
+		req, err := newTestRequest("GET", getGetObjectURL("", bucketName, objectName), 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+
+		expectedRespStatus := http.StatusForbidden
+		if tags == "public=yes" {
+			expectedRespStatus = http.StatusOK
+		}
+		if rec.Code != expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, expectedRespStatus, rec.Code)
+		}
+		if rec.Code == http.StatusOK && rec.Header().Get(amzTaggingCount) != "1" {
+			t.Fatalf("Test %d: %s: Expected tagging count to be `1`, but instead found `%s`", i+1, instanceType, rec.Header().Get(amzTaggingCount))
+		}
+	}
+	// Anonymous PutObject and PutObjectTagging are allowed by the bucket
+	// policy only for the tag env=prod, evaluated against the tags being
+	// stored, tag condition keys sent by the client are ignored.
+	bucketPolicy, err = policy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:PutObject","s3:PutObjectTagging"],"Resource":["arn:aws:s3:::`+bucketName+`/*"],"Condition":{"StringEquals":{"s3:RequestObjectTag/env":["prod"]}}}]}`), bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to parse bucket policy: %v", instanceType, err)
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+
+	prodTaggingData := []byte(`<Tagging><TagSet><Tag><Key>env</Key><Value>prod</Value></Tag></TagSet></Tagging>`)
+	otherTaggingData := []byte(`<Tagging><TagSet><Tag><Key>owner</Key><Value>ops</Value></Tag></TagSet></Tagging>`)
+	requestTagTestCases := []struct {
+		targetURL          string
+		data               []byte
+		headers            map[string]string
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// PutObject with env=prod in x-amz-tagging header.
+		{getPutObjectURL("", bucketName, objectName), data, map[string]string{"X-Amz-Tagging": "env=prod"}, http.StatusOK},
+		// Test case - 2.
+		// PutObject with env=dev in x-amz-tagging header.
+		{getPutObjectURL("", bucketName, objectName), data, map[string]string{"X-Amz-Tagging": "env=dev"}, http.StatusForbidden},
+		// Test case - 3.
+		// PutObjectTagging with env=prod in the tagging document.
+		{getObjectTaggingURL("", bucketName, objectName), prodTaggingData, nil, http.StatusOK},
+		// Test case - 4.
+		// x-amz-tagging header of PutObjectTagging is not stored.
+		{getObjectTaggingURL("", bucketName, objectName), otherTaggingData, map[string]string{"X-Amz-Tagging": "env=prod"}, http.StatusForbidden},
+		// Test case - 5.
+		// Tag condition key sent as query parameter.
+		{getObjectTaggingURL("", bucketName, objectName) + "&RequestObjectTag/env=prod", otherTaggingData, nil, http.StatusForbidden},
+		// Test case - 6.
+		// Tag condition key sent as header.
+		{getPutObjectURL("", bucketName, objectName), data, map[string]string{"RequestObjectTag/env": "prod"}, http.StatusForbidden},
+	}
+	for i, testCase := range requestTagTestCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestRequest("PUT", testCase.targetURL, int64(len(testCase.data)), bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		for k, v := range testCase.headers {
+			req.Header.Set(k, v)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+}
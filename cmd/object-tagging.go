@@ -0,0 +1,160 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/tagging"
+)
+
+const (
+	// Request header and metadata key carrying the URL encoded object tags.
+	amzObjectTagging = "X-Amz-Tagging"
+
+	// Response header carrying the number of tags of an object.
+	amzTaggingCount = "x-amz-tagging-count"
+
+	// Request header specifying whether CopyObject copies the tags of
+	// the source object or replaces them.
+	amzTaggingDirective = "X-Amz-Tagging-Directive"
+
+	// Tagging document with the maximum of 10 tags fits comfortably in
+	// this limit.
+	maxObjectTaggingSize = 1 * humanize.MiByte
+)
+
+// isTaggingDirectiveValid - check if tagging-directive is valid.
+func isTaggingDirectiveValid(h http.Header) bool {
+	if _, ok := h[amzTaggingDirective]; ok {
+		return isTaggingCopy(h) || isTaggingReplace(h)
+	}
+	// By default tags are copied.
+	return true
+}
+
+// Check if the tagging COPY is requested.
+func isTaggingCopy(h http.Header) bool {
+	return h.Get(amzTaggingDirective) == "COPY"
+}
+
+// Check if the tagging REPLACE is requested.
+func isTaggingReplace(h http.Header) bool {
+	return h.Get(amzTaggingDirective) == "REPLACE"
+}
+
+// extractObjectTags - validates the object tags sent in x-amz-tagging
+// header and adds them URL encoded to the object metadata.
+func extractObjectTags(h http.Header, metadata map[string]string) error {
+	if _, ok := h[amzObjectTagging]; !ok {
+		return nil
+	}
+
+	tags, err := tagging.ParseTags(h.Get(amzObjectTagging))
+	if err != nil {
+		return err
+	}
+
+	if s := tags.String(); s != "" {
+		metadata[amzObjectTagging] = s
+	}
+	return nil
+}
+
+// getObjectTags - returns the tags kept in the object metadata.
+func getObjectTags(objInfo ObjectInfo) (*tagging.Tagging, error) {
+	return tagging.ParseTags(objInfo.UserDefined[amzObjectTagging])
+}
+
+// hasObjectTagConditionKeyPrefix - returns whether the given request
+// header or query parameter name is an object tag condition key.
+func hasObjectTagConditionKeyPrefix(key string) bool {
+	key = strings.ToLower(key)
+	return strings.HasPrefix(key, "existingobjecttag/") || strings.HasPrefix(key, "requestobjecttag/")
+}
+
+// getObjectTagConditionValues - returns the values for
+// s3:ExistingObjectTag/<key> conditions from the tags of the requested
+// object and for s3:RequestObjectTag/<key> conditions from the tags being
+// stored, i.e. the x-amz-tagging header of PutObject, CopyObject and
+// NewMultipartUpload and the tagging document of PutObjectTagging.
+// Invalid requests are not rejected here, the handlers report them.
+func getObjectTagConditionValues(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (map[string][]string, APIErrorCode) {
+	values := make(map[string][]string)
+
+	switch action {
+	case policy.PutObjectAction:
+		// Object parts and CompleteMultipartUpload do not carry the
+		// tags, they are sent when the upload is initiated.
+		if _, ok := r.URL.Query()["uploadId"]; ok {
+			return values, ErrNone
+		}
+		if tags, err := tagging.ParseTags(r.Header.Get(amzObjectTagging)); err == nil {
+			for _, tag := range tags.TagSet.Tags {
+				values["RequestObjectTag/"+tag.Key] = []string{tag.Value}
+			}
+		}
+		return values, ErrNone
+	case policy.GetObjectAction, policy.GetObjectVersionAction, policy.GetObjectTaggingAction,
+		policy.PutObjectTaggingAction, policy.DeleteObjectTaggingAction:
+	default:
+		return values, ErrNone
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, ErrServerNotInitialized
+	}
+
+	if versionID, s3Err := getRequestVersionID(objAPI, r.URL.Query().Get("versionId")); s3Err == ErrNone {
+		if objInfo, err := objAPI.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{VersionID: versionID}); err == nil {
+			if tags, err := getObjectTags(objInfo); err == nil {
+				for _, tag := range tags.TagSet.Tags {
+					values["ExistingObjectTag/"+tag.Key] = []string{tag.Value}
+				}
+			}
+		}
+	}
+
+	if action == policy.PutObjectTaggingAction {
+		// To extract the tags from XML in request body, get copy of request body.
+		payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxObjectTaggingSize))
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return nil, ErrMalformedXML
+		}
+
+		if tags, err := tagging.ParseTagging(bytes.NewReader(payload)); err == nil {
+			for _, tag := range tags.TagSet.Tags {
+				values["RequestObjectTag/"+tag.Key] = []string{tag.Value}
+			}
+		}
+
+		// Populate payload again to handle it in HTTP handler.
+		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+
+	return values, ErrNone
+}
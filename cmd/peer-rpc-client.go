@@ -21,9 +21,16 @@ import (
 	"crypto/tls"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
 	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+	"github.com/scriptburn/minio/pkg/madmin"
 	xnet "github.com/scriptburn/minio/pkg/net"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/versioning"
 )
 
 // PeerRPCClient - peer RPC client talks to peer RPC server.
@@ -48,6 +55,16 @@ func (rpcClient *PeerRPCClient) SetBucketPolicy(bucketName string, bucketPolicy
 	return rpcClient.Call(peerServiceName+".SetBucketPolicy", &args, &reply)
 }
 
+// SetBucketVersioning - calls set bucket versioning RPC.
+func (rpcClient *PeerRPCClient) SetBucketVersioning(bucketName string, config *versioning.Versioning) error {
+	args := SetBucketVersioningArgs{
+		BucketName: bucketName,
+		Versioning: *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketVersioning", &args, &reply)
+}
+
 // RemoveBucketPolicy - calls remove bucket policy RPC.
 func (rpcClient *PeerRPCClient) RemoveBucketPolicy(bucketName string) error {
 	args := RemoveBucketPolicyArgs{
@@ -57,6 +74,120 @@ func (rpcClient *PeerRPCClient) RemoveBucketPolicy(bucketName string) error {
 	return rpcClient.Call(peerServiceName+".RemoveBucketPolicy", &args, &reply)
 }
 
+// SetBucketLifecycle - calls set bucket lifecycle RPC.
+func (rpcClient *PeerRPCClient) SetBucketLifecycle(bucketName string, bucketLifecycle *lifecycle.Lifecycle) error {
+	args := SetBucketLifecycleArgs{
+		BucketName: bucketName,
+		Lifecycle:  *bucketLifecycle,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketLifecycle", &args, &reply)
+}
+
+// SetBucketObjectLockConfig - calls set bucket object lock configuration RPC.
+func (rpcClient *PeerRPCClient) SetBucketObjectLockConfig(bucketName string, config *objectlock.Config) error {
+	args := SetBucketObjectLockConfigArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketObjectLockConfig", &args, &reply)
+}
+
+// SetBucketCORS - calls set bucket CORS configuration RPC.
+func (rpcClient *PeerRPCClient) SetBucketCORS(bucketName string, config *cors.Config) error {
+	args := SetBucketCORSArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketCORS", &args, &reply)
+}
+
+// RemoveBucketCORS - calls remove bucket CORS configuration RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketCORS(bucketName string) error {
+	args := RemoveBucketCORSArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketCORS", &args, &reply)
+}
+
+// SetBucketSSEConfig - calls set bucket encryption configuration RPC.
+func (rpcClient *PeerRPCClient) SetBucketSSEConfig(bucketName string, config *sse.BucketSSEConfig) error {
+	args := SetBucketSSEConfigArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketSSEConfig", &args, &reply)
+}
+
+// RemoveBucketSSEConfig - calls remove bucket encryption configuration RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketSSEConfig(bucketName string) error {
+	args := RemoveBucketSSEConfigArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketSSEConfig", &args, &reply)
+}
+
+// SetBucketQuota - calls set bucket quota RPC.
+func (rpcClient *PeerRPCClient) SetBucketQuota(bucketName string, q madmin.BucketQuota) error {
+	args := SetBucketQuotaArgs{
+		BucketName: bucketName,
+		Quota:      q,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketQuota", &args, &reply)
+}
+
+// RemoveBucketQuota - calls remove bucket quota RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketQuota(bucketName string) error {
+	args := RemoveBucketQuotaArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketQuota", &args, &reply)
+}
+
+// UpdateBucketUsage - calls update bucket usage RPC.
+func (rpcClient *PeerRPCClient) UpdateBucketUsage(changes map[string]int64) error {
+	args := UpdateBucketUsageArgs{
+		Changes: changes,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".UpdateBucketUsage", &args, &reply)
+}
+
+// SetBucketReplication - calls set bucket replication RPC.
+func (rpcClient *PeerRPCClient) SetBucketReplication(bucketName string, config *replication.Config) error {
+	args := SetBucketReplicationArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketReplication", &args, &reply)
+}
+
+// RemoveBucketReplication - calls remove bucket replication RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketReplication(bucketName string) error {
+	args := RemoveBucketReplicationArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketReplication", &args, &reply)
+}
+
+// RemoveBucketLifecycle - calls remove bucket lifecycle RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketLifecycle(bucketName string) error {
+	args := RemoveBucketLifecycleArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketLifecycle", &args, &reply)
+}
+
 // PutBucketNotification - calls put bukcet notification RPC.
 func (rpcClient *PeerRPCClient) PutBucketNotification(bucketName string, rulesMap event.RulesMap) error {
 	args := PutBucketNotificationArgs{
@@ -141,6 +272,28 @@ func (rpcClient *PeerRPCClient) LoadCredentials() error {
 	return rpcClient.Call(peerServiceName+".LoadCredentials", &args, &reply)
 }
 
+// LoadNotificationTarget - calls load notification target RPC.
+func (rpcClient *PeerRPCClient) LoadNotificationTarget(targetID event.TargetID) error {
+	args := LoadNotificationTargetArgs{
+		TargetID: targetID,
+	}
+	reply := VoidReply{}
+
+	return rpcClient.Call(peerServiceName+".LoadNotificationTarget", &args, &reply)
+}
+
+// GetTargetStatus - calls get target status RPC, returns whether each
+// target is online in the remote server.
+func (rpcClient *PeerRPCClient) GetTargetStatus(targetIDs []event.TargetID) ([]bool, error) {
+	args := GetTargetStatusArgs{
+		TargetIDs: targetIDs,
+	}
+	var reply []bool
+
+	err := rpcClient.Call(peerServiceName+".GetTargetStatus", &args, &reply)
+	return reply, err
+}
+
 // DrivePerfInfo - returns drive performance info for remote server.
 func (rpcClient *PeerRPCClient) DrivePerfInfo() (ServerDrivesPerfInfo, error) {
 	args := AuthArgs{}
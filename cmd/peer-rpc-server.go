@@ -26,9 +26,16 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/scriptburn/minio/cmd/logger"
 	xrpc "github.com/scriptburn/minio/cmd/rpc"
+	"github.com/scriptburn/minio/pkg/cors"
 	"github.com/scriptburn/minio/pkg/event"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+	"github.com/scriptburn/minio/pkg/madmin"
 	xnet "github.com/scriptburn/minio/pkg/net"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
+	"github.com/scriptburn/minio/pkg/versioning"
 )
 
 const peerServiceName = "Peer"
@@ -54,6 +61,48 @@ func (receiver *peerRPCReceiver) DeleteBucket(args *DeleteBucketArgs, reply *Voi
 
 	globalNotificationSys.RemoveNotification(args.BucketName)
 	globalPolicySys.Remove(args.BucketName)
+	globalBucketVersioningSys.Remove(args.BucketName)
+	globalLifecycleSys.Remove(args.BucketName)
+	globalBucketObjectLockSys.Remove(args.BucketName)
+	globalBucketCORSSys.Remove(args.BucketName)
+	globalBucketSSEConfigSys.Remove(args.BucketName)
+	globalBucketQuotaSys.Remove(args.BucketName)
+	globalBucketReplicationSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketSSEConfigArgs - set bucket encryption configuration RPC arguments.
+type SetBucketSSEConfigArgs struct {
+	AuthArgs
+	BucketName string
+	Config     sse.BucketSSEConfig
+}
+
+// SetBucketSSEConfig - handles set bucket encryption configuration RPC call which adds the configuration to globalBucketSSEConfigSys.
+func (receiver *peerRPCReceiver) SetBucketSSEConfig(args *SetBucketSSEConfigArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketSSEConfigSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketSSEConfigArgs - delete bucket encryption configuration RPC arguments.
+type RemoveBucketSSEConfigArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketSSEConfig - handles delete bucket encryption configuration RPC call which removes the configuration from globalBucketSSEConfigSys.
+func (receiver *peerRPCReceiver) RemoveBucketSSEConfig(args *RemoveBucketSSEConfigArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketSSEConfigSys.Remove(args.BucketName)
 	return nil
 }
 
@@ -92,6 +141,196 @@ func (receiver *peerRPCReceiver) RemoveBucketPolicy(args *RemoveBucketPolicyArgs
 	return nil
 }
 
+// SetBucketVersioningArgs - set bucket versioning RPC arguments.
+type SetBucketVersioningArgs struct {
+	AuthArgs
+	BucketName string
+	Versioning versioning.Versioning
+}
+
+// SetBucketVersioning - handles set bucket versioning RPC call which adds bucket versioning configuration to globalBucketVersioningSys.
+func (receiver *peerRPCReceiver) SetBucketVersioning(args *SetBucketVersioningArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketVersioningSys.Set(args.BucketName, args.Versioning)
+	return nil
+}
+
+// SetBucketLifecycleArgs - set bucket lifecycle RPC arguments.
+type SetBucketLifecycleArgs struct {
+	AuthArgs
+	BucketName string
+	Lifecycle  lifecycle.Lifecycle
+}
+
+// SetBucketLifecycle - handles set bucket lifecycle RPC call which adds bucket lifecycle configuration to globalLifecycleSys.
+func (receiver *peerRPCReceiver) SetBucketLifecycle(args *SetBucketLifecycleArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalLifecycleSys.Set(args.BucketName, args.Lifecycle)
+	return nil
+}
+
+// RemoveBucketLifecycleArgs - delete bucket lifecycle RPC arguments.
+type RemoveBucketLifecycleArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketLifecycle - handles delete bucket lifecycle RPC call which removes bucket lifecycle configuration from globalLifecycleSys.
+func (receiver *peerRPCReceiver) RemoveBucketLifecycle(args *RemoveBucketLifecycleArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalLifecycleSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketObjectLockConfigArgs - set bucket object lock configuration RPC arguments.
+type SetBucketObjectLockConfigArgs struct {
+	AuthArgs
+	BucketName string
+	Config     objectlock.Config
+}
+
+// SetBucketObjectLockConfig - handles set bucket object lock configuration RPC call which adds the configuration to globalBucketObjectLockSys.
+func (receiver *peerRPCReceiver) SetBucketObjectLockConfig(args *SetBucketObjectLockConfigArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketObjectLockSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// SetBucketCORSArgs - set bucket CORS configuration RPC arguments.
+type SetBucketCORSArgs struct {
+	AuthArgs
+	BucketName string
+	Config     cors.Config
+}
+
+// SetBucketCORS - handles set bucket CORS configuration RPC call which adds the configuration to globalBucketCORSSys.
+func (receiver *peerRPCReceiver) SetBucketCORS(args *SetBucketCORSArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketCORSSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketCORSArgs - delete bucket CORS configuration RPC arguments.
+type RemoveBucketCORSArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketCORS - handles delete bucket CORS configuration RPC call which removes the configuration from globalBucketCORSSys.
+func (receiver *peerRPCReceiver) RemoveBucketCORS(args *RemoveBucketCORSArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketCORSSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketQuotaArgs - set bucket quota RPC arguments.
+type SetBucketQuotaArgs struct {
+	AuthArgs
+	BucketName string
+	Quota      madmin.BucketQuota
+}
+
+// SetBucketQuota - handles set bucket quota RPC call which adds the quota to globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) SetBucketQuota(args *SetBucketQuotaArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// The usage of a bucket getting its first quota is crawled by the
+	// node setting the quota before calling this RPC.
+	globalBucketQuotaSys.Set(args.BucketName, args.Quota)
+	return globalBucketQuotaSys.loadUsage(objAPI)
+}
+
+// RemoveBucketQuotaArgs - delete bucket quota RPC arguments.
+type RemoveBucketQuotaArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketQuota - handles delete bucket quota RPC call which removes the quota from globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) RemoveBucketQuota(args *RemoveBucketQuotaArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketQuotaSys.Remove(args.BucketName)
+	return nil
+}
+
+// UpdateBucketUsageArgs - update bucket usage RPC arguments.
+type UpdateBucketUsageArgs struct {
+	AuthArgs
+	Changes map[string]int64
+}
+
+// UpdateBucketUsage - handles update bucket usage RPC call which adds the usage changes made on the calling node to globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) UpdateBucketUsage(args *UpdateBucketUsageArgs, reply *VoidReply) error {
+	globalBucketQuotaSys.applyUsageChanges(args.Changes)
+	return nil
+}
+
+// SetBucketReplicationArgs - set bucket replication RPC arguments.
+type SetBucketReplicationArgs struct {
+	AuthArgs
+	BucketName string
+	Config     replication.Config
+}
+
+// SetBucketReplication - handles set bucket replication RPC call which adds the configuration to globalBucketReplicationSys.
+func (receiver *peerRPCReceiver) SetBucketReplication(args *SetBucketReplicationArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketReplicationSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketReplicationArgs - delete bucket replication RPC arguments.
+type RemoveBucketReplicationArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketReplication - handles delete bucket replication RPC call which removes the configuration from globalBucketReplicationSys.
+func (receiver *peerRPCReceiver) RemoveBucketReplication(args *RemoveBucketReplicationArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketReplicationSys.Remove(args.BucketName)
+	return nil
+}
+
 // PutBucketNotificationArgs - put bucket notification RPC arguments.
 type PutBucketNotificationArgs struct {
 	AuthArgs
@@ -241,6 +480,44 @@ func (receiver *peerRPCReceiver) LoadCredentials(args *AuthArgs, reply *VoidRepl
 	return globalConfigSys.Load(newObjectLayerFn())
 }
 
+// LoadNotificationTargetArgs - load notification target RPC arguments.
+type LoadNotificationTargetArgs struct {
+	AuthArgs
+	TargetID event.TargetID
+}
+
+// LoadNotificationTarget - handles load notification target RPC call which
+// reloads the server config and replaces the target in globalNotificationSys.
+func (receiver *peerRPCReceiver) LoadNotificationTarget(args *LoadNotificationTargetArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	return loadNotificationTarget(objAPI, args.TargetID)
+}
+
+// GetTargetStatusArgs - get target status RPC arguments.
+type GetTargetStatusArgs struct {
+	AuthArgs
+	TargetIDs []event.TargetID
+}
+
+// GetTargetStatus - handles get target status RPC call which returns
+// whether each target is online in this server.
+func (receiver *peerRPCReceiver) GetTargetStatus(args *GetTargetStatusArgs, reply *[]bool) error {
+	if globalNotificationSys == nil {
+		return errServerNotInitialized
+	}
+
+	*reply = make([]bool, len(args.TargetIDs))
+	for i, targetID := range args.TargetIDs {
+		(*reply)[i] = globalNotificationSys.GetTargetStatus(targetID)
+	}
+
+	return nil
+}
+
 // DrivePerfInfo - handles drive performance RPC call
 func (receiver *peerRPCReceiver) DrivePerfInfo(args *AuthArgs, reply *ServerDrivesPerfInfo) error {
 	objAPI := newObjectLayerFn()
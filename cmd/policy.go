@@ -32,6 +32,7 @@ import (
 	"github.com/scriptburn/minio/cmd/logger"
 	"github.com/scriptburn/minio/pkg/handlers"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/policy/condition"
 )
 
 // PolicySys - policy subsystem.
@@ -207,6 +208,21 @@ func getConditionValues(request *http.Request, locationConstraint string) map[st
 		}
 	}
 
+	// Values of identity condition keys are set only by the server,
+	// values sent in headers or query parameters are ignored.
+	for _, key := range condition.IdentityKeys {
+		delete(args, key.Name())
+		delete(args, http.CanonicalHeaderKey(key.Name()))
+	}
+
+	// Values of object tag condition keys are set from the tags of the
+	// object and the tags being stored, see getObjectTagConditionValues().
+	for key := range args {
+		if hasObjectTagConditionKeyPrefix(key) {
+			delete(args, key)
+		}
+	}
+
 	if locationConstraint != "" {
 		args["LocationConstraint"] = []string{locationConstraint}
 	}
@@ -214,6 +230,39 @@ func getConditionValues(request *http.Request, locationConstraint string) map[st
 	return args
 }
 
+// getIdentityConditionValues - returns a copy of given condition values along
+// with values of identity condition keys, username is the user policies are
+// evaluated for, userID is the access key of the request and claims are the
+// claims of its session token.
+func getIdentityConditionValues(conditionValues map[string][]string, username, userID string, claims map[string]interface{}) map[string][]string {
+	args := make(map[string][]string, len(conditionValues)+2)
+	for key, values := range conditionValues {
+		args[key] = values
+	}
+
+	args[condition.Key(condition.AWSUsername).Name()] = []string{username}
+	args[condition.Key(condition.AWSUserID).Name()] = []string{userID}
+
+	for _, key := range condition.JWTKeys {
+		switch v := claims[strings.TrimPrefix(string(key), "jwt:")].(type) {
+		case string:
+			args[key.Name()] = []string{v}
+		case []interface{}:
+			var values []string
+			for _, value := range v {
+				if s, ok := value.(string); ok {
+					values = append(values, s)
+				}
+			}
+			if len(values) > 0 {
+				args[key.Name()] = values
+			}
+		}
+	}
+
+	return args
+}
+
 // getPolicyConfig - get policy config for given bucket name.
 func getPolicyConfig(objAPI ObjectLayer, bucketName string) (*policy.Policy, error) {
 	// Construct path to policy.json for the given bucket.
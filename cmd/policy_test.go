@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -422,3 +423,48 @@ func TestBucketAccessPolicyToPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestGetIdentityConditionValues(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://127.0.0.1:9000/mybucket/myobject?username=bob&jwt:sub=bob&prefix=home", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Username", "bob")
+	req.Header.Set("Userid", "bob")
+
+	// Identity values sent by the client are ignored.
+	conditionValues := getConditionValues(req, "")
+	for _, key := range []string{"username", "Username", "Userid", "jwt:sub"} {
+		if _, ok := conditionValues[key]; ok {
+			t.Fatalf("Unexpected client supplied value for %s", key)
+		}
+	}
+
+	claims := map[string]interface{}{
+		"sub":    "2f8b2c1e",
+		"groups": []interface{}{"developers", "testers"},
+		"exp":    float64(1565295972),
+	}
+	values := getIdentityConditionValues(conditionValues, "alice", "Q3AM3UQ867SPQQA43P2F", claims)
+
+	expectedValues := map[string][]string{
+		"username":   {"alice"},
+		"userid":     {"Q3AM3UQ867SPQQA43P2F"},
+		"jwt:sub":    {"2f8b2c1e"},
+		"jwt:groups": {"developers", "testers"},
+		"prefix":     {"home"},
+	}
+	for key, expected := range expectedValues {
+		if !reflect.DeepEqual(values[key], expected) {
+			t.Fatalf("%s: expected: %v, got: %v", key, expected, values[key])
+		}
+	}
+	if _, ok := values["jwt:exp"]; ok {
+		t.Fatal("Unexpected value for non identity claim")
+	}
+
+	// Condition values of the request are not modified.
+	if _, ok := conditionValues["username"]; ok {
+		t.Fatal("Condition values of the request must not be modified")
+	}
+}
@@ -86,6 +86,11 @@ func formatXLCleanupTmpLocalEndpoints(endpoints EndpointList) error {
 		if err := mkdirAll(pathJoin(endpoint.Path, minioMetaTmpBucket), 0777); err != nil {
 			return err
 		}
+		// Disks formatted before versioning support lack the versions
+		// meta volume, create it if needed but never clean it up.
+		if err := mkdirAll(pathJoin(endpoint.Path, minioMetaVersionsBucket), 0777); err != nil {
+			return err
+		}
 	}
 	return nil
 }
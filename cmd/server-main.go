@@ -356,6 +356,41 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize policy system")
 	}
 
+	// Initialize bucket versioning system.
+	if err = globalBucketVersioningSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket versioning system")
+	}
+
+	// Initialize lifecycle system.
+	if err = globalLifecycleSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize lifecycle system")
+	}
+
+	// Initialize bucket object lock system.
+	if err = globalBucketObjectLockSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket object lock system")
+	}
+
+	// Initialize bucket CORS system.
+	if err = globalBucketCORSSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket CORS system")
+	}
+
+	// Initialize bucket encryption configuration system.
+	if err = globalBucketSSEConfigSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket encryption configuration system")
+	}
+
+	// Initialize bucket replication system.
+	if err = globalBucketReplicationSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket replication system")
+	}
+
+	// Initialize bucket quota system.
+	if err = globalBucketQuotaSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket quota system")
+	}
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
@@ -371,6 +406,9 @@ func serverMain(ctx *cli.Context) {
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
 
+	// Apply bucket lifecycle rules in background.
+	initDailyLifecycle()
+
 	// Prints the formatted startup message once object layer is initialized.
 	printStartupMessage(getAPIEndpoints())
 
@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -35,7 +36,9 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/scriptburn/minio/pkg/event"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
 )
 
 // API suite container common to both FS and XL.
@@ -77,6 +80,7 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.SetUpSuite(c)
 	suite.TestObjectDir(c)
 	suite.TestBucketPolicy(c)
+	suite.TestBucketReplicationConfig(c)
 	suite.TestDeleteBucket(c)
 	suite.TestDeleteBucketNotEmpty(c)
 	suite.TestDeleteMultipleObjects(c)
@@ -93,6 +97,7 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.TestCopyObject(c)
 	suite.TestPutObject(c)
 	suite.TestListBuckets(c)
+	suite.TestListenNotificationHandler(c)
 	suite.TestValidateSignature(c)
 	suite.TestSHA256Mismatch(c)
 	suite.TestPutObjectLongName(c)
@@ -382,6 +387,54 @@ func (s *TestSuiteCommon) TestBucketPolicy(c *check) {
 	c.Assert(response.StatusCode, http.StatusNotFound)
 }
 
+// TestBucketReplicationConfig - Inserts the bucket replication configuration and verifies it by fetching and removing it.
+func (s *TestSuiteCommon) TestBucketReplicationConfig(c *check) {
+	bucketName := getRandomBucketName()
+
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest("PUT", getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client := http.Client{Transport: s.transport}
+	response, err := client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	replicationData := []byte(`<ReplicationConfiguration><Rule><ID>backup</ID><Status>Enabled</Status><Prefix></Prefix><Destination><Bucket>arn:aws:s3:::backup</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`)
+	defer globalBucketReplicationSys.Remove(bucketName)
+
+	// Replication requests pass the global handlers of the server.
+	request, err = newTestSignedRequest("PUT", getBucketReplicationURL(s.endPoint, bucketName),
+		int64(len(replicationData)), bytes.NewReader(replicationData), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest("GET", getBucketReplicationURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	var config replication.Config
+	err = xmlDecoder(response.Body, &config, response.ContentLength)
+	c.Assert(err, nil)
+	c.Assert(config.Destination().BucketName(), "backup")
+
+	request, err = newTestSignedRequest("DELETE", getBucketReplicationURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+}
+
 // TestDeleteBucket - validates DELETE bucket operation.
 func (s *TestSuiteCommon) TestDeleteBucket(c *check) {
 	bucketName := getRandomBucketName()
@@ -522,6 +575,76 @@ func (s *TestSuiteCommon) TestListenBucketNotificationHandler(c *check) {
 	}
 }
 
+func (s *TestSuiteCommon) TestListenNotificationHandler(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	req, err := newTestSignedRequest("PUT", getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client := http.Client{Transport: s.transport}
+	// execute the request.
+	response, err := client.Do(req)
+	c.Assert(err, nil)
+	// assert the http response status code.
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	validEvents := []string{"s3:ObjectCreated:*"}
+	invalidEvents := []string{"invalidEvent"}
+
+	req, err = newTestSignedRequest("GET",
+		getListenBucketNotificationURL(s.endPoint, "", []string{}, []string{}, invalidEvents),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client = http.Client{Transport: s.transport}
+	// execute the request.
+	response, err = client.Do(req)
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "A specified event is not supported for notifications.", http.StatusBadRequest)
+
+	req, err = newTestSignedRequest("GET",
+		getListenBucketNotificationURL(s.endPoint, "", []string{"a", "b"}, []string{}, validEvents),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client = http.Client{Transport: s.transport}
+	// execute the request.
+	response, err = client.Do(req)
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "Cannot specify more than one prefix rule in a filter.", http.StatusBadRequest)
+
+	req, err = newTestSignedRequest("GET",
+		getListenBucketNotificationURL(s.endPoint, "", []string{"listen/"}, []string{}, validEvents),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client = http.Client{Transport: s.transport}
+	// execute the request.
+	response, err = client.Do(req)
+	c.Assert(err, nil)
+	// assert the http response status code.
+	c.Assert(response.StatusCode, http.StatusOK)
+	defer response.Body.Close()
+
+	req, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, "listen/object"),
+		int64(len("hello")), bytes.NewReader([]byte("hello")), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to upload the object.
+	putResponse, err := client.Do(req)
+	c.Assert(err, nil)
+	// assert the http response status code.
+	c.Assert(putResponse.StatusCode, http.StatusOK)
+
+	var records struct{ Records []event.Event }
+	c.Assert(json.NewDecoder(response.Body).Decode(&records), nil)
+	c.Assert(len(records.Records), 1)
+	c.Assert(records.Records[0].S3.Bucket.Name, bucketName)
+	c.Assert(records.Records[0].S3.Object.Key, "listen%2Fobject")
+}
+
 // Test deletes multple objects and verifies server resonse.
 func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	// generate a random bucket name.
@@ -17,11 +17,16 @@
 package cmd
 
 import (
+	"bytes"
 	"crypto/hmac"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/sha256-simd"
 	"github.com/scriptburn/minio/pkg/auth"
 )
@@ -30,6 +35,10 @@ import (
 // client did not calculate sha256 of the payload.
 const unsignedPayload = "UNSIGNED-PAYLOAD"
 
+// Maximum size of STS request body, STS requests carry form values
+// in the body and AWS SDKs do not send 'X-Amz-Content-Sha256' for them.
+const stsRequestBodyLimit = 10 * humanize.KiByte
+
 // skipContentSha256Cksum returns true if caller needs to skip
 // payload checksum, false if not.
 func skipContentSha256Cksum(r *http.Request) bool {
@@ -53,7 +62,19 @@ func skipContentSha256Cksum(r *http.Request) bool {
 }
 
 // Returns SHA256 for calculating canonical-request.
-func getContentSha256Cksum(r *http.Request) string {
+func getContentSha256Cksum(r *http.Request, stype serviceType) string {
+	// STS requests are signed with the SHA256 of their body, which
+	// is read here and put back for parsing form values later.
+	if stype == serviceSTS {
+		payload, err := ioutil.ReadAll(io.LimitReader(r.Body, stsRequestBodyLimit))
+		if err != nil {
+			return ""
+		}
+		sum256 := sha256.Sum256(payload)
+		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		return hex.EncodeToString(sum256[:])
+	}
+
 	var (
 		defaultSha256Cksum string
 		v                  []string
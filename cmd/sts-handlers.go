@@ -17,17 +17,25 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/scriptburn/minio/cmd/logger"
 	"github.com/scriptburn/minio/pkg/auth"
+	iampolicy "github.com/scriptburn/minio/pkg/iam/policy"
 	"github.com/scriptburn/minio/pkg/iam/validator"
 )
 
 const (
 	// STS API version.
 	stsAPIVersion = "2011-06-15"
+
+	// Maximum length of the session policy of AssumeRole.
+	maxSessionPolicySize = 2048
 )
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -41,6 +49,20 @@ func registerSTSRouter(router *mux.Router) {
 	// STS Router
 	stsRouter := router.NewRoute().PathPrefix("/").Subrouter()
 
+	// AssumeRole, AWS SDKs send form values in the body signed
+	// for 'sts' service.
+	stsRouter.Methods("POST").MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+		return strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") &&
+			strings.HasPrefix(r.Header.Get("Authorization"), signV4Algorithm) &&
+			r.URL.Path == slashSeparator &&
+			len(r.URL.Query()) == 0
+	}).HandlerFunc(httpTraceAll(sts.AssumeRole))
+
+	// AssumeRole
+	stsRouter.Methods("POST").HandlerFunc(httpTraceAll(sts.AssumeRole)).
+		Queries("Action", "AssumeRole").
+		Queries("Version", stsAPIVersion)
+
 	// AssumeRoleWithClientGrants
 	stsRouter.Methods("POST").HandlerFunc(httpTraceAll(sts.AssumeRoleWithClientGrants)).
 		Queries("Action", "AssumeRoleWithClientGrants").
@@ -53,6 +75,137 @@ func registerSTSRouter(router *mux.Router) {
 		Queries("Version", stsAPIVersion).
 		Queries("WebIdentityToken", "{Token:.*}")
 
+	// AssumeRoleWithLDAPIdentity, form values may also be sent in the
+	// body of unsigned requests.
+	stsRouter.Methods("POST").MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+		return strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") &&
+			r.Header.Get("Authorization") == "" &&
+			r.URL.Path == slashSeparator &&
+			len(r.URL.Query()) == 0
+	}).HandlerFunc(httpTraceAll(sts.AssumeRoleWithLDAPIdentity))
+
+	// AssumeRoleWithLDAPIdentity
+	stsRouter.Methods("POST").HandlerFunc(httpTraceAll(sts.AssumeRoleWithLDAPIdentity)).
+		Queries("Action", "AssumeRoleWithLDAPIdentity").
+		Queries("Version", stsAPIVersion).
+		Queries("LDAPUsername", "{LDAPUsername:.*}").
+		Queries("LDAPPassword", "{LDAPPassword:.*}")
+
+}
+
+// checkAssumeRoleAuth - validates the signature of AssumeRole request and
+// returns credentials of the regular user requesting temporary credentials.
+func checkAssumeRoleAuth(ctx context.Context, r *http.Request) (auth.Credentials, STSErrorCode) {
+	if getRequestAuthType(r) != authTypeSigned {
+		return auth.Credentials{}, ErrSTSAccessDenied
+	}
+
+	region := globalServerConfig.GetRegion()
+	if s3Err := isReqAuthenticated(ctx, r, region, serviceSTS); s3Err != ErrNone {
+		return auth.Credentials{}, ErrSTSAccessDenied
+	}
+
+	cred, owner, s3Err := getReqAccessKeyV4(r, region, serviceSTS)
+	if s3Err != ErrNone {
+		return auth.Credentials{}, ErrSTSAccessDenied
+	}
+
+	// Root credentials, temporary credentials and service accounts
+	// are not allowed to assume role.
+	if owner || cred.IsTemp() || cred.ParentUser != "" {
+		return auth.Credentials{}, ErrSTSAccessDenied
+	}
+
+	return cred, ErrSTSNone
+}
+
+// AssumeRole - implementation of AWS STS API for regular Minio users
+// to get temporary credentials, optionally restricted by a session policy.
+//
+// Eg:-
+//    $ aws sts assume-role --role-arn arn:xxx:xxx --role-session-name anything --endpoint-url https://minio:9000
+func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AssumeRole")
+
+	defer logger.AuditLog(w, r, "AssumeRole", nil)
+
+	if globalIAMSys == nil || globalNotificationSys == nil {
+		writeSTSErrorResponse(w, ErrSTSNotInitialized)
+		return
+	}
+
+	user, stsErr := checkAssumeRoleAuth(ctx, r)
+	if stsErr != ErrSTSNone {
+		writeSTSErrorResponse(w, stsErr)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	if r.Form.Get("Action") != "AssumeRole" || r.Form.Get("Version") != stsAPIVersion {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	duration, err := validator.GetDefaultExpiration(r.Form.Get("DurationSeconds"))
+	if err != nil {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	var sessionPolicy *iampolicy.Policy
+	if sessionPolicyStr := r.Form.Get("Policy"); sessionPolicyStr != "" {
+		if len(sessionPolicyStr) > maxSessionPolicySize {
+			writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+			return
+		}
+
+		sessionPolicy, err = iampolicy.ParseConfig(strings.NewReader(sessionPolicyStr))
+		if err != nil || sessionPolicy.IsEmpty() {
+			writeSTSErrorResponse(w, ErrSTSMalformedPolicyDocument)
+			return
+		}
+	}
+
+	m := map[string]interface{}{
+		"exp": float64(time.Now().UTC().Add(duration).Unix()),
+	}
+
+	secret := globalServerConfig.GetCredential().SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInternalError)
+		return
+	}
+	cred.ParentUser = user.AccessKey
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUserWithParent(cred, sessionPolicy); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInternalError)
+		return
+	}
+
+	// Notify all other Minio peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUsers() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	encodedSuccessResponse := encodeResponse(&AssumeRoleResponse{
+		Result: AssumeRoleResult{
+			Credentials: cred,
+		},
+	})
+
+	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
 // AssumeRoleWithWebIdentity - implementation of AWS STS API supporting OAuth2.0
@@ -223,3 +376,89 @@ func (sts *stsAPIHandlers) AssumeRoleWithClientGrants(w http.ResponseWriter, r *
 
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
+
+// AssumeRoleWithLDAPIdentity - implementation of Minio STS extension API
+// for users of the LDAP identity provider, temporary credentials are
+// allowed canned policies mapped to the user DN and DNs of its groups.
+//
+// Eg:-
+//    $ curl -X POST 'https://minio:9000/?Action=AssumeRoleWithLDAPIdentity&LDAPUsername=foo&LDAPPassword=bar&Version=2011-06-15'
+func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AssumeRoleWithLDAPIdentity")
+
+	defer logger.AuditLog(w, r, "AssumeRoleWithLDAPIdentity", nil)
+
+	if globalIAMSys == nil || globalNotificationSys == nil || !globalLDAPConfig.IsEnabled() {
+		writeSTSErrorResponse(w, ErrSTSNotInitialized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	if r.Form.Get("Action") != "AssumeRoleWithLDAPIdentity" || r.Form.Get("Version") != stsAPIVersion {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	duration, err := validator.GetDefaultExpiration(r.Form.Get("DurationSeconds"))
+	if err != nil {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	userDN, groupDNs, err := globalLDAPConfig.Authenticate(r.Form.Get("LDAPUsername"), r.Form.Get("LDAPPassword"))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSAccessDenied)
+		return
+	}
+
+	// Users without any mapped policies are not allowed to get
+	// temporary credentials, unless policies are evaluated by OPA.
+	policyNames := globalIAMSys.LDAPPolicyNames(userDN, groupDNs)
+	if len(policyNames) == 0 && globalPolicyOPA == nil {
+		writeSTSErrorResponse(w, ErrSTSAccessDenied)
+		return
+	}
+	sort.Strings(policyNames)
+
+	m := map[string]interface{}{
+		"exp":      float64(time.Now().UTC().Add(duration).Unix()),
+		"ldapUser": userDN,
+	}
+
+	secret := globalServerConfig.GetCredential().SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInternalError)
+		return
+	}
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, strings.Join(policyNames, ",")); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrSTSInternalError)
+		return
+	}
+
+	// Notify all other Minio peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUsers() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	encodedSuccessResponse := encodeResponse(&AssumeRoleWithLDAPResponse{
+		Result: LDAPIdentityResult{
+			Credentials: cred,
+		},
+	})
+
+	writeSuccessResponseXML(w, encodedSuccessResponse)
+}
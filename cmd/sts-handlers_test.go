@@ -0,0 +1,200 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/sha256-simd"
+	"github.com/scriptburn/minio/pkg/auth"
+	"github.com/scriptburn/minio/pkg/iam/policy"
+	"github.com/scriptburn/minio/pkg/madmin"
+)
+
+// signSTSRequestV4 - signs the request with signature v4 for 'sts'
+// service the way AWS SDKs do, without 'X-Amz-Content-Sha256'.
+func signSTSRequestV4(req *http.Request, body string, accessKey, secretKey string) {
+	currTime := UTCNow()
+	req.Header.Set("X-Amz-Date", currTime.Format(iso8601Format))
+
+	signedHeaders := make(http.Header)
+	signedHeaders.Set("host", req.Host)
+	signedHeaders.Set("content-type", req.Header.Get("Content-Type"))
+	signedHeaders.Set("x-amz-date", req.Header.Get("X-Amz-Date"))
+
+	sum256 := sha256.Sum256([]byte(body))
+	region := globalServerConfig.GetRegion()
+	scope := getScope(currTime, region, serviceSTS)
+	canonicalRequest := getCanonicalRequest(signedHeaders, hex.EncodeToString(sum256[:]),
+		req.URL.RawQuery, req.URL.Path, req.Method)
+	stringToSign := getStringToSign(canonicalRequest, currTime, scope)
+	signature := getSignature(getSigningKey(secretKey, currTime, region, serviceSTS), stringToSign)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signV4Algorithm, accessKey, scope, getSignedHeaders(signedHeaders), signature))
+}
+
+func TestAssumeRoleHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for STS handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	defer func(iamSys *IAMSys) {
+		globalIAMSys = iamSys
+	}(globalIAMSys)
+	globalIAMSys = NewIAMSys()
+	if err = globalIAMSys.Init(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	userCred := auth.Credentials{AccessKey: "stsuser", SecretKey: "stsuser123"}
+	if err = globalIAMSys.SetUser(userCred.AccessKey, madmin.UserInfo{SecretKey: userCred.SecretKey, Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetUserPolicy(userCred.AccessKey, []string{"readwrite"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	// Executes AssumeRole request with form values in the body signed
+	// with given credentials.
+	execAssumeRole := func(path string, cred auth.Credentials, form url.Values) *httptest.ResponseRecorder {
+		body := form.Encode()
+		req, err := http.NewRequest("POST", "http://127.0.0.1:9000"+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		signSTSRequestV4(req, body, cred.AccessKey, cred.SecretKey)
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assumeRoleForm := func(durationSeconds, sessionPolicy string) url.Values {
+		form := url.Values{}
+		form.Set("Action", "AssumeRole")
+		form.Set("Version", stsAPIVersion)
+		if durationSeconds != "" {
+			form.Set("DurationSeconds", durationSeconds)
+		}
+		if sessionPolicy != "" {
+			form.Set("Policy", sessionPolicy)
+		}
+		return form
+	}
+
+	sessionPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`
+
+	testCases := []struct {
+		cred               auth.Credentials
+		form               url.Values
+		expectedStatusCode int
+	}{
+		// Root credentials are not allowed to assume role.
+		{globalServerConfig.GetCredential(), assumeRoleForm("", ""), http.StatusForbidden},
+		// Wrong secret key.
+		{auth.Credentials{AccessKey: userCred.AccessKey, SecretKey: "wrongsecret"}, assumeRoleForm("", ""), http.StatusForbidden},
+		// Duration out of range.
+		{userCred, assumeRoleForm("100", ""), http.StatusBadRequest},
+		// Malformed session policy.
+		{userCred, assumeRoleForm("", "{not-a-policy"), http.StatusBadRequest},
+		// Session policy too large.
+		{userCred, assumeRoleForm("", strings.Repeat(" ", maxSessionPolicySize+1)), http.StatusBadRequest},
+		// Unknown API version.
+		{userCred, url.Values{"Action": []string{"AssumeRole"}, "Version": []string{"2010-01-01"}}, http.StatusBadRequest},
+		// Valid requests.
+		{userCred, assumeRoleForm("", ""), http.StatusOK},
+		{userCred, assumeRoleForm("900", sessionPolicy), http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		rec := execAssumeRole(slashSeparator, testCase.cred, testCase.form)
+		if rec.Code != testCase.expectedStatusCode {
+			t.Fatalf("Case %d: expected status code %d, got %d: %s", i+1, testCase.expectedStatusCode, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Signed form uploads to buckets and objects are not routed
+	// to AssumeRole.
+	if rec := execAssumeRole("/mybucket/myobject", userCred, assumeRoleForm("", "")); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected request to an object path not to be routed to STS, got %d", rec.Code)
+	}
+
+	rec := execAssumeRole(slashSeparator, userCred, assumeRoleForm("900", sessionPolicy))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("AssumeRole failed: %s", rec.Body.String())
+	}
+	var resp AssumeRoleResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	tempCred := resp.Result.Credentials
+	if tempCred.SessionToken == "" {
+		t.Fatal("Expected a session token in temporary credentials")
+	}
+	if expiry := time.Until(tempCred.Expiration); expiry <= 0 || expiry > 900*time.Second {
+		t.Fatalf("Unexpected expiration of temporary credentials %v", tempCred.Expiration)
+	}
+
+	cred, ok := globalIAMSys.GetUser(tempCred.AccessKey)
+	if !ok || cred.SecretKey != tempCred.SecretKey || cred.ParentUser != userCred.AccessKey {
+		t.Fatalf("Unexpected temporary credentials %#v", cred)
+	}
+
+	// Temporary credentials are allowed what the parent user is
+	// allowed, restricted by the session policy.
+	args := iampolicy.Args{
+		AccountName: tempCred.AccessKey,
+		Action:      iampolicy.GetObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "myobject",
+	}
+	if !globalIAMSys.IsAllowed(args) {
+		t.Fatal("Expected GetObject to be allowed for temporary credentials")
+	}
+	args.Action = iampolicy.PutObjectAction
+	if globalIAMSys.IsAllowed(args) {
+		t.Fatal("Expected PutObject to be denied by the session policy")
+	}
+
+	// Temporary credentials are not allowed to assume role.
+	if rec = execAssumeRole(slashSeparator, tempCred, assumeRoleForm("", "")); rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected temporary credentials to be denied, got %d", rec.Code)
+	}
+
+	// Temporary credentials are revoked with their parent user.
+	if err = globalIAMSys.DeleteUser(userCred.AccessKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = globalIAMSys.GetUser(tempCred.AccessKey); ok {
+		t.Fatal("Expected temporary credentials to be removed with the parent user")
+	}
+}
@@ -855,7 +855,7 @@ func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires i
 
 	region := globalServerConfig.GetRegion()
 	date := UTCNow()
-	scope := getScope(date, region)
+	scope := getScope(date, region, serviceS3)
 	credential := fmt.Sprintf("%s/%s", accessKeyID, scope)
 
 	// Set URL query.
@@ -874,7 +874,7 @@ func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires i
 	queryStr := strings.Replace(query.Encode(), "+", "%20", -1)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, req.URL.Path, req.Method)
 	stringToSign := getStringToSign(canonicalRequest, date, scope)
-	signingKey := getSigningKey(secretAccessKey, date, region)
+	signingKey := getSigningKey(secretAccessKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	req.URL.RawQuery = query.Encode()
@@ -1064,7 +1064,7 @@ func signRequestV4(req *http.Request, accessKey, secretKey string) error {
 
 // getCredentialString generate a credential string.
 func getCredentialString(accessKeyID, location string, t time.Time) string {
-	return accessKeyID + "/" + getScope(t, location)
+	return accessKeyID + "/" + getScope(t, location, serviceS3)
 }
 
 // getMD5HashBase64 returns MD5 hash in base64 encoding of given data.
@@ -1407,6 +1407,27 @@ func getDeleteObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+// return URL for getting, setting and removing the tags of an object.
+func getObjectTaggingURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("tagging", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for getting and setting the retention of an object.
+func getObjectRetentionURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("retention", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for getting and setting the legal hold of an object.
+func getObjectLegalHoldURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("legal-hold", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
 // return URL for deleting multiple objects from a bucket.
 func getMultiDeleteObjectURL(endPoint, bucketName string) string {
 	queryValue := url.Values{}
@@ -1453,6 +1474,41 @@ func getDeletePolicyURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for bucket object lock configuration operations.
+func getBucketObjectLockConfigURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("object-lock", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket CORS configuration operations.
+func getBucketCORSURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket encryption configuration operations.
+func getBucketEncryptionURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("encryption", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket replication operations.
+func getBucketReplicationURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("replication", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket lifecycle operations.
+func getBucketLifecycleURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("lifecycle", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for creating the bucket.
 func getMakeBucketURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", url.Values{})
@@ -2053,12 +2109,36 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "PutObject":
 			// Register PutObject handler.
 			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectHandler)
+		case "SelectObjectContent":
+			// Register SelectObjectContent handler.
+			bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.SelectObjectContentHandler).Queries("select", "").Queries("select-type", "2")
 		case "DeleteObject":
 			// Register Delete Object handler.
 			bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.DeleteObjectHandler)
 		case "CopyObject":
 			// Register Copy Object  handler.
 			bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectHandler)
+		case "PutObjectTagging":
+			// Register PutObjectTagging handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectTaggingHandler).Queries("tagging", "")
+		case "GetObjectTagging":
+			// Register GetObjectTagging handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectTaggingHandler).Queries("tagging", "")
+		case "DeleteObjectTagging":
+			// Register DeleteObjectTagging handler.
+			bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.DeleteObjectTaggingHandler).Queries("tagging", "")
+		case "PutObjectRetention":
+			// Register PutObjectRetention handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectRetentionHandler).Queries("retention", "")
+		case "GetObjectRetention":
+			// Register GetObjectRetention handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectRetentionHandler).Queries("retention", "")
+		case "PutObjectLegalHold":
+			// Register PutObjectLegalHold handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectLegalHoldHandler).Queries("legal-hold", "")
+		case "GetObjectLegalHold":
+			// Register GetObjectLegalHold handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectLegalHoldHandler).Queries("legal-hold", "")
 		case "PutBucketPolicy":
 			// Register PutBucket Policy handler.
 			bucket.Methods("PUT").HandlerFunc(api.PutBucketPolicyHandler).Queries("policy", "")
@@ -2068,6 +2148,48 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "GetBucketPolicy":
 			// Register Get Bucket policy HTTP Handler.
 			bucket.Methods("GET").HandlerFunc(api.GetBucketPolicyHandler).Queries("policy", "")
+		case "PutBucketLifecycle":
+			// Register PutBucketLifecycle handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketLifecycleHandler).Queries("lifecycle", "")
+		case "GetBucketLifecycle":
+			// Register GetBucketLifecycle handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketLifecycleHandler).Queries("lifecycle", "")
+		case "DeleteBucketLifecycle":
+			// Register DeleteBucketLifecycle handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketLifecycleHandler).Queries("lifecycle", "")
+		case "PutBucketObjectLockConfig":
+			// Register PutBucketObjectLockConfig handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketObjectLockConfigHandler).Queries("object-lock", "")
+		case "GetBucketObjectLockConfig":
+			// Register GetBucketObjectLockConfig handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketObjectLockConfigHandler).Queries("object-lock", "")
+		case "PutBucketCors":
+			// Register PutBucketCors handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketCorsHandler).Queries("cors", "")
+		case "GetBucketCors":
+			// Register GetBucketCors handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketCorsHandler).Queries("cors", "")
+		case "DeleteBucketCors":
+			// Register DeleteBucketCors handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketCorsHandler).Queries("cors", "")
+		case "PutBucketEncryption":
+			// Register PutBucketEncryption handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketEncryptionHandler).Queries("encryption", "")
+		case "GetBucketEncryption":
+			// Register GetBucketEncryption handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketEncryptionHandler).Queries("encryption", "")
+		case "DeleteBucketEncryption":
+			// Register DeleteBucketEncryption handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketEncryptionHandler).Queries("encryption", "")
+		case "PutBucketReplication":
+			// Register PutBucketReplication handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketReplicationHandler).Queries("replication", "")
+		case "GetBucketReplication":
+			// Register GetBucketReplication handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketReplicationHandler).Queries("replication", "")
+		case "DeleteBucketReplication":
+			// Register DeleteBucketReplication handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketReplicationHandler).Queries("replication", "")
 		case "GetBucketLocation":
 			// Register GetBucketLocation handler.
 			bucket.Methods("GET").HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
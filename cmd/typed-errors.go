@@ -83,5 +83,22 @@ var errNoSuchUser = errors.New("Specified user does not exist")
 // error returned in IAM subsystem when policy doesn't exist.
 var errNoSuchPolicy = errors.New("Specified canned policy does not exist")
 
+// error returned in IAM subsystem when group doesn't exist.
+var errNoSuchGroup = errors.New("Specified group does not exist")
+
+// error returned in IAM subsystem when service account doesn't exist.
+var errNoSuchServiceAccount = errors.New("Specified service account does not exist")
+
+// error returned in IAM subsystem when an action on users is requested
+// for service accounts or temporary credentials.
+var errIAMActionNotAllowed = errors.New("Specified IAM action is not allowed on service accounts and temporary credentials")
+
+// error returned when notification target doesn't exist.
+var errNoSuchNotificationTarget = errors.New("Specified notification target does not exist")
+
+// error returned in IAM subsystem when a non-empty group needs to be
+// deleted.
+var errGroupNotEmpty = errors.New("Specified group is not empty - cannot remove it")
+
 // error returned when access is denied.
 var errAccessDenied = errors.New("Do not have enough permissions to access this resource")
@@ -173,6 +173,9 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 					return toJSONError(err)
 				}
 
+				// Notify bucket created event.
+				sendBucketEvent(event.BucketCreated, args.BucketName, r)
+
 				reply.UIVersion = browser.UIVersion
 				return nil
 			}
@@ -185,6 +188,9 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 		return toJSONError(err, args.BucketName)
 	}
 
+	// Notify bucket created event.
+	sendBucketEvent(event.BucketCreated, args.BucketName, r)
+
 	reply.UIVersion = browser.UIVersion
 	return nil
 }
@@ -254,6 +260,9 @@ func (web *webAPIHandlers) DeleteBucket(r *http.Request, args *RemoveBucketArgs,
 		}
 	}
 
+	// Notify bucket removed event.
+	sendBucketEvent(event.BucketRemoved, args.BucketName, r)
+
 	return nil
 }
 
@@ -587,6 +596,23 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		return nil
 	}
 
+	var opts ObjectOptions
+	setVersioningOpts(&opts, args.BucketName, "")
+
+	// removeObject - deletes the object unless it is protected by object
+	// lock, on a bucket with versioning configured a delete marker is
+	// placed as DeleteObject API does.
+	removeObject := func(objectName string) error {
+		if err := enforceObjectLock(context.Background(), objectAPI, r, args.BucketName, objectName, opts); err != nil {
+			return err
+		}
+		if opts.Versioned || opts.VersionSuspended {
+			_, err := deleteObjectVersion(context.Background(), objectAPI, args.BucketName, objectName, opts, r)
+			return err
+		}
+		return deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, objectName, r)
+	}
+
 	var err error
 next:
 	for _, objectName := range args.Objects {
@@ -610,7 +636,7 @@ next:
 				return toJSONError(errAccessDenied)
 			}
 
-			if err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, objectName, r); err != nil {
+			if err = removeObject(objectName); err != nil {
 				break next
 			}
 			continue
@@ -637,8 +663,7 @@ next:
 			}
 			marker = lo.NextMarker
 			for _, obj := range lo.Objects {
-				err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, obj.Name, r)
-				if err != nil {
+				if err = removeObject(obj.Name); err != nil {
 					break next
 				}
 			}
@@ -875,6 +900,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	setBucketEncryptionHeader(r.Header, bucket)
 
 	// Require Content-Length to be set in the request
 	size := r.ContentLength
@@ -890,6 +916,12 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s3Error := setObjectLockMetadata(r, bucket, object, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setReplicationStatus(metadata, r.Header, bucket, object)
+
 	var pReader *PutObjReader
 	var reader io.Reader = r.Body
 	actualSize := size
@@ -938,6 +970,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket, "")
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(r.Header) && !hasSuffix(object, slashSeparator) { // handle SSE requests
 			rawReader := hashReader
@@ -968,6 +1001,20 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Deny overwriting an object protected by object lock.
+	if err = enforceObjectLock(ctx, objectAPI, r, bucket, object, opts); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	// Deny writing beyond the hard quota of the bucket, the size of a
+	// compressed object is only known once written.
+	replacedSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, opts)
+	if err = enforceBucketQuota(bucket, actualSize, replacedSize); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	putObject := objectAPI.PutObject
 	if !hasServerSideEncryptionHeader(r.Header) && web.CacheAPI() != nil {
 		putObject = web.CacheAPI().PutObject
@@ -989,6 +1036,9 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	updateBucketUsage(bucket, objInfo, replacedSize)
+	replicateObjectAsync(bucket, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -1722,7 +1772,7 @@ func presignedGet(host, bucket, object string, expiry int64, creds auth.Credenti
 
 	date := UTCNow()
 	dateStr := date.Format(iso8601Format)
-	credential := fmt.Sprintf("%s/%s", accessKey, getScope(date, region))
+	credential := fmt.Sprintf("%s/%s", accessKey, getScope(date, region, serviceS3))
 
 	var expiryStr = "604800" // Default set to be expire in 7days.
 	if expiry < 604800 && expiry > 0 {
@@ -1743,8 +1793,8 @@ func presignedGet(host, bucket, object string, expiry int64, creds auth.Credenti
 	extractedSignedHeaders := make(http.Header)
 	extractedSignedHeaders.Set("host", host)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, path, "GET")
-	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region))
-	signingKey := getSigningKey(secretKey, date, region)
+	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region, serviceS3))
+	signingKey := getSigningKey(secretKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	// Construct the final presigned URL.
@@ -1876,6 +1926,10 @@ func toWebAPIError(err error) APIError {
 		return getAPIError(ErrObjectExistsAsDirectory)
 	case ObjectNotFound:
 		return getAPIError(ErrNoSuchKey)
+	case ObjectLocked:
+		return getAPIError(ErrObjectLocked)
+	case BucketQuotaExceeded:
+		return getAPIError(ErrBucketQuotaExceeded)
 	case ObjectNameInvalid:
 		return getAPIError(ErrNoSuchKey)
 	case InsufficientWriteQuorum:
@@ -142,6 +142,13 @@ func testLoginWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler)
 	apiRouter := initTestWebRPCEndPoint(obj)
 	credentials := globalServerConfig.GetCredential()
 
+	// Logins of unknown users are authenticated against IAM.
+	defer func(iamSys *IAMSys) {
+		globalIAMSys = iamSys
+	}(globalIAMSys)
+	globalIAMSys = NewIAMSys()
+	globalIAMSys.Init(obj)
+
 	// test cases with sample input and expected output.
 	testCases := []struct {
 		username string
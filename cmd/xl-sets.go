@@ -29,9 +29,15 @@ import (
 
 	"github.com/scriptburn/minio/cmd/logger"
 	"github.com/scriptburn/minio/pkg/bpool"
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
 	"github.com/scriptburn/minio/pkg/madmin"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
 	"github.com/scriptburn/minio/pkg/sync/errgroup"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // setsStorageAPI is encapsulated type for Close()
@@ -511,6 +517,96 @@ func (s *xlSets) DeleteBucketPolicy(ctx context.Context, bucket string) error {
 	return removePolicyConfig(ctx, s, bucket)
 }
 
+// SetBucketLifecycle sets lifecycle on bucket
+func (s *xlSets) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	return saveLifecycleConfig(ctx, s, bucket, lifecycle)
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (s *xlSets) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return getLifecycleConfig(s, bucket)
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (s *xlSets) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return removeLifecycleConfig(ctx, s, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (s *xlSets) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, s, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (s *xlSets) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(s, bucket)
+}
+
+// SetBucketCORS sets CORS configuration on bucket
+func (s *xlSets) SetBucketCORS(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveCORSConfig(ctx, s, bucket, config)
+}
+
+// GetBucketCORS will get CORS configuration on bucket
+func (s *xlSets) GetBucketCORS(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getCORSConfig(s, bucket)
+}
+
+// DeleteBucketCORS deletes CORS configuration on bucket
+func (s *xlSets) DeleteBucketCORS(ctx context.Context, bucket string) error {
+	return removeCORSConfig(ctx, s, bucket)
+}
+
+// SetBucketSSEConfig sets server side encryption configuration on bucket
+func (s *xlSets) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.BucketSSEConfig) error {
+	return saveBucketSSEConfig(ctx, s, bucket, config)
+}
+
+// GetBucketSSEConfig will get server side encryption configuration on bucket
+func (s *xlSets) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.BucketSSEConfig, error) {
+	return getBucketSSEConfig(s, bucket)
+}
+
+// DeleteBucketSSEConfig deletes server side encryption configuration on bucket
+func (s *xlSets) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, s, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (s *xlSets) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, s, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (s *xlSets) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(s, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (s *xlSets) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, s, bucket)
+}
+
+// PutObjectTags - replaces the tags of an object in the hashedSet based on the object name.
+func (s *xlSets) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
+	return s.getHashedSet(object).PutObjectTags(ctx, bucket, object, tags, opts)
+}
+
+// GetObjectTags - returns the tags of an object from the hashedSet based on the object name.
+func (s *xlSets) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tagging.Tagging, error) {
+	return s.getHashedSet(object).GetObjectTags(ctx, bucket, object, opts)
+}
+
+// DeleteObjectTags - removes the tags of an object in the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return s.getHashedSet(object).DeleteObjectTags(ctx, bucket, object, opts)
+}
+
+// UpdateObjectMetadata - updates the metadata of an object in the hashedSet based on the object name.
+func (s *xlSets) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error {
+	return s.getHashedSet(object).UpdateObjectMetadata(ctx, bucket, object, metadata, opts)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (s *xlSets) IsNotificationSupported() bool {
 	return s.getHashedSet("").IsNotificationSupported()
@@ -526,6 +622,11 @@ func (s *xlSets) IsEncryptionSupported() bool {
 	return s.getHashedSet("").IsEncryptionSupported()
 }
 
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (s *xlSets) IsVersioningSupported() bool {
+	return s.getHashedSet("").IsVersioningSupported()
+}
+
 // IsCompressionSupported returns whether compression is applicable for this layer.
 func (s *xlSets) IsCompressionSupported() bool {
 	return s.getHashedSet("").IsCompressionSupported()
@@ -616,6 +717,11 @@ func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string)
 	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
 }
 
+// DeleteObjectVersion - deletes a version of an object from the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).DeleteObjectVersion(ctx, bucket, object, opts)
+}
+
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
 	srcSet := s.getHashedSet(srcObject)
@@ -815,6 +921,60 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 	return result, nil
 }
 
+// ListObjectVersions - implements listing of object versions across sets, the
+// namespace is walked like in ListObjects() and the versions of each object
+// are read from its hashedSet.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	if err = checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, s); err != nil {
+		return result, err
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	if maxKeys == 0 || (delimiter == slashSeparator && prefix == slashSeparator) {
+		return result, nil
+	}
+
+	// Default is recursive, if delimiter is set then list non recursive.
+	recursive := delimiter != slashSeparator
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	isLeaf := func(bucket, entry string) bool {
+		entry = strings.TrimSuffix(entry, slashSeparator)
+		// Verify if we are at the leaf, a leaf is where we
+		// see `xl.json` inside a directory.
+		return s.getHashedSet(entry).isObject(bucket, entry)
+	}
+
+	isLeafDir := func(bucket, entry string) bool {
+		// Verify prefixes in all sets.
+		for _, set := range s.sets {
+			if set.isObjectDir(bucket, entry) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var setDisks = make([][]StorageAPI, 0, len(s.sets))
+	for _, set := range s.sets {
+		setDisks = append(setDisks, set.getLoadBalancedDisks())
+	}
+
+	listDir := listDirSetsFactory(ctx, isLeaf, isLeafDir, setDisks...)
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, keyMarker, recursive, listDir, isLeaf, isLeafDir, endWalkCh)
+
+	return listObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, walkResultCh,
+		func(object string) ([]ObjectInfo, error) {
+			return s.getHashedSet(object).getObjectVersions(ctx, bucket, object)
+		})
+}
+
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
 	// In list multipart uploads we are going to treat input prefix as the object,
 	// this means that we are not supporting directory navigation.
@@ -22,7 +22,12 @@ import (
 	"sync"
 
 	"github.com/scriptburn/minio/cmd/logger"
+	"github.com/scriptburn/minio/pkg/cors"
+	"github.com/scriptburn/minio/pkg/lifecycle"
+	"github.com/scriptburn/minio/pkg/objectlock"
 	"github.com/scriptburn/minio/pkg/policy"
+	"github.com/scriptburn/minio/pkg/replication"
+	"github.com/scriptburn/minio/pkg/sse"
 )
 
 // list all errors that can be ignore in a bucket operation.
@@ -256,6 +261,16 @@ func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string) error {
 				}
 				dErrs[index] = err
 			}
+
+			// Cleanup all the noncurrent object versions.
+			err = cleanupDir(ctx, disk, minioMetaVersionsBucket, retainSlash(bucket))
+
+			if err != nil {
+				if err == errVolumeNotFound {
+					return
+				}
+				dErrs[index] = err
+			}
 		}(index, disk)
 	}
 
@@ -289,6 +304,76 @@ func (xl xlObjects) DeleteBucketPolicy(ctx context.Context, bucket string) error
 	return removePolicyConfig(ctx, xl, bucket)
 }
 
+// SetBucketLifecycle sets lifecycle on bucket
+func (xl xlObjects) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	return saveLifecycleConfig(ctx, xl, bucket, lifecycle)
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (xl xlObjects) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return getLifecycleConfig(xl, bucket)
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (xl xlObjects) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return removeLifecycleConfig(ctx, xl, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (xl xlObjects) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (xl xlObjects) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(xl, bucket)
+}
+
+// SetBucketCORS sets CORS configuration on bucket
+func (xl xlObjects) SetBucketCORS(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveCORSConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketCORS will get CORS configuration on bucket
+func (xl xlObjects) GetBucketCORS(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getCORSConfig(xl, bucket)
+}
+
+// DeleteBucketCORS deletes CORS configuration on bucket
+func (xl xlObjects) DeleteBucketCORS(ctx context.Context, bucket string) error {
+	return removeCORSConfig(ctx, xl, bucket)
+}
+
+// SetBucketSSEConfig sets server side encryption configuration on bucket
+func (xl xlObjects) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.BucketSSEConfig) error {
+	return saveBucketSSEConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketSSEConfig will get server side encryption configuration on bucket
+func (xl xlObjects) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.BucketSSEConfig, error) {
+	return getBucketSSEConfig(xl, bucket)
+}
+
+// DeleteBucketSSEConfig deletes server side encryption configuration on bucket
+func (xl xlObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, xl, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (xl xlObjects) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (xl xlObjects) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(xl, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (xl xlObjects) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, xl, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (xl xlObjects) IsNotificationSupported() bool {
 	return true
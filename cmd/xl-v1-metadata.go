@@ -154,6 +154,10 @@ type xlMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// Captures all the individual object `xl.json`.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of the current object `xl.json`, empty for the "null" version.
+	VersionID string `json:"versionId,omitempty"`
+	// Indicates if the current object `xl.json` is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
 }
 
 // XL metadata constants.
@@ -216,6 +220,8 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		ModTime:         m.Stat.ModTime,
 		ContentType:     m.Meta["content-type"],
 		ContentEncoding: m.Meta["content-encoding"],
+		VersionID:       m.VersionID,
+		DeleteMarker:    m.DeleteMarker,
 	}
 
 	objInfo.backendType = BackendErasure
@@ -307,6 +313,7 @@ func getXLMetaInQuorum(ctx context.Context, metaArr []xlMetaV1, modTime time.Tim
 			for _, p := range meta.Parts {
 				h.Write([]byte(p.Name))
 			}
+			h.Write([]byte(meta.VersionID))
 			metaHashes[i] = hex.EncodeToString(h.Sum(nil))
 		}
 	}
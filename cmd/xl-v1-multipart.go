@@ -211,6 +211,9 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 	xlMeta.Stat.ModTime = UTCNow()
 	xlMeta.Meta = meta
 
+	// Remember the object path, lifecycle rules abort uploads by prefix.
+	xlMeta.Meta[lifecycleUploadPathKey] = pathJoin(bucket, object)
+
 	uploadID := mustGetUUID()
 	uploadIDPath := xl.getUploadIDDir(bucket, object, uploadID)
 	tempUploadIDPath := uploadID
@@ -711,9 +714,15 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	// Save successfully calculated md5sum.
 	xlMeta.Meta["etag"] = s3MD5
 
+	// The upload path is only needed while the upload is incomplete.
+	delete(xlMeta.Meta, lifecycleUploadPathKey)
+
 	// Save the consolidated actual size.
 	xlMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// Save the version ID of the new object.
+	xlMeta.VersionID = newObjectVersionID(opts)
+
 	tempUploadIDPath := uploadID
 
 	// Update all xl metadata, make sure to not modify fields like
@@ -722,6 +731,7 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		partsMetadata[index].Stat = xlMeta.Stat
 		partsMetadata[index].Meta = xlMeta.Meta
 		partsMetadata[index].Parts = xlMeta.Parts
+		partsMetadata[index].VersionID = xlMeta.VersionID
 	}
 
 	// Write unique `xl.json` for each disk.
@@ -735,7 +745,26 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(rErr, minioMetaMultipartBucket, uploadIDPath)
 	}
 
-	if xl.isObject(bucket, object) {
+	if opts.Versioned || opts.VersionSuspended {
+		// Deny if WORM is enabled
+		if globalWORMEnabled && xl.isObject(bucket, object) {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+
+		// Keep the existing object as a noncurrent version, it becomes
+		// current again if the new version can not be committed.
+		preserved, perr := xl.preserveCurrentVersion(ctx, bucket, object, writeQuorum, opts)
+		if perr != nil {
+			return oi, toObjectErr(perr, bucket, object)
+		}
+		if preserved {
+			defer func() {
+				if e != nil {
+					logger.LogIf(ctx, xl.promoteNoncurrentVersion(ctx, bucket, object, writeQuorum))
+				}
+			}()
+		}
+	} else if xl.isObject(bucket, object) {
 		// Deny if WORM is enabled
 		if globalWORMEnabled {
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
@@ -775,7 +804,9 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 
 	// Success, return object info.
-	return xlMeta.ToObjectInfo(bucket, object), nil
+	oi = xlMeta.ToObjectInfo(bucket, object)
+	oi.IsLatest = true
+	return oi, nil
 }
 
 // AbortMultipartUpload - aborts an ongoing multipart operation
@@ -869,7 +900,14 @@ func (xl xlObjects) cleanupStaleMultipartUploadsOnDisk(ctx context.Context, disk
 			if err != nil {
 				continue
 			}
-			if now.Sub(fi.ModTime) > expiry {
+			expired := now.Sub(fi.ModTime) > expiry
+			if !expired && !globalLifecycleSys.IsEmpty() {
+				xlMeta, err := readXLMeta(ctx, disk, minioMetaMultipartBucket, uploadIDPath)
+				if err == nil {
+					expired = isUploadExpiredByLifecycle(xlMeta.Meta, fi.ModTime)
+				}
+			}
+			if expired {
 				xl.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, len(xl.getDisks())/2+1, false)
 			}
 		}
@@ -29,6 +29,7 @@ import (
 	"github.com/scriptburn/minio/cmd/logger"
 	"github.com/scriptburn/minio/pkg/hash"
 	"github.com/scriptburn/minio/pkg/mimedb"
+	"github.com/scriptburn/minio/pkg/tagging"
 )
 
 // list all errors which can be ignored in object operations.
@@ -84,10 +85,22 @@ func (xl xlObjects) prepareFile(ctx context.Context, bucket, object string, size
 func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, e error) {
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
+	// Copying an object onto itself on a versioned bucket or from an
+	// older version creates a new version, it is not a metadata update.
+	if dstOpts.Versioned || srcOpts.VersionID != "" {
+		cpSrcDstSame = false
+	}
+
+	// Resolve the location of the requested source object version.
+	metaBucket, metaObject, _, err := xl.resolveObjectVersion(ctx, srcBucket, srcObject, srcOpts.VersionID)
+	if err != nil {
+		return oi, toObjectErr(err, srcBucket, srcObject)
+	}
+
 	// Read metadata associated with the object from all disks.
 	storageDisks := xl.getDisks()
 
-	metaArr, errs := readAllXLMetadata(ctx, storageDisks, srcBucket, srcObject)
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, metaBucket, metaObject)
 
 	// get Quorum for this object
 	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -206,7 +219,7 @@ func (xl xlObjects) GetObjectNInfo(ctx context.Context, bucket, object string, r
 	}
 
 	var objInfo ObjectInfo
-	objInfo, err = xl.getObjectInfo(ctx, bucket, object)
+	objInfo, err = xl.getObjectInfoVersion(ctx, bucket, object, opts.VersionID)
 	if err != nil {
 		nsUnlocker()
 		return nil, toObjectErr(err, bucket, object)
@@ -271,8 +284,14 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return toObjectErr(err, bucket, object)
 	}
 
+	// Resolve the location of the requested object version.
+	srcBucket, srcObject, _, err := xl.resolveObjectVersion(ctx, bucket, object, opts.VersionID)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
 	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), srcBucket, srcObject)
 
 	// get Quorum for this object
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -356,7 +375,7 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partName)
 			endOffset := getErasureShardFileEndOffset(partOffset, partLength, partSize, xlMeta.Erasure.BlockSize, xlMeta.Erasure.DataBlocks)
-			bitrotReaders[index] = newBitrotReader(disk, bucket, pathJoin(object, partName), checksumInfo.Algorithm, endOffset, checksumInfo.Hash)
+			bitrotReaders[index] = newBitrotReader(disk, srcBucket, pathJoin(srcObject, partName), checksumInfo.Algorithm, endOffset, checksumInfo.Hash)
 		}
 
 		err := erasure.Decode(ctx, writer, bitrotReaders, partOffset, partLength, partSize)
@@ -439,9 +458,9 @@ func (xl xlObjects) GetObjectInfo(ctx context.Context, bucket, object string, op
 		return oi, nil
 	}
 
-	info, err := xl.getObjectInfo(ctx, bucket, object)
+	info, err := xl.getObjectInfoVersion(ctx, bucket, object, opts.VersionID)
 	if err != nil {
-		return oi, toObjectErr(err, bucket, object)
+		return info, toObjectErr(err, bucket, object)
 	}
 
 	return info, nil
@@ -582,7 +601,15 @@ func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string) (o
 		return objInfo, err
 	}
 
-	return xlMeta.ToObjectInfo(bucket, object), nil
+	// An object whose current version is a delete marker is
+	// treated as not found.
+	if xlMeta.DeleteMarker {
+		return objInfo, errFileNotFound
+	}
+
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = true
+	return objInfo, nil
 }
 
 func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, isDir bool, errs []error) {
@@ -872,7 +899,26 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		metadata["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
 
-	if xl.isObject(bucket, object) {
+	if opts.Versioned || opts.VersionSuspended {
+		// Deny if WORM is enabled
+		if globalWORMEnabled && xl.isObject(bucket, object) {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+
+		// Keep the existing object as a noncurrent version, it becomes
+		// current again if the new version can not be committed.
+		preserved, perr := xl.preserveCurrentVersion(ctx, bucket, object, writeQuorum, opts)
+		if perr != nil {
+			return ObjectInfo{}, toObjectErr(perr, bucket, object)
+		}
+		if preserved {
+			defer func() {
+				if err != nil {
+					logger.LogIf(ctx, xl.promoteNoncurrentVersion(ctx, bucket, object, writeQuorum))
+				}
+			}()
+		}
+	} else if xl.isObject(bucket, object) {
 		// Deny if WORM is enabled
 		if globalWORMEnabled {
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
@@ -893,12 +939,15 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		}
 	}
 
+	versionID := newObjectVersionID(opts)
+
 	// Fill all the necessary metadata.
 	// Update `xl.json` content on each disks.
 	for index := range partsMetadata {
 		partsMetadata[index].Meta = metadata
 		partsMetadata[index].Stat.Size = sizeWritten
 		partsMetadata[index].Stat.ModTime = modTime
+		partsMetadata[index].VersionID = versionID
 	}
 
 	// Write unique `xl.json` for each disk.
@@ -925,6 +974,8 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ContentType:     xlMeta.Meta["content-type"],
 		ContentEncoding: xlMeta.Meta["content-encoding"],
 		UserDefined:     xlMeta.Meta,
+		VersionID:       xlMeta.VersionID,
+		IsLatest:        true,
 	}
 
 	// Success, return object info.
@@ -1057,3 +1108,114 @@ func (xl xlObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuat
 	}
 	return listObjectsV2Info, err
 }
+
+// PutObjectTags - replaces the tags of an object, tags are kept URL
+// encoded in `xl.json` along with the user defined metadata.
+func (xl xlObjects) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
+	return xl.UpdateObjectMetadata(ctx, bucket, object, map[string]string{amzObjectTagging: tags}, opts)
+}
+
+// GetObjectTags - returns the tags of an object.
+func (xl xlObjects) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tagging.Tagging, error) {
+	objInfo, err := xl.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return getObjectTags(objInfo)
+}
+
+// DeleteObjectTags - removes all the tags of an object.
+func (xl xlObjects) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return xl.PutObjectTags(ctx, bucket, object, "", opts)
+}
+
+// UpdateObjectMetadata - updates the given metadata keys of an object
+// version in place, keys with an empty value are removed.
+func (xl xlObjects) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error {
+	// Lock the object before updating its metadata.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	return xl.updateObjectMetadata(ctx, bucket, object, metadata, opts)
+}
+
+// updateObjectMetadata - updates `xl.json` of the requested object
+// version in place.
+func (xl xlObjects) updateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) error {
+	// Resolve the location of the requested object version.
+	metaBucket, metaObject, _, err := xl.resolveObjectVersion(ctx, bucket, object, opts.VersionID)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Read metadata associated with the object from all disks.
+	storageDisks := xl.getDisks()
+
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, metaBucket, metaObject)
+
+	// get Quorum for this object
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return toObjectErr(reducedErr, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(storageDisks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Delete markers carry no metadata.
+	if xlMeta.DeleteMarker {
+		if opts.VersionID != "" {
+			return MethodNotAllowed{Bucket: bucket, Object: object}
+		}
+		return ObjectNotFound{Bucket: bucket, Object: object}
+	}
+
+	if opts.CheckPrecondFn != nil && opts.CheckPrecondFn(xlMeta.ToObjectInfo(bucket, object)) {
+		return PreconditionFailed{Bucket: bucket, Object: object}
+	}
+
+	// Update `xl.json` content on each online disk.
+	for index, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		if metaArr[index].Meta == nil {
+			metaArr[index].Meta = make(map[string]string)
+		}
+		for k, v := range metadata {
+			if v == "" {
+				delete(metaArr[index].Meta, k)
+			} else {
+				metaArr[index].Meta[k] = v
+			}
+		}
+	}
+
+	tempObj := mustGetUUID()
+
+	// Write unique `xl.json` for each disk.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Rename atomically `xl.json` from tmp location to destination for each disk.
+	if _, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaBucket, metaObject, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	return nil
+}
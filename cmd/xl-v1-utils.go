@@ -208,6 +208,14 @@ func parseXLParts(xlMetaBuf []byte) []ObjectPartInfo {
 	return partInfo
 }
 
+func parseXLVersionID(xlMetaBuf []byte) string {
+	return gjson.GetBytes(xlMetaBuf, "versionId").String()
+}
+
+func parseXLDeleteMarker(xlMetaBuf []byte) bool {
+	return gjson.GetBytes(xlMetaBuf, "deleteMarker").Bool()
+}
+
 func parseXLMetaMap(xlMetaBuf []byte) map[string]string {
 	// Get xlMetaV1.Meta map.
 	metaMapResult := gjson.GetBytes(xlMetaBuf, "meta").Map()
@@ -244,6 +252,9 @@ func xlMetaV1UnmarshalJSON(ctx context.Context, xlMetaBuf []byte) (xlMeta xlMeta
 	xlMeta.Minio.Release = parseXLRelease(xlMetaBuf)
 	// parse xlMetaV1.
 	xlMeta.Meta = parseXLMetaMap(xlMetaBuf)
+	// Get the xlMetaV1.VersionID and xlMetaV1.DeleteMarker fields.
+	xlMeta.VersionID = parseXLVersionID(xlMetaBuf)
+	xlMeta.DeleteMarker = parseXLDeleteMarker(xlMetaBuf)
 
 	return xlMeta, nil
 }
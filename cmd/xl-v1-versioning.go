@@ -0,0 +1,510 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/scriptburn/minio/cmd/logger"
+)
+
+// The current version of an object always lives at its regular
+// location `bucket/object/xl.json`, a delete marker is simply an
+// `xl.json` without parts. Noncurrent versions are moved aside to
+// `.minio.sys/versions/bucket/sha256(object)/versionID/`, the "null"
+// version is stored under the `null` directory.
+
+// getVersionsDir - returns the directory holding all the noncurrent
+// versions of an object inside minioMetaVersionsBucket.
+func (xl xlObjects) getVersionsDir(bucket, object string) string {
+	return pathJoin(bucket, getSHA256Hash([]byte(object)))
+}
+
+// getVersionDir - returns the directory of a single noncurrent version
+// of an object inside minioMetaVersionsBucket.
+func (xl xlObjects) getVersionDir(bucket, object, versionID string) string {
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return pathJoin(xl.getVersionsDir(bucket, object), versionID)
+}
+
+// newObjectVersionID - returns the version ID for a new object version,
+// only versioned buckets generate unique version IDs.
+func newObjectVersionID(opts ObjectOptions) string {
+	if opts.Versioned {
+		return mustGetUUID()
+	}
+	return ""
+}
+
+// getObjectMeta - reads `xl.json` in quorum and returns the picked
+// metadata along with the write quorum of the object.
+func (xl xlObjects) getObjectMeta(ctx context.Context, bucket, object string) (xlMeta xlMetaV1, writeQuorum int, err error) {
+	disks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return xlMeta, 0, err
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return xlMeta, 0, reducedErr
+	}
+
+	// List all online disks.
+	_, modTime := listOnlineDisks(disks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err = pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	return xlMeta, writeQuorum, err
+}
+
+// resolveObjectVersion - returns the location of the requested version
+// of an object, isLatest is set when it is the current version.
+func (xl xlObjects) resolveObjectVersion(ctx context.Context, bucket, object, versionID string) (srcBucket, srcObject string, isLatest bool, err error) {
+	if versionID == "" {
+		return bucket, object, true, nil
+	}
+
+	if !isValidVersionID(versionID) {
+		return "", "", false, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+	}
+
+	nullVersion := versionID == nullVersionID
+	if nullVersion {
+		versionID = ""
+	}
+
+	xlMeta, _, err := xl.getObjectMeta(ctx, bucket, object)
+	switch err {
+	case nil:
+		if xlMeta.VersionID == versionID {
+			return bucket, object, true, nil
+		}
+	case errFileNotFound:
+	default:
+		return "", "", false, err
+	}
+
+	versionDir := xl.getVersionDir(bucket, object, versionID)
+	if xl.isObject(minioMetaVersionsBucket, versionDir) {
+		return minioMetaVersionsBucket, versionDir, false, nil
+	}
+
+	if nullVersion {
+		versionID = nullVersionID
+	}
+	return "", "", false, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+}
+
+// getObjectInfoVersion - returns the object info of the requested
+// version of an object, reading a delete marker by its version ID
+// returns the object info along with MethodNotAllowed.
+func (xl xlObjects) getObjectInfoVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	if versionID == "" {
+		return xl.getObjectInfo(ctx, bucket, object)
+	}
+
+	srcBucket, srcObject, isLatest, err := xl.resolveObjectVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return objInfo, err
+	}
+
+	xlMeta, _, err := xl.getObjectMeta(ctx, srcBucket, srcObject)
+	if err != nil {
+		return objInfo, err
+	}
+
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = isLatest
+	if objInfo.DeleteMarker {
+		return objInfo, MethodNotAllowed{Bucket: bucket, Object: object}
+	}
+	return objInfo, nil
+}
+
+// listVersionIDs - lists the directories of all the noncurrent versions
+// of an object.
+func (xl xlObjects) listVersionIDs(ctx context.Context, bucket, object string) ([]string, error) {
+	for _, disk := range xl.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		entries, err := disk.ListDir(minioMetaVersionsBucket, xl.getVersionsDir(bucket, object), -1)
+		if err != nil {
+			if err == errFileNotFound {
+				return nil, nil
+			}
+			logger.LogIf(ctx, err)
+			return nil, err
+		}
+		for i := range entries {
+			entries[i] = strings.TrimSuffix(entries[i], slashSeparator)
+		}
+		return entries, nil
+	}
+	return nil, errDiskNotFound
+}
+
+// getNoncurrentVersions - returns all the noncurrent versions of an
+// object sorted newest first.
+func (xl xlObjects) getNoncurrentVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	versionIDs, err := xl.listVersionIDs(ctx, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+
+	var objInfos []ObjectInfo
+	for _, versionID := range versionIDs {
+		xlMeta, _, err := xl.getObjectMeta(ctx, minioMetaVersionsBucket, pathJoin(xl.getVersionsDir(bucket, object), versionID))
+		if err != nil {
+			// Ignore versions removed in the interim period of listing
+			// and ignore versions present only on outdated disks.
+			switch err {
+			case errFileNotFound, errXLReadQuorum:
+				continue
+			}
+			return nil, err
+		}
+		objInfos = append(objInfos, xlMeta.ToObjectInfo(bucket, object))
+	}
+
+	sort.SliceStable(objInfos, func(i, j int) bool {
+		return objInfos[i].ModTime.After(objInfos[j].ModTime)
+	})
+	return objInfos, nil
+}
+
+// getObjectVersions - returns all the versions of an object including
+// delete markers, the current version is always returned first.
+func (xl xlObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	var objInfos []ObjectInfo
+
+	xlMeta, _, err := xl.getObjectMeta(ctx, bucket, object)
+	switch err {
+	case nil:
+		objInfo := xlMeta.ToObjectInfo(bucket, object)
+		objInfo.IsLatest = true
+		objInfos = append(objInfos, objInfo)
+	case errFileNotFound:
+	default:
+		return nil, err
+	}
+
+	noncurrent, err := xl.getNoncurrentVersions(ctx, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return append(objInfos, noncurrent...), nil
+}
+
+// purgeNoncurrentVersion - permanently removes a noncurrent version if
+// it exists.
+func (xl xlObjects) purgeNoncurrentVersion(ctx context.Context, bucket, object, versionID string, writeQuorum int) error {
+	versionDir := xl.getVersionDir(bucket, object, versionID)
+	if !xl.isObject(minioMetaVersionsBucket, versionDir) {
+		return nil
+	}
+	return xl.deleteObject(ctx, minioMetaVersionsBucket, versionDir, writeQuorum, false)
+}
+
+// preserveCurrentVersion - moves the current version of an object out of
+// the way before a new version or a delete marker takes its place. The
+// current version is kept as a noncurrent version, except for the "null"
+// version on version suspended buckets which is overwritten. Returns true
+// if the current version was kept, the caller restores it with
+// promoteNoncurrentVersion if its replacement can not be committed.
+func (xl xlObjects) preserveCurrentVersion(ctx context.Context, bucket, object string, writeQuorum int, opts ObjectOptions) (bool, error) {
+	// Only one "null" version may exist, on version suspended buckets
+	// the new version becomes the "null" version.
+	if opts.VersionSuspended {
+		if err := xl.purgeNoncurrentVersion(ctx, bucket, object, "", writeQuorum); err != nil {
+			return false, err
+		}
+	}
+
+	if !xl.isObject(bucket, object) {
+		return false, nil
+	}
+
+	xlMeta, _, err := xl.getObjectMeta(ctx, bucket, object)
+	if err != nil || (opts.VersionSuspended && xlMeta.VersionID == "") {
+		// Purge the current version, also allow purging the existing
+		// object if it is not present in quorum disks so users can
+		// overwrite stale objects.
+		return false, xl.deleteObject(ctx, bucket, object, writeQuorum, false)
+	}
+
+	// A noncurrent copy of the same version may only exist for the
+	// "null" version, purge it before moving the current one aside.
+	if err = xl.purgeNoncurrentVersion(ctx, bucket, object, xlMeta.VersionID, writeQuorum); err != nil {
+		return false, err
+	}
+
+	if _, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaVersionsBucket,
+		xl.getVersionDir(bucket, object, xlMeta.VersionID), true, writeQuorum, []error{errFileNotFound}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// putDeleteMarker - writes a delete marker as the current version of an
+// object, the caller is expected to have preserved the current version.
+func (xl xlObjects) putDeleteMarker(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	dataBlocks, parityBlocks := getRedundancyCount("", len(xl.getDisks()))
+	writeQuorum := dataBlocks + 1
+
+	xlMeta := newXLMetaV1(object, dataBlocks, parityBlocks)
+	xlMeta.Stat.ModTime = UTCNow()
+	xlMeta.Meta = make(map[string]string)
+	xlMeta.VersionID = versionID
+	xlMeta.DeleteMarker = true
+
+	tempObj := mustGetUUID()
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	onlineDisks, err := writeSameXLMetadata(ctx, xl.getDisks(), minioMetaTmpBucket, tempObj, xlMeta, writeQuorum)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	objInfo := xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = true
+	return objInfo, nil
+}
+
+// promoteNoncurrentVersion - makes the newest noncurrent version the
+// current version of an object, called after the current version was
+// permanently deleted.
+func (xl xlObjects) promoteNoncurrentVersion(ctx context.Context, bucket, object string, writeQuorum int) error {
+	objInfos, err := xl.getNoncurrentVersions(ctx, bucket, object)
+	if err != nil || len(objInfos) == 0 {
+		return err
+	}
+
+	_, err = rename(ctx, xl.getDisks(), minioMetaVersionsBucket, xl.getVersionDir(bucket, object, objInfos[0].VersionID),
+		bucket, object, true, writeQuorum, []error{errFileNotFound})
+	return err
+}
+
+// deleteObjectVersion - permanently deletes a single version of an object.
+func (xl xlObjects) deleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	srcBucket, srcObject, isLatest, err := xl.resolveObjectVersion(ctx, bucket, object, versionID)
+	if err != nil {
+		return objInfo, err
+	}
+
+	xlMeta, writeQuorum, err := xl.getObjectMeta(ctx, srcBucket, srcObject)
+	if err != nil {
+		return objInfo, err
+	}
+
+	if err = xl.deleteObject(ctx, srcBucket, srcObject, writeQuorum, false); err != nil {
+		return objInfo, err
+	}
+
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	if isLatest {
+		err = xl.promoteNoncurrentVersion(ctx, bucket, object, writeQuorum)
+	}
+	return objInfo, err
+}
+
+// DeleteObjectVersion - deletes an object honoring the bucket versioning
+// state. Without a version ID a delete marker becomes the current version
+// of the object, otherwise the requested version is permanently removed
+// and the newest remaining version becomes current again.
+func (xl xlObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	// Object directories and unversioned buckets keep the regular behavior.
+	if hasSuffix(object, slashSeparator) || (opts.VersionID == "" && !opts.Versioned && !opts.VersionSuspended) {
+		if err = xl.DeleteObject(ctx, bucket, object); err != nil {
+			return objInfo, err
+		}
+		return ObjectInfo{Bucket: bucket, Name: object}, nil
+	}
+
+	// Acquire a write lock before deleting the object.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if opts.VersionID != "" {
+		objInfo, err = xl.deleteObjectVersion(ctx, bucket, object, opts.VersionID)
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		return objInfo, nil
+	}
+
+	writeQuorum := len(xl.getDisks())/2 + 1
+	preserved, err := xl.preserveCurrentVersion(ctx, bucket, object, writeQuorum, opts)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	if objInfo, err = xl.putDeleteMarker(ctx, bucket, object, newObjectVersionID(opts)); err != nil && preserved {
+		// The current version is restored if the delete marker can
+		// not be written.
+		logger.LogIf(ctx, xl.promoteNoncurrentVersion(ctx, bucket, object, writeQuorum))
+	}
+	return objInfo, err
+}
+
+// listObjectVersions - collects the versions of every key received
+// from the tree walk, keys starting at keyMarker are expected.
+func listObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int,
+	walkResultCh <-chan treeWalkResult, getObjectVersions func(object string) ([]ObjectInfo, error)) (result ListObjectVersionsInfo, err error) {
+	var count int
+
+	// Adds the versions of an object to the result, returns false once
+	// maxKeys is reached.
+	addVersions := func(objInfos []ObjectInfo) bool {
+		for _, objInfo := range objInfos {
+			if count == maxKeys {
+				result.IsTruncated = true
+				return false
+			}
+			result.Objects = append(result.Objects, objInfo)
+			result.NextKeyMarker = objInfo.Name
+			result.NextVersionIDMarker = objInfo.VersionID
+			if objInfo.VersionID == "" {
+				result.NextVersionIDMarker = nullVersionID
+			}
+			count++
+		}
+		return true
+	}
+
+	// Continue listing the versions of the key marker after the
+	// version ID marker.
+	if keyMarker != "" && versionIDMarker != "" {
+		objInfos, err := getObjectVersions(keyMarker)
+		if err != nil && err != errFileNotFound {
+			return result, toObjectErr(err, bucket, keyMarker)
+		}
+		for i, objInfo := range objInfos {
+			if objInfo.VersionID == versionIDMarker || (objInfo.VersionID == "" && versionIDMarker == nullVersionID) {
+				objInfos = objInfos[i+1:]
+				break
+			}
+		}
+		if !addVersions(objInfos) {
+			return result, nil
+		}
+	}
+
+	for {
+		walkResult, ok := <-walkResultCh
+		if !ok {
+			break
+		}
+
+		// For any walk error return right away.
+		if walkResult.err != nil {
+			return result, toObjectErr(walkResult.err, bucket, prefix)
+		}
+
+		entry := walkResult.entry
+		if hasSuffix(entry, slashSeparator) {
+			if delimiter != slashSeparator {
+				continue
+			}
+			if count == maxKeys {
+				result.IsTruncated = true
+				return result, nil
+			}
+			result.Prefixes = append(result.Prefixes, entry)
+			result.NextKeyMarker = entry
+			result.NextVersionIDMarker = ""
+			count++
+			continue
+		}
+
+		objInfos, err := getObjectVersions(entry)
+		if err != nil {
+			// Ignore errFileNotFound as the object might have got
+			// deleted in the interim period of listing, ignore quorum
+			// error as it might be an entry from an outdated disk.
+			switch err {
+			case errFileNotFound, errXLReadQuorum:
+				continue
+			}
+			return result, toObjectErr(err, bucket, prefix)
+		}
+
+		if !addVersions(objInfos) {
+			return result, nil
+		}
+	}
+
+	result.NextKeyMarker = ""
+	result.NextVersionIDMarker = ""
+	return result, nil
+}
+
+// ListObjectVersions - lists all the versions of the objects at prefix,
+// delimited by '/'.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	if err = checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, xl); err != nil {
+		return result, err
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	if maxKeys == 0 || (delimiter == slashSeparator && prefix == slashSeparator) {
+		return result, nil
+	}
+
+	// Default is recursive, if delimiter is set then list non recursive.
+	recursive := delimiter != slashSeparator
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	isLeaf := xl.isObject
+	isLeafDir := xl.isObjectDir
+	listDir := listDirFactory(ctx, isLeaf, xl.getLoadBalancedDisks()...)
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, keyMarker, recursive, listDir, isLeaf, isLeafDir, endWalkCh)
+
+	return listObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, walkResultCh,
+		func(object string) ([]ObjectInfo, error) {
+			return xl.getObjectVersions(ctx, bucket, object)
+		})
+}
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (xl xlObjects) IsVersioningSupported() bool {
+	return true
+}
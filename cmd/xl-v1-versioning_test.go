@@ -0,0 +1,195 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+func TestXLObjectVersions(t *testing.T) {
+	objLayer, disks, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ObjectOptions{Versioned: true}
+	putVersion := func(data string) ObjectInfo {
+		objInfo, perr := objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), nil, opts)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		if objInfo.VersionID == "" || !objInfo.IsLatest {
+			t.Fatalf("expected a latest version with a version ID, got %#v", objInfo)
+		}
+		return objInfo
+	}
+
+	v1 := putVersion("version-1")
+	v2 := putVersion("version-22")
+
+	// The older version remains readable by its version ID.
+	objInfo, err := objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: v1.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != v1.Size || objInfo.IsLatest {
+		t.Fatalf("expected noncurrent version of size %d, got %#v", v1.Size, objInfo)
+	}
+
+	// Deleting without a version ID places a delete marker.
+	marker, err := objLayer.DeleteObjectVersion(ctx, bucket, object, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !marker.DeleteMarker || marker.VersionID == "" {
+		t.Fatalf("expected a delete marker, got %#v", marker)
+	}
+	if _, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+		t.Fatal("expected the object to be hidden by the delete marker")
+	}
+	if _, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: marker.VersionID}); err == nil {
+		t.Fatal("expected reading a delete marker to fail")
+	} else if _, ok := err.(MethodNotAllowed); !ok {
+		t.Fatalf("expected MethodNotAllowed, got %v", err)
+	}
+
+	result, err := objLayer.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(result.Objects))
+	}
+	if !result.Objects[0].DeleteMarker || !result.Objects[0].IsLatest {
+		t.Fatalf("expected the delete marker to be the latest version, got %#v", result.Objects[0])
+	}
+
+	// Listing resumes after the given key and version ID marker.
+	result, err = objLayer.ListObjectVersions(ctx, bucket, "", object, v2.VersionID, "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].VersionID != v1.VersionID {
+		t.Fatalf("expected only version %s after the marker, got %#v", v1.VersionID, result.Objects)
+	}
+
+	// Removing the delete marker makes the previous version current again.
+	if _, err = objLayer.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: marker.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.VersionID != v2.VersionID {
+		t.Fatalf("expected version %s to be current, got %s", v2.VersionID, objInfo.VersionID)
+	}
+
+	// Permanently removing a noncurrent version.
+	if _, err = objLayer.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: v1.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: v1.VersionID}); err == nil {
+		t.Fatalf("expected version %s to be removed", v1.VersionID)
+	}
+}
+
+// commitFailingDisk - fails renames into bucket except the ones restoring
+// a noncurrent version, so that new versions can not be committed.
+type commitFailingDisk struct {
+	StorageAPI
+	bucket string
+}
+
+func (d commitFailingDisk) RenameFile(srcVolume, srcPath, dstVolume, dstPath string) error {
+	if dstVolume == d.bucket && srcVolume != minioMetaVersionsBucket {
+		return errFaultyDisk
+	}
+	return d.StorageAPI.RenameFile(srcVolume, srcPath, dstVolume, dstPath)
+}
+
+func TestXLObjectVersionsCommitFailure(t *testing.T) {
+	objLayer, disks, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ObjectOptions{Versioned: true}
+	v1, err := objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("version-1")), int64(len("version-1")), "", ""), nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xl := objLayer.(*xlObjects)
+	for i := range xl.storageDisks {
+		xl.storageDisks[i] = commitFailingDisk{xl.storageDisks[i], bucket}
+	}
+
+	// The current version is kept when its replacement fails.
+	checkCurrentVersion := func(operation string) {
+		objInfo, err := objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected version %s to be current, got %v", operation, v1.VersionID, err)
+		}
+		if objInfo.VersionID != v1.VersionID {
+			t.Fatalf("%s: expected version %s to be current, got %s", operation, v1.VersionID, objInfo.VersionID)
+		}
+		if err = objLayer.GetObject(ctx, bucket, object, 0, objInfo.Size, ioutil.Discard, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: expected version %s to be readable, got %v", operation, v1.VersionID, err)
+		}
+	}
+
+	if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("version-2")), int64(len("version-2")), "", ""), nil, opts); err == nil {
+		t.Fatal("expected PutObject to fail")
+	}
+	checkCurrentVersion("PutObject")
+
+	uploadID, err := objLayer.NewMultipartUpload(ctx, bucket, object, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partInfo, err := objLayer.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader([]byte("version-3")), int64(len("version-3")), "", ""), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: partInfo.ETag}}, opts); err == nil {
+		t.Fatal("expected CompleteMultipartUpload to fail")
+	}
+	checkCurrentVersion("CompleteMultipartUpload")
+
+	if _, err = objLayer.DeleteObjectVersion(ctx, bucket, object, opts); err == nil {
+		t.Fatal("expected DeleteObjectVersion to fail")
+	}
+	checkCurrentVersion("DeleteObjectVersion")
+}
@@ -73,6 +73,18 @@ type Credentials struct {
 	Expiration   time.Time `xml:"Expiration" json:"expiration,omitempty"`
 	SessionToken string    `xml:"SessionToken" json:"sessionToken,omitempty"`
 	Status       string    `xml:"-" json:"status,omitempty"`
+	ParentUser   string    `xml:"-" json:"parentUser,omitempty"`
+}
+
+// IsTemp - returns whether credential is temporary with a session token.
+func (cred Credentials) IsTemp() bool {
+	return cred.SessionToken != ""
+}
+
+// IsServiceAccount - returns whether credential is a service account
+// derived from a parent user.
+func (cred Credentials) IsServiceAccount() bool {
+	return cred.ParentUser != "" && !cred.IsTemp()
 }
 
 // IsExpired - returns whether Credential is expired or not.
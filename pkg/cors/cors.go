@@ -0,0 +1,218 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Maximum number of rules allowed in a CORS configuration as per AWS S3
+// specification.
+const maxRules = 100
+
+// Maximum length of a rule ID.
+const maxRuleIDLength = 255
+
+var (
+	errCORSNoRule              = errors.New("CORS configuration should have at least one rule")
+	errCORSTooManyRules        = errors.New("CORS configuration allows a maximum of 100 rules")
+	errCORSInvalidRuleID       = errors.New("CORS rule ID must not be longer than 255 characters")
+	errCORSNoOrigin            = errors.New("CORS rule should have at least one AllowedOrigin")
+	errCORSNoMethod            = errors.New("CORS rule should have at least one AllowedMethod")
+	errCORSInvalidMethod       = errors.New("CORS rule AllowedMethod must be one of GET, PUT, HEAD, POST or DELETE")
+	errCORSInvalidWildcard     = errors.New("CORS rule AllowedOrigin and AllowedHeader can have at most one wildcard")
+	errCORSInvalidMaxAge       = errors.New("CORS rule MaxAgeSeconds must not be negative")
+	errCORSInvalidExposeHeader = errors.New("CORS rule ExposeHeader must not contain a wildcard")
+)
+
+// Methods which may be allowed by a CORS rule.
+var supportedMethods = map[string]struct{}{
+	"GET":    {},
+	"PUT":    {},
+	"HEAD":   {},
+	"POST":   {},
+	"DELETE": {},
+}
+
+// Rule - represents <CORSRule>...</CORSRule>
+type Rule struct {
+	XMLName        xml.Name `xml:"CORSRule"`
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return errCORSInvalidRuleID
+	}
+
+	if len(rule.AllowedOrigins) == 0 {
+		return errCORSNoOrigin
+	}
+	for _, origin := range rule.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return errCORSInvalidWildcard
+		}
+	}
+
+	if len(rule.AllowedMethods) == 0 {
+		return errCORSNoMethod
+	}
+	for _, method := range rule.AllowedMethods {
+		if _, ok := supportedMethods[method]; !ok {
+			return errCORSInvalidMethod
+		}
+	}
+
+	for _, header := range rule.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return errCORSInvalidWildcard
+		}
+	}
+
+	for _, header := range rule.ExposeHeaders {
+		if strings.Contains(header, "*") {
+			return errCORSInvalidExposeHeader
+		}
+	}
+
+	if rule.MaxAgeSeconds < 0 {
+		return errCORSInvalidMaxAge
+	}
+
+	return nil
+}
+
+// wildcardMatch - matches s against a pattern with at most one '*'
+// matching any sequence of characters.
+func wildcardMatch(pattern, s string) bool {
+	i := strings.Index(pattern, "*")
+	if i < 0 {
+		return pattern == s
+	}
+
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	return len(s) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
+
+// MatchOrigin - returns true if the rule allows the given origin.
+func (rule Rule) MatchOrigin(origin string) bool {
+	for _, allowed := range rule.AllowedOrigins {
+		if wildcardMatch(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAnyOrigin - returns true if the rule allows every origin.
+func (rule Rule) AllowsAnyOrigin() bool {
+	for _, allowed := range rule.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchMethod - returns true if the rule allows the given HTTP method.
+func (rule Rule) MatchMethod(method string) bool {
+	for _, allowed := range rule.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchHeaders - returns true if the rule allows all the given request
+// headers, header names are compared case insensitively.
+func (rule Rule) MatchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(header)
+		matched := false
+		for _, allowed := range rule.AllowedHeaders {
+			if wildcardMatch(strings.ToLower(allowed), header) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Config - represents <CORSConfiguration>...</CORSConfiguration>
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (config Config) Validate() error {
+	if len(config.Rules) == 0 {
+		return errCORSNoRule
+	}
+	if len(config.Rules) > maxRules {
+		return errCORSTooManyRules
+	}
+
+	for _, rule := range config.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match - returns the first rule which allows a request from the given
+// origin with the given method and request headers.
+func (config Config) Match(origin, method string, headers []string) (rule Rule, ok bool) {
+	for _, r := range config.Rules {
+		if r.MatchOrigin(origin) && r.MatchMethod(method) && r.MatchHeaders(headers) {
+			return r, true
+		}
+	}
+	return rule, false
+}
+
+// ParseCORSConfig - parses data in given reader to Config.
+func ParseCORSConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
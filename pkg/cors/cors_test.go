@@ -0,0 +1,96 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCORSConfig(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, false},
+		{`<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><ID>app</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>POST</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds><ExposeHeader>ETag</ExposeHeader></CORSRule></CORSConfiguration>`, false},
+		{`<CORSConfiguration></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedHeader>x-*-*</AllowedHeader></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><ExposeHeader>x-amz-*</ExposeHeader></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`, true},
+		{`<CORSConfiguration>`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseCORSConfig(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config := Config{
+		Rules: []Rule{
+			{
+				ID:             "upload",
+				AllowedOrigins: []string{"https://*.example.com"},
+				AllowedMethods: []string{"PUT", "POST"},
+				AllowedHeaders: []string{"Content-*", "x-amz-date"},
+			},
+			{
+				ID:             "read",
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "HEAD"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		expectedOK bool
+	}{
+		{"https://app.example.com", "PUT", nil, "upload", true},
+		{"https://app.example.com", "PUT", []string{"content-type", "X-Amz-Date"}, "upload", true},
+		{"https://app.example.com", "PUT", []string{"authorization"}, "", false},
+		{"http://app.example.com", "PUT", nil, "", false},
+		{"https://example.com", "POST", nil, "", false},
+		{"https://app.example.com", "DELETE", nil, "", false},
+		{"https://app.example.com", "GET", nil, "read", true},
+		{"http://localhost:8080", "HEAD", nil, "read", true},
+		{"http://localhost:8080", "GET", []string{"range"}, "", false},
+	}
+
+	for i, testCase := range testCases {
+		rule, ok := config.Match(testCase.origin, testCase.method, testCase.headers)
+		if ok != testCase.expectedOK {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedOK, ok)
+		}
+		if rule.ID != testCase.expectedID {
+			t.Fatalf("test %v: rule: expected: %v, got: %v", i+1, testCase.expectedID, rule.ID)
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package event
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"strings"
 )
@@ -57,6 +58,27 @@ func (arn *ARN) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return nil
 }
 
+// MarshalJSON - encodes to JSON data.
+func (arn ARN) MarshalJSON() ([]byte, error) {
+	return json.Marshal(arn.String())
+}
+
+// UnmarshalJSON - decodes JSON data.
+func (arn *ARN) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsedARN, err := parseARN(s)
+	if err != nil {
+		return err
+	}
+
+	*arn = *parsedARN
+	return nil
+}
+
 // parseARN - parses string to ARN.
 func parseARN(s string) (*ARN, error) {
 	// ARN must be in the format of arn:minio:sqs:<REGION>:<ID>:<TYPE>
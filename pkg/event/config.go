@@ -35,7 +35,8 @@ func ValidateFilterRuleValue(value string) error {
 		}
 	}
 
-	if len(value) <= 1024 && utf8.ValidString(value) && !strings.Contains(value, `\`) {
+	// NUL separates pattern and object filter in rules key.
+	if len(value) <= 1024 && utf8.ValidString(value) && !strings.ContainsAny(value, "\\\x00") {
 		return nil
 	}
 
@@ -119,9 +120,12 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
-// S3Key - represents elements inside <S3Key>...</S3Key>
+// S3Key - represents elements inside <Filter>...</Filter>
 type S3Key struct {
 	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+
+	// Minio extension, AWS S3 clients ignore it.
+	ObjectFilter *ObjectFilter `xml:"MinioFilter,omitempty" json:"MinioFilter,omitempty"`
 }
 
 // common - represents common elements inside <QueueConfiguration>, <CloudFunctionConfiguration>
@@ -186,7 +190,7 @@ func (q *Queue) SetRegion(region string) {
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
 	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	return NewFilterRulesMap(q.Events, pattern, q.Filter.ObjectFilter, q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
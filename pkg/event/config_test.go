@@ -379,8 +379,32 @@ func TestQueueToRulesMap(t *testing.T) {
 		panic(err)
 	}
 
+	data = []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+    <Filter>
+        <S3Key>
+            <FilterRule>
+                <Name>prefix</Name>
+                <Value>videos/</Value>
+            </FilterRule>
+        </S3Key>
+        <MinioFilter>
+            <MinSize>104857600</MinSize>
+            <ContentType>video/*</ContentType>
+        </MinioFilter>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>`)
+	queueCase3 := &Queue{}
+	if err := xml.Unmarshal(data, queueCase3); err != nil {
+		panic(err)
+	}
+
 	rulesMapCase1 := NewRulesMap([]Name{ObjectAccessedAll, ObjectCreatedAll, ObjectRemovedAll}, "*", TargetID{"1", "webhook"})
 	rulesMapCase2 := NewRulesMap([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"1", "webhook"})
+	rulesMapCase3 := NewFilterRulesMap([]Name{ObjectCreatedPut}, "videos/*", &ObjectFilter{MinSize: 104857600, ContentTypes: []string{"video/*"}}, TargetID{"1", "webhook"})
 
 	testCases := []struct {
 		queue          *Queue
@@ -388,6 +412,7 @@ func TestQueueToRulesMap(t *testing.T) {
 	}{
 		{queueCase1, rulesMapCase1},
 		{queueCase2, rulesMapCase2},
+		{queueCase3, rulesMapCase3},
 	}
 
 	for i, testCase := range testCases {
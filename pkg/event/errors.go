@@ -32,6 +32,8 @@ func IsEventError(err error) bool {
 		return true
 	case ErrInvalidFilterValue, *ErrInvalidFilterValue:
 		return true
+	case ErrInvalidObjectFilter, *ErrInvalidObjectFilter:
+		return true
 	case ErrDuplicateEventName, *ErrDuplicateEventName:
 		return true
 	case ErrUnsupportedConfiguration, *ErrUnsupportedConfiguration:
@@ -83,6 +85,15 @@ func (err ErrInvalidFilterValue) Error() string {
 	return fmt.Sprintf("invalid filter value '%v'", err.FilterValue)
 }
 
+// ErrInvalidObjectFilter - invalid Minio object filter error.
+type ErrInvalidObjectFilter struct {
+	Reason string
+}
+
+func (err ErrInvalidObjectFilter) Error() string {
+	return fmt.Sprintf("invalid object filter: %v", err.Reason)
+}
+
 // ErrDuplicateEventName - duplicate event name error.
 type ErrDuplicateEventName struct {
 	EventName Name
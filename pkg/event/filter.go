@@ -0,0 +1,223 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/pkg/wildcard"
+)
+
+// ObjectInfo - object attributes matched by rules.
+type ObjectInfo struct {
+	Name        string
+	Size        int64
+	ContentType string
+
+	// User metadata keys are lower cased and without "x-amz-meta-" prefix.
+	UserMetadata map[string]string
+	Tags         map[string]string
+}
+
+// FilterKeyValue - represents elements inside <Metadata>...</Metadata> and
+// <Tag>...</Tag> of <MinioFilter>.
+type FilterKeyValue struct {
+	Key   string `xml:"Key" json:"Key"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// ObjectFilter - represents elements inside <MinioFilter>...</MinioFilter>.
+// This is a Minio extension to <Filter> to match objects by size, content
+// type, user metadata and tags, AWS S3 clients ignore it.
+//
+// Sizes are inclusive and zero means no limit. Content type matches if any
+// of the content types match. All metadata and tags must match. Content
+// types, metadata and tag values may contain '*' and '?' wildcards.
+// Events of removed objects carry only the object name, so they match a
+// filter only if it has no minimum size, content types, metadata or tags.
+type ObjectFilter struct {
+	MinSize      int64            `xml:"MinSize,omitempty" json:"MinSize,omitempty"`
+	MaxSize      int64            `xml:"MaxSize,omitempty" json:"MaxSize,omitempty"`
+	ContentTypes []string         `xml:"ContentType,omitempty" json:"ContentType,omitempty"`
+	Metadata     []FilterKeyValue `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	Tags         []FilterKeyValue `xml:"Tag,omitempty" json:"Tag,omitempty"`
+}
+
+// validateKeyValues - checks keys are unique and keys and values are valid.
+func validateKeyValues(name string, keyValues []FilterKeyValue, foldCase bool) error {
+	keySet := set.NewStringSet()
+	for _, kv := range keyValues {
+		key := kv.Key
+		if foldCase {
+			key = strings.ToLower(key)
+		}
+
+		if key == "" {
+			return &ErrInvalidObjectFilter{"empty " + name + " key"}
+		}
+
+		if keySet.Contains(key) {
+			return &ErrInvalidObjectFilter{"duplicate " + name + " key '" + kv.Key + "'"}
+		}
+		keySet.Add(key)
+
+		if err := ValidateFilterRuleValue(kv.Key); err != nil {
+			return err
+		}
+
+		if err := ValidateFilterRuleValue(kv.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate - checks whether object filter has valid values or not.
+func (filter ObjectFilter) Validate() error {
+	if filter.MinSize < 0 || filter.MaxSize < 0 {
+		return &ErrInvalidObjectFilter{"negative size"}
+	}
+
+	if filter.MaxSize != 0 && filter.MaxSize < filter.MinSize {
+		return &ErrInvalidObjectFilter{"maximum size is less than minimum size"}
+	}
+
+	for _, contentType := range filter.ContentTypes {
+		if contentType == "" {
+			return &ErrInvalidObjectFilter{"empty content type"}
+		}
+
+		if err := ValidateFilterRuleValue(contentType); err != nil {
+			return err
+		}
+	}
+
+	// User metadata keys are case insensitive like HTTP headers.
+	if err := validateKeyValues("metadata", filter.Metadata, true); err != nil {
+		return err
+	}
+
+	return validateKeyValues("tag", filter.Tags, false)
+}
+
+// UnmarshalXML - decodes XML data.
+func (filter *ObjectFilter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectFilter ObjectFilter
+	parsedFilter := objectFilter{}
+	if err := d.DecodeElement(&parsedFilter, &start); err != nil {
+		return err
+	}
+
+	if err := ObjectFilter(parsedFilter).Validate(); err != nil {
+		return err
+	}
+
+	*filter = ObjectFilter(parsedFilter)
+	return nil
+}
+
+// IsEmpty - returns whether object filter matches all objects.
+func (filter ObjectFilter) IsEmpty() bool {
+	return filter.MinSize == 0 && filter.MaxSize == 0 && len(filter.ContentTypes) == 0 &&
+		len(filter.Metadata) == 0 && len(filter.Tags) == 0
+}
+
+// Match - returns whether object matches this filter.
+func (filter ObjectFilter) Match(object ObjectInfo) bool {
+	if object.Size < filter.MinSize {
+		return false
+	}
+
+	if filter.MaxSize != 0 && object.Size > filter.MaxSize {
+		return false
+	}
+
+	if len(filter.ContentTypes) != 0 {
+		found := false
+		for _, contentType := range filter.ContentTypes {
+			if wildcard.MatchSimple(contentType, object.ContentType) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	for _, kv := range filter.Metadata {
+		value, ok := object.UserMetadata[strings.ToLower(kv.Key)]
+		if !ok || !wildcard.MatchSimple(kv.Value, value) {
+			return false
+		}
+	}
+
+	for _, kv := range filter.Tags {
+		value, ok := object.Tags[kv.Key]
+		if !ok || !wildcard.MatchSimple(kv.Value, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Object filter is encoded as URL query in rules key.
+const (
+	filterMinSize     = "minsize"
+	filterMaxSize     = "maxsize"
+	filterContentType = "contenttype"
+	filterMetaPrefix  = "meta:"
+	filterTagPrefix   = "tag:"
+)
+
+// encode - returns canonical string of this filter, equal filters return
+// the same string.
+func (filter ObjectFilter) encode() string {
+	values := make(url.Values)
+
+	if filter.MinSize != 0 {
+		values.Set(filterMinSize, strconv.FormatInt(filter.MinSize, 10))
+	}
+
+	if filter.MaxSize != 0 {
+		values.Set(filterMaxSize, strconv.FormatInt(filter.MaxSize, 10))
+	}
+
+	// ToSlice() returns sorted content types.
+	for _, contentType := range set.CreateStringSet(filter.ContentTypes...).ToSlice() {
+		values.Add(filterContentType, contentType)
+	}
+
+	for _, kv := range filter.Metadata {
+		values.Set(filterMetaPrefix+strings.ToLower(kv.Key), kv.Value)
+	}
+
+	for _, kv := range filter.Tags {
+		values.Set(filterTagPrefix+kv.Key, kv.Value)
+	}
+
+	// Encode() sorts by key.
+	return values.Encode()
+}
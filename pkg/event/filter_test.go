@@ -0,0 +1,120 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestObjectFilterUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		data           []byte
+		expectedResult *ObjectFilter
+		expectErr      bool
+	}{
+		{[]byte(`<MinioFilter><MinSize>-1</MinSize></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><MinSize>10</MinSize><MaxSize>5</MaxSize></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><ContentType></ContentType></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><Metadata><Key></Key><Value>a</Value></Metadata></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><Metadata><Key>Foo</Key><Value>a</Value></Metadata><Metadata><Key>foo</Key><Value>b</Value></Metadata></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><Tag><Key>k</Key><Value>a</Value></Tag><Tag><Key>k</Key><Value>b</Value></Tag></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter><MinSize>abc</MinSize></MinioFilter>`), nil, true},
+		{[]byte(`<MinioFilter></MinioFilter>`), &ObjectFilter{}, false},
+		{[]byte(`<MinioFilter><MinSize>10</MinSize><MaxSize>20</MaxSize><ContentType>video/*</ContentType><Metadata><Key>category</Key><Value>raw</Value></Metadata><Tag><Key>k</Key><Value>v</Value></Tag></MinioFilter>`),
+			&ObjectFilter{10, 20, []string{"video/*"}, []FilterKeyValue{{"category", "raw"}}, []FilterKeyValue{{"k", "v"}}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := &ObjectFilter{}
+		err := xml.Unmarshal(testCase.data, result)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("test %v: data: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
+
+func TestObjectFilterMatch(t *testing.T) {
+	object := ObjectInfo{
+		Name:         "videos/a.mp4",
+		Size:         200,
+		ContentType:  "video/mp4",
+		UserMetadata: map[string]string{"category": "raw"},
+		Tags:         map[string]string{"project": "x1"},
+	}
+
+	testCases := []struct {
+		filter         ObjectFilter
+		expectedResult bool
+	}{
+		{ObjectFilter{}, true},
+		{ObjectFilter{MinSize: 200, MaxSize: 200}, true},
+		{ObjectFilter{MinSize: 201}, false},
+		{ObjectFilter{MaxSize: 199}, false},
+		{ObjectFilter{ContentTypes: []string{"image/*", "video/*"}}, true},
+		{ObjectFilter{ContentTypes: []string{"image/*"}}, false},
+		{ObjectFilter{Metadata: []FilterKeyValue{{"Category", "raw"}}}, true},
+		{ObjectFilter{Metadata: []FilterKeyValue{{"category", "done"}}}, false},
+		{ObjectFilter{Metadata: []FilterKeyValue{{"owner", "*"}}}, false},
+		{ObjectFilter{Tags: []FilterKeyValue{{"project", "x?"}}}, true},
+		{ObjectFilter{Tags: []FilterKeyValue{{"Project", "x1"}}}, false},
+		{ObjectFilter{MinSize: 100, Tags: []FilterKeyValue{{"project", "x1"}, {"team", "a"}}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.filter.Match(object)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestObjectFilterEncode(t *testing.T) {
+	testCases := []struct {
+		filter         ObjectFilter
+		filter2        ObjectFilter
+		expectedResult bool
+	}{
+		{ObjectFilter{MinSize: 10, MaxSize: 20}, ObjectFilter{MinSize: 10, MaxSize: 20}, true},
+		{ObjectFilter{MinSize: 10}, ObjectFilter{MaxSize: 10}, false},
+		{ObjectFilter{ContentTypes: []string{"video/*", "image/*"}}, ObjectFilter{ContentTypes: []string{"image/*", "video/*"}}, true},
+		{
+			ObjectFilter{Metadata: []FilterKeyValue{{"Category", "a&b=c"}}, Tags: []FilterKeyValue{{"t 2", "2"}, {"t1", "1"}}},
+			ObjectFilter{Metadata: []FilterKeyValue{{"category", "a&b=c"}}, Tags: []FilterKeyValue{{"t1", "1"}, {"t 2", "2"}}},
+			true,
+		},
+		{ObjectFilter{Metadata: []FilterKeyValue{{"k", "v"}}}, ObjectFilter{Tags: []FilterKeyValue{{"k", "v"}}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.filter.encode() == testCase.filter2.encode()
+
+		if result != testCase.expectedResult {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
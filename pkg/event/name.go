@@ -37,6 +37,15 @@ const (
 	ObjectCreatedPut
 	ObjectRemovedAll
 	ObjectRemovedDelete
+	BucketQuotaSoftLimitExceeded
+	ObjectRemovedDeleteMarkerCreated
+	ObjectReplicationAll
+	ObjectReplicationComplete
+	ObjectReplicationFailed
+	ObjectHealed
+	BucketCreated
+	BucketRemoved
+	TestEvent
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -47,7 +56,9 @@ func (name Name) Expand() []Name {
 	case ObjectCreatedAll:
 		return []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}
 	case ObjectRemovedAll:
-		return []Name{ObjectRemovedDelete}
+		return []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}
+	case ObjectReplicationAll:
+		return []Name{ObjectReplicationComplete, ObjectReplicationFailed}
 	default:
 		return []Name{name}
 	}
@@ -76,6 +87,24 @@ func (name Name) String() string {
 		return "s3:ObjectRemoved:*"
 	case ObjectRemovedDelete:
 		return "s3:ObjectRemoved:Delete"
+	case BucketQuotaSoftLimitExceeded:
+		return "s3:BucketQuota:SoftLimitExceeded"
+	case ObjectRemovedDeleteMarkerCreated:
+		return "s3:ObjectRemoved:DeleteMarkerCreated"
+	case ObjectReplicationAll:
+		return "s3:Replication:*"
+	case ObjectReplicationComplete:
+		return "s3:Replication:OperationCompletedReplication"
+	case ObjectReplicationFailed:
+		return "s3:Replication:OperationFailedReplication"
+	case ObjectHealed:
+		return "s3:ObjectHealed"
+	case BucketCreated:
+		return "s3:BucketCreated"
+	case BucketRemoved:
+		return "s3:BucketRemoved"
+	case TestEvent:
+		return "s3:TestEvent"
 	}
 
 	return ""
@@ -146,6 +175,24 @@ func ParseName(s string) (Name, error) {
 		return ObjectRemovedAll, nil
 	case "s3:ObjectRemoved:Delete":
 		return ObjectRemovedDelete, nil
+	case "s3:BucketQuota:SoftLimitExceeded":
+		return BucketQuotaSoftLimitExceeded, nil
+	case "s3:ObjectRemoved:DeleteMarkerCreated":
+		return ObjectRemovedDeleteMarkerCreated, nil
+	case "s3:Replication:*":
+		return ObjectReplicationAll, nil
+	case "s3:Replication:OperationCompletedReplication":
+		return ObjectReplicationComplete, nil
+	case "s3:Replication:OperationFailedReplication":
+		return ObjectReplicationFailed, nil
+	case "s3:ObjectHealed":
+		return ObjectHealed, nil
+	case "s3:BucketCreated":
+		return BucketCreated, nil
+	case "s3:BucketRemoved":
+		return BucketRemoved, nil
+	case "s3:TestEvent":
+		return TestEvent, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
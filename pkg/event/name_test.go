@@ -30,8 +30,10 @@ func TestNameExpand(t *testing.T) {
 	}{
 		{ObjectAccessedAll, []Name{ObjectAccessedGet, ObjectAccessedHead}},
 		{ObjectCreatedAll, []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}},
-		{ObjectRemovedAll, []Name{ObjectRemovedDelete}},
+		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
+		{ObjectReplicationAll, []Name{ObjectReplicationComplete, ObjectReplicationFailed}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
+		{BucketCreated, []Name{BucketCreated}},
 	}
 
 	for i, testCase := range testCases {
@@ -60,6 +62,15 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{BucketQuotaSoftLimitExceeded, "s3:BucketQuota:SoftLimitExceeded"},
+		{ObjectRemovedDeleteMarkerCreated, "s3:ObjectRemoved:DeleteMarkerCreated"},
+		{ObjectReplicationAll, "s3:Replication:*"},
+		{ObjectReplicationComplete, "s3:Replication:OperationCompletedReplication"},
+		{ObjectReplicationFailed, "s3:Replication:OperationFailedReplication"},
+		{ObjectHealed, "s3:ObjectHealed"},
+		{BucketCreated, "s3:BucketCreated"},
+		{BucketRemoved, "s3:BucketRemoved"},
+		{TestEvent, "s3:TestEvent"},
 		{blankName, ""},
 	}
 
@@ -200,6 +211,12 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"s3:BucketQuota:SoftLimitExceeded", BucketQuotaSoftLimitExceeded, false},
+		{"s3:ObjectRemoved:DeleteMarkerCreated", ObjectRemovedDeleteMarkerCreated, false},
+		{"s3:Replication:OperationFailedReplication", ObjectReplicationFailed, false},
+		{"s3:BucketCreated", BucketCreated, false},
+		{"s3:TestEvent", TestEvent, false},
+		{"s3:BucketRemoved:*", blankName, true},
 		{"", blankName, true},
 	}
 
@@ -45,22 +45,71 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
-// Rules - event rules
-type Rules map[string]TargetIDSet
+// ruleKeySeparator - separates pattern and encoded object filter in rules
+// key, filter rule values never contain it.
+const ruleKeySeparator = "\x00"
+
+// newRuleKey - returns rules key for pattern and object filter, the key
+// is the pattern itself if there is no object filter.
+func newRuleKey(pattern string, filter *ObjectFilter) string {
+	if filter == nil {
+		return pattern
+	}
+
+	return pattern + ruleKeySeparator + filter.encode()
+}
+
+// Rule - target IDs of objects matching name pattern and object filter,
+// nil object filter matches all objects.
+type Rule struct {
+	Pattern   string
+	Filter    *ObjectFilter
+	TargetIDs TargetIDSet
+}
+
+// Rules - event rules keyed by pattern and object filter, the key is a
+// string so equal rules added separately are merged.
+type Rules map[string]Rule
 
 // Add - adds pattern and target ID.
 func (rules Rules) Add(pattern string, targetID TargetID) {
-	rules[pattern] = NewTargetIDSet(targetID).Union(rules[pattern])
+	rules.AddFilter(pattern, nil, targetID)
+}
+
+// AddFilter - adds pattern, object filter and target ID.
+func (rules Rules) AddFilter(pattern string, filter *ObjectFilter, targetID TargetID) {
+	if filter != nil && filter.IsEmpty() {
+		filter = nil
+	}
+
+	rules.add(newRuleKey(pattern, filter), Rule{pattern, filter, NewTargetIDSet(targetID)})
+}
+
+// add - adds target IDs of rule by rules key.
+func (rules Rules) add(key string, rule Rule) {
+	rule.TargetIDs = rule.TargetIDs.Union(rules[key].TargetIDs)
+	rules[key] = rule
 }
 
 // Match - returns TargetIDSet matching object name in rules.
 func (rules Rules) Match(objectName string) TargetIDSet {
+	return rules.MatchObject(ObjectInfo{Name: objectName})
+}
+
+// MatchObject - returns TargetIDSet matching object in rules.
+func (rules Rules) MatchObject(object ObjectInfo) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
-			targetIDs = targetIDs.Union(targetIDSet)
+	for _, rule := range rules {
+		if !wildcard.MatchSimple(rule.Pattern, object.Name) {
+			continue
+		}
+
+		if rule.Filter != nil && !rule.Filter.Match(object) {
+			continue
 		}
+
+		targetIDs = targetIDs.Union(rule.TargetIDs)
 	}
 
 	return targetIDs
@@ -70,8 +119,9 @@ func (rules Rules) Match(objectName string) TargetIDSet {
 func (rules Rules) Clone() Rules {
 	rulesCopy := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		rulesCopy[pattern] = targetIDSet.Clone()
+	for key, rule := range rules {
+		rule.TargetIDs = rule.TargetIDs.Clone()
+		rulesCopy[key] = rule
 	}
 
 	return rulesCopy
@@ -81,8 +131,8 @@ func (rules Rules) Clone() Rules {
 func (rules Rules) Union(rules2 Rules) Rules {
 	nrules := rules.Clone()
 
-	for pattern, targetIDSet := range rules2 {
-		nrules[pattern] = nrules[pattern].Union(targetIDSet)
+	for key, rule := range rules2 {
+		nrules.add(key, rule)
 	}
 
 	return nrules
@@ -92,9 +142,10 @@ func (rules Rules) Union(rules2 Rules) Rules {
 func (rules Rules) Difference(rules2 Rules) Rules {
 	nrules := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		if nv := targetIDSet.Difference(rules2[pattern]); len(nv) > 0 {
-			nrules[pattern] = nv
+	for key, rule := range rules {
+		if nv := rule.TargetIDs.Difference(rules2[key].TargetIDs); len(nv) > 0 {
+			rule.TargetIDs = nv
+			nrules[key] = rule
 		}
 	}
 
@@ -132,6 +132,35 @@ func TestRulesMatch(t *testing.T) {
 	}
 }
 
+func TestRulesMatchObject(t *testing.T) {
+	rulesCase1 := make(Rules)
+	rulesCase1.Add(NewPattern("videos/", ""), TargetID{"1", "webhook"})
+	rulesCase1.AddFilter(NewPattern("videos/", ""), &ObjectFilter{MinSize: 100, ContentTypes: []string{"video/*"}}, TargetID{"2", "amqp"})
+
+	rulesCase2 := make(Rules)
+	rulesCase2.AddFilter("*", &ObjectFilter{Tags: []FilterKeyValue{{"project", "x*"}}}, TargetID{"1", "webhook"})
+
+	testCases := []struct {
+		rules          Rules
+		object         ObjectInfo
+		expectedResult TargetIDSet
+	}{
+		{rulesCase1, ObjectInfo{Name: "videos/a.mp4", Size: 200, ContentType: "video/mp4"}, NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"2", "amqp"})},
+		{rulesCase1, ObjectInfo{Name: "videos/a.mp4", Size: 50, ContentType: "video/mp4"}, NewTargetIDSet(TargetID{"1", "webhook"})},
+		{rulesCase1, ObjectInfo{Name: "photos/a.mp4", Size: 200, ContentType: "video/mp4"}, NewTargetIDSet()},
+		{rulesCase2, ObjectInfo{Name: "a.txt", Tags: map[string]string{"project": "x1"}}, NewTargetIDSet(TargetID{"1", "webhook"})},
+		{rulesCase2, ObjectInfo{Name: "a.txt"}, NewTargetIDSet()},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.rules.MatchObject(testCase.object)
+
+		if !reflect.DeepEqual(testCase.expectedResult, result) {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestRulesClone(t *testing.T) {
 	rulesCase1 := make(Rules)
 
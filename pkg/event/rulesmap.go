@@ -21,8 +21,13 @@ type RulesMap map[Name]Rules
 
 // add - adds event names, prefixes, suffixes and target ID to rules map.
 func (rulesMap RulesMap) add(eventNames []Name, pattern string, targetID TargetID) {
+	rulesMap.addFilter(eventNames, pattern, nil, targetID)
+}
+
+// addFilter - adds event names, prefixes, suffixes, object filter and target ID to rules map.
+func (rulesMap RulesMap) addFilter(eventNames []Name, pattern string, filter *ObjectFilter, targetID TargetID) {
 	rules := make(Rules)
-	rules.Add(pattern, targetID)
+	rules.AddFilter(pattern, filter, targetID)
 
 	for _, eventName := range eventNames {
 		for _, name := range eventName.Expand() {
@@ -65,14 +70,25 @@ func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
 	return rulesMap[eventName].Match(objectName)
 }
 
+// MatchObject - returns TargetIDSet matching object and event name in rules map.
+func (rulesMap RulesMap) MatchObject(eventName Name, object ObjectInfo) TargetIDSet {
+	return rulesMap[eventName].MatchObject(object)
+}
+
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
+	return NewFilterRulesMap(eventNames, pattern, nil, targetID)
+}
+
+// NewFilterRulesMap - creates new rules map with given values, nil object
+// filter matches all objects.
+func NewFilterRulesMap(eventNames []Name, pattern string, filter *ObjectFilter, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
 	if pattern == "" {
 		pattern = "*"
 	}
 
 	rulesMap := make(RulesMap)
-	rulesMap.add(eventNames, pattern, targetID)
+	rulesMap.addFilter(eventNames, pattern, filter, targetID)
 	return rulesMap
 }
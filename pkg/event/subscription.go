@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"errors"
+
+	"github.com/minio/minio-go/pkg/set"
+)
+
+// Subscription - server wide subscription of a target to events of all
+// buckets. Events without bucket notification configuration, like
+// s3:BucketCreated, are delivered only to server wide subscriptions.
+type Subscription struct {
+	ARN    ARN    `json:"arn"`
+	Events []Name `json:"events"`
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// Validate - checks whether subscription has valid values or not.
+func (s Subscription) Validate(region string) error {
+	if s.ARN.TargetID.ID == "" || s.ARN.TargetID.Name == "" {
+		return errors.New("missing arn")
+	}
+
+	if s.ARN.region != "" && region != "" && s.ARN.region != region {
+		return &ErrUnknownRegion{s.ARN.region}
+	}
+
+	if len(s.Events) == 0 {
+		return errors.New("missing event name(s)")
+	}
+
+	eventStringSet := set.NewStringSet()
+	for _, eventName := range s.Events {
+		if eventStringSet.Contains(eventName.String()) {
+			return &ErrDuplicateEventName{eventName}
+		}
+
+		eventStringSet.Add(eventName.String())
+	}
+
+	if err := ValidateFilterRuleValue(s.Prefix); err != nil {
+		return err
+	}
+
+	return ValidateFilterRuleValue(s.Suffix)
+}
+
+// ToRulesMap - converts Subscription to RulesMap, prefix and suffix apply
+// only to object events as bucket events have no object name.
+func (s Subscription) ToRulesMap() RulesMap {
+	var bucketEvents, objectEvents []Name
+	for _, eventName := range s.Events {
+		switch eventName {
+		case BucketCreated, BucketRemoved:
+			bucketEvents = append(bucketEvents, eventName)
+		default:
+			objectEvents = append(objectEvents, eventName)
+		}
+	}
+
+	rulesMap := NewRulesMap(bucketEvents, "*", s.ARN.TargetID)
+	rulesMap.Add(NewRulesMap(objectEvents, NewPattern(s.Prefix, s.Suffix), s.ARN.TargetID))
+	return rulesMap
+}
@@ -0,0 +1,124 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSubscriptionUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data           []byte
+		expectedResult *Subscription
+		expectErr      bool
+	}{
+		{[]byte(`{"arn": "webhook", "events": ["s3:BucketCreated"]}`), nil, true},
+		{[]byte(`{"arn": "arn:minio:sqs::1:webhook", "events": ["s3:BucketCreated:*"]}`), nil, true},
+		{[]byte(`{"arn": "arn:minio:sqs::1:webhook", "events": ["s3:BucketCreated", "s3:BucketRemoved"]}`),
+			&Subscription{ARN: ARN{TargetID{"1", "webhook"}, ""}, Events: []Name{BucketCreated, BucketRemoved}}, false},
+		{[]byte(`{"arn": "arn:minio:sqs:us-east-1:1:webhook", "events": ["s3:ObjectCreated:*"], "prefix": "images/"}`),
+			&Subscription{ARN: ARN{TargetID{"1", "webhook"}, "us-east-1"}, Events: []Name{ObjectCreatedAll}, Prefix: "images/"}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := &Subscription{}
+		err := json.Unmarshal(testCase.data, result)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("test %v: data: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("test %v: error: %v", i+1, err)
+			}
+
+			subscription := &Subscription{}
+			if err = json.Unmarshal(data, subscription); err != nil {
+				t.Fatalf("test %v: error: %v", i+1, err)
+			}
+
+			if !reflect.DeepEqual(subscription, result) {
+				t.Fatalf("test %v: data: expected: %v, got: %v", i+1, result, subscription)
+			}
+		}
+	}
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	arn := ARN{TargetID{"1", "webhook"}, "us-east-1"}
+
+	testCases := []struct {
+		subscription Subscription
+		region       string
+		expectErr    bool
+	}{
+		{Subscription{Events: []Name{BucketCreated}}, "", true},
+		{Subscription{ARN: arn}, "", true},
+		{Subscription{ARN: arn, Events: []Name{BucketCreated, BucketCreated}}, "", true},
+		{Subscription{ARN: arn, Events: []Name{BucketCreated}}, "eu-west-1", true},
+		{Subscription{ARN: arn, Events: []Name{ObjectCreatedAll}, Prefix: "../"}, "", true},
+		{Subscription{ARN: arn, Events: []Name{BucketCreated}}, "us-east-1", false},
+		{Subscription{ARN: ARN{TargetID: TargetID{"1", "webhook"}}, Events: []Name{BucketCreated}}, "eu-west-1", false},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.subscription.Validate(testCase.region)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+func TestSubscriptionToRulesMap(t *testing.T) {
+	subscription := Subscription{
+		ARN:    ARN{TargetID: TargetID{"1", "webhook"}},
+		Events: []Name{BucketCreated, ObjectCreatedPut},
+		Prefix: "images/",
+	}
+
+	rulesMap := subscription.ToRulesMap()
+
+	testCases := []struct {
+		eventName      Name
+		objectName     string
+		expectedResult TargetIDSet
+	}{
+		{BucketCreated, "", NewTargetIDSet(TargetID{"1", "webhook"})},
+		{ObjectCreatedPut, "images/a.jpg", NewTargetIDSet(TargetID{"1", "webhook"})},
+		{ObjectCreatedPut, "a.jpg", NewTargetIDSet()},
+		{BucketRemoved, "", NewTargetIDSet()},
+	}
+
+	for i, testCase := range testCases {
+		result := rulesMap.Match(testCase.eventName, testCase.objectName)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -42,6 +42,8 @@ type AMQPArgs struct {
 	Internal     bool     `json:"internal"`
 	NoWait       bool     `json:"noWait"`
 	AutoDeleted  bool     `json:"autoDeleted"`
+	QueueDir     string   `json:"queueDir"`
+	QueueLimit   uint64   `json:"queueLimit"`
 }
 
 // Validate AMQP arguments
@@ -52,7 +54,7 @@ func (a *AMQPArgs) Validate() error {
 	if _, err := amqp.ParseURI(a.URL.String()); err != nil {
 		return err
 	}
-	return nil
+	return validateQueueDir(a.QueueDir)
 }
 
 // AMQPTarget - AMQP target
@@ -142,8 +144,14 @@ func (target *AMQPTarget) Send(eventData event.Event) error {
 		})
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - closes underneath connection to AMQP server.
 func (target *AMQPTarget) Close() error {
+	target.connMutex.Lock()
+	defer target.connMutex.Unlock()
+
+	if err := target.conn.Close(); err != nil && err != amqp.ErrClosed {
+		return err
+	}
 	return nil
 }
 
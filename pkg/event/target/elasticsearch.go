@@ -31,10 +31,12 @@ import (
 
 // ElasticsearchArgs - Elasticsearch target arguments.
 type ElasticsearchArgs struct {
-	Enable bool     `json:"enable"`
-	Format string   `json:"format"`
-	URL    xnet.URL `json:"url"`
-	Index  string   `json:"index"`
+	Enable     bool     `json:"enable"`
+	Format     string   `json:"format"`
+	URL        xnet.URL `json:"url"`
+	Index      string   `json:"index"`
+	QueueDir   string   `json:"queueDir"`
+	QueueLimit uint64   `json:"queueLimit"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -54,7 +56,7 @@ func (a ElasticsearchArgs) Validate() error {
 	if a.Index == "" {
 		return errors.New("empty index value")
 	}
-	return nil
+	return validateQueueDir(a.QueueDir)
 }
 
 // ElasticsearchTarget - Elasticsearch target.
@@ -111,8 +113,9 @@ func (target *ElasticsearchTarget) Send(eventData event.Event) error {
 	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - stops background processes of Elasticsearch client.
 func (target *ElasticsearchTarget) Close() error {
+	target.client.Stop()
 	return nil
 }
 
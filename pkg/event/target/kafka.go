@@ -43,6 +43,8 @@ type KafkaArgs struct {
 		User     string `json:"username"`
 		Password string `json:"password"`
 	} `json:"sasl"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
 }
 
 // Validate KafkaArgs fields
@@ -58,7 +60,7 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return validateQueueDir(k.QueueDir)
 }
 
 // KafkaTarget - Kafka target.
@@ -17,15 +17,21 @@
 package target
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/url"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/scriptburn/minio/pkg/event"
 	xnet "github.com/scriptburn/minio/pkg/net"
 )
@@ -43,6 +49,7 @@ type MQTTArgs struct {
 	KeepAlive            time.Duration  `json:"keepAliveInterval"`
 	RootCAs              *x509.CertPool `json:"-"`
 	QueueDir             string         `json:"queueDir"`
+	QueueLimit           uint64         `json:"queueLimit"`
 }
 
 // Validate MQTTArgs fields
@@ -59,10 +66,7 @@ func (m MQTTArgs) Validate() error {
 	default:
 		return errors.New("unknown protocol in broker address")
 	}
-	if m.QueueDir != "" && !filepath.IsAbs(m.QueueDir) {
-		return errors.New("queueDir path should be absolute")
-	}
-	return nil
+	return validateQueueDir(m.QueueDir)
 }
 
 // MQTTTarget - MQTT target.
@@ -100,20 +104,15 @@ func (target *MQTTTarget) Send(eventData event.Event) error {
 	}
 
 	token := target.client.Publish(target.args.Topic, target.args.QoS, false, string(data))
-
-	// In-case if the queueDir is configured OR mqtt broker is offline, the token.Wait() waits indefinitely
-	if target.args.QueueDir == "" {
-		token.Wait()
-		return token.Error()
-	}
-
-	// Need a fix from the paho.mqtt.golang library - https://github.com/eclipse/paho.mqtt.golang/issues/274
-	// Right now the server panics on IO File store errors, returning nil for now.
-	return nil
+	token.Wait()
+	return token.Error()
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - disconnects from MQTT broker.
 func (target *MQTTTarget) Close() error {
+	if target.client.IsConnected() {
+		target.client.Disconnect(250)
+	}
 	return nil
 }
 
@@ -129,19 +128,77 @@ func NewMQTTTarget(id string, args MQTTArgs) (*MQTTTarget, error) {
 		SetTLSConfig(&tls.Config{RootCAs: args.RootCAs}).
 		AddBroker(args.Broker.String())
 
-	if args.QueueDir != "" {
-		options = options.SetStore(mqtt.NewFileStore(args.QueueDir))
-	}
-
 	client := mqtt.NewClient(options)
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
 		return nil, token.Error()
 	}
 
+	if args.QueueDir != "" {
+		err := migrateMQTTFileStore(args.QueueDir, func(packet *packets.PublishPacket) error {
+			token := client.Publish(packet.TopicName, packet.Qos, packet.Retain, packet.Payload)
+			token.Wait()
+			return token.Error()
+		})
+		if err != nil {
+			client.Disconnect(250)
+			return nil, err
+		}
+	}
+
 	return &MQTTTarget{
 		id:     event.TargetID{ID: id, Name: "mqtt"},
 		args:   args,
 		client: client,
 	}, nil
 }
+
+// migrateMQTTFileStore - publishes events queued in queueDir by the MQTT
+// client file store of older releases, oldest first, and removes them.
+// Events queued since are kept in a sub-directory by the queue store.
+func migrateMQTTFileStore(queueDir string, publish func(*packets.PublishPacket) error) error {
+	files, err := ioutil.ReadDir(queueDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		// Outgoing packets are saved as "o.<message-id>.msg".
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, "o.") || !strings.HasSuffix(name, ".msg") {
+			continue
+		}
+
+		path := filepath.Join(queueDir, name)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		packet, err := packets.ReadPacket(bytes.NewReader(data))
+		if err != nil {
+			// Unreadable packets are left as is, they never become readable.
+			continue
+		}
+
+		// Other outgoing packets belong to sessions which are gone.
+		if publishPacket, ok := packet.(*packets.PublishPacket); ok {
+			if err = publish(publishPacket); err != nil {
+				return err
+			}
+		}
+
+		if err = os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+func TestMigrateMQTTFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mqtt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = migrateMQTTFileStore(filepath.Join(dir, "notfound"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Packets as saved by the MQTT client file store of older releases.
+	fileStore := mqtt.NewFileStore(dir)
+	fileStore.Open()
+	modTime := time.Now().Add(-time.Hour)
+	putPacket := func(key string, packet packets.ControlPacket) {
+		fileStore.Put(key, packet)
+		modTime = modTime.Add(time.Second)
+		if err := os.Chtimes(filepath.Join(dir, key+".msg"), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newPublishPacket := func(payload string) *packets.PublishPacket {
+		packet := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+		packet.TopicName = "minio"
+		packet.Qos = 1
+		packet.Payload = []byte(payload)
+		return packet
+	}
+	putPacket("o.2", newPublishPacket("event1"))
+	putPacket("i.3", packets.NewControlPacket(packets.Pubrec))
+	putPacket("o.1", newPublishPacket("event2"))
+	putPacket("o.4", packets.NewControlPacket(packets.Pubrel))
+	putPacket("o.3", newPublishPacket("event3"))
+	if err = os.Mkdir(filepath.Join(dir, "minio-mqtt-1"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	errPublish := errors.New("broker is offline")
+	var payloads []string
+	publish := func(packet *packets.PublishPacket) error {
+		if len(payloads) == 1 && errPublish != nil {
+			return errPublish
+		}
+		if packet.TopicName != "minio" || packet.Qos != 1 {
+			t.Fatalf("unexpected packet %v", packet)
+		}
+		payloads = append(payloads, string(packet.Payload))
+		return nil
+	}
+
+	// Publishing stops at the first failure, the rest is kept.
+	if err = migrateMQTTFileStore(dir, publish); err != errPublish {
+		t.Fatalf("expected: %v, got: %v", errPublish, err)
+	}
+	errPublish = nil
+	if err = migrateMQTTFileStore(dir, publish); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPayloads := []string{"event1", "event2", "event3"}
+	if !reflect.DeepEqual(payloads, expectedPayloads) {
+		t.Fatalf("expected: %v, got: %v", expectedPayloads, payloads)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	expectedNames := []string{"i.3.msg", "minio-mqtt-1"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("expected: %v, got: %v", expectedNames, names)
+	}
+}
@@ -43,6 +43,8 @@ type NATSArgs struct {
 		Async              bool   `json:"async"`
 		MaxPubAcksInflight int    `json:"maxPubAcksInflight"`
 	} `json:"streaming"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
 }
 
 // Validate NATSArgs fields
@@ -65,7 +67,7 @@ func (n NATSArgs) Validate() error {
 		}
 	}
 
-	return nil
+	return validateQueueDir(n.QueueDir)
 }
 
 // NATSTarget - NATS target.
@@ -37,6 +37,8 @@ type NSQArgs struct {
 		Enable     bool `json:"enable"`
 		SkipVerify bool `json:"skipVerify"`
 	} `json:"tls"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
 }
 
 // Validate NSQArgs fields
@@ -53,7 +55,7 @@ func (n NSQArgs) Validate() error {
 		return errors.New("empty topic")
 	}
 
-	return nil
+	return validateQueueDir(n.QueueDir)
 }
 
 // NSQTarget - NSQ target.
@@ -89,6 +89,8 @@ type PostgreSQLArgs struct {
 	User             string   `json:"user"`     // default: user running minio
 	Password         string   `json:"password"` // default: no password
 	Database         string   `json:"database"` // default: same as user
+	QueueDir         string   `json:"queueDir"`
+	QueueLimit       uint64   `json:"queueLimit"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -122,7 +124,7 @@ func (p PostgreSQLArgs) Validate() error {
 		}
 	}
 
-	return nil
+	return validateQueueDir(p.QueueDir)
 }
 
 // PostgreSQLTarget - PostgreSQL target.
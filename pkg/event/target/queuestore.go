@@ -0,0 +1,201 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scriptburn/minio/pkg/event"
+)
+
+const (
+	// DefaultQueueLimit - maximum number of events kept in a queue
+	// directory when no queue limit is configured.
+	DefaultQueueLimit = 10000
+
+	eventExt = ".event"
+	tmpExt   = ".tmp"
+)
+
+// errLimitExceeded - returned when the queue store is full.
+var errLimitExceeded = errors.New("the maximum queue limit reached")
+
+// queueStore - persists events as files in a directory, one file per
+// event, named by a sequence number so they are listed in the order
+// they were put.
+type queueStore struct {
+	sync.Mutex
+	directory string
+	limit     uint64
+	count     uint64
+	lastSeq   int64
+}
+
+// newQueueStore - creates queue store for given directory, limit 0
+// means DefaultQueueLimit.
+func newQueueStore(directory string, limit uint64) *queueStore {
+	if limit == 0 {
+		limit = DefaultQueueLimit
+	}
+
+	return &queueStore{
+		directory: directory,
+		limit:     limit,
+	}
+}
+
+// Open - creates the queue directory if not present and loads the
+// state of already queued events.
+func (store *queueStore) Open() error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.MkdirAll(store.directory, 0700); err != nil {
+		return err
+	}
+
+	// Remove events partially written before a crash.
+	tmpPaths, err := filepath.Glob(filepath.Join(store.directory, "*"+tmpExt))
+	if err != nil {
+		return err
+	}
+	for _, tmpPath := range tmpPaths {
+		os.Remove(tmpPath)
+	}
+
+	keys, err := store.list()
+	if err != nil {
+		return err
+	}
+
+	store.count = uint64(len(keys))
+	if len(keys) > 0 {
+		seq, err := strconv.ParseInt(keys[len(keys)-1], 10, 64)
+		if err != nil {
+			return err
+		}
+		store.lastSeq = seq
+	}
+
+	return nil
+}
+
+// Put - saves event to the end of the queue.
+func (store *queueStore) Put(eventData event.Event) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if store.count >= store.limit {
+		return errLimitExceeded
+	}
+
+	data, err := json.Marshal(eventData)
+	if err != nil {
+		return err
+	}
+
+	// Sequence numbers are time based so they keep increasing across
+	// restarts, events put within the same nanosecond still get
+	// distinct increasing numbers.
+	seq := time.Now().UnixNano()
+	if seq <= store.lastSeq {
+		seq = store.lastSeq + 1
+	}
+
+	key := fmt.Sprintf("%020d", seq)
+	tmpPath := filepath.Join(store.directory, key+tmpExt)
+	if err = ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	// Rename is atomic, a partially written event is never listed.
+	if err = os.Rename(tmpPath, filepath.Join(store.directory, key+eventExt)); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	store.lastSeq = seq
+	store.count++
+	return nil
+}
+
+// Get - reads event by key.
+func (store *queueStore) Get(key string) (eventData event.Event, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(store.directory, key+eventExt))
+	if err != nil {
+		return eventData, err
+	}
+
+	err = json.Unmarshal(data, &eventData)
+	return eventData, err
+}
+
+// Del - removes event by key.
+func (store *queueStore) Del(key string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.Remove(filepath.Join(store.directory, key+eventExt)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if store.count > 0 {
+		store.count--
+	}
+	return nil
+}
+
+// List - returns keys of queued events in the order they were put.
+func (store *queueStore) List() ([]string, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	return store.list()
+}
+
+func (store *queueStore) list() ([]string, error) {
+	entries, err := ioutil.ReadDir(store.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, eventExt) {
+			continue
+		}
+		keys = append(keys, strings.TrimSuffix(name, eventExt))
+	}
+
+	// Keys are zero padded numbers, lexical order is the put order.
+	sort.Strings(keys)
+	return keys, nil
+}
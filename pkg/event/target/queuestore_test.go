@@ -0,0 +1,116 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scriptburn/minio/pkg/event"
+)
+
+func newTestEvent(objectName string) event.Event {
+	return event.Event{
+		EventVersion: "2.0",
+		EventName:    event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "mybucket"},
+			Object: event.Object{Key: objectName},
+		},
+	}
+}
+
+func TestQueueStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "queuestore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := newQueueStore(filepath.Join(dir, "queue"), 3)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = store.Put(newTestEvent(fmt.Sprintf("object%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = store.Put(newTestEvent("object3")); err != errLimitExceeded {
+		t.Fatalf("expected: %v, got: %v", errLimitExceeded, err)
+	}
+
+	// Partially written event is removed on open.
+	if err = ioutil.WriteFile(filepath.Join(dir, "queue", "00000000000000000001"+tmpExt), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queued events are loaded in order when opened again.
+	store = newQueueStore(filepath.Join(dir, "queue"), 3)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if store.count != 3 {
+		t.Fatalf("expected: 3, got: %v", store.count)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "queue", "00000000000000000001"+tmpExt)); !os.IsNotExist(err) {
+		t.Fatalf("expected partially written event to be removed, got: %v", err)
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("expected: 3 keys, got: %v", keys)
+	}
+
+	for i, key := range keys {
+		eventData, err := store.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := newTestEvent(fmt.Sprintf("object%d", i))
+		if !reflect.DeepEqual(eventData, expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, eventData)
+		}
+	}
+
+	if err = store.Del(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	// Deleting again is not an error.
+	if err = store.Del(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put(newTestEvent("object3")); err != nil {
+		t.Fatal(err)
+	}
+
+	newKeys, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newKeys) != 3 || newKeys[2] <= keys[2] {
+		t.Fatalf("expected new event after %v, got: %v", keys[2], newKeys)
+	}
+}
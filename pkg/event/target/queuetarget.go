@@ -0,0 +1,242 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scriptburn/minio/pkg/event"
+)
+
+const (
+	// minRetryInterval - interval after first failure to send a queued event.
+	minRetryInterval = 1 * time.Second
+
+	// maxRetryInterval - maximum interval between retries of a queued event.
+	maxRetryInterval = 1 * time.Minute
+)
+
+// validateQueueDir - checks queue directory is an absolute path if set.
+func validateQueueDir(queueDir string) error {
+	if queueDir != "" && !filepath.IsAbs(queueDir) {
+		return errors.New("queueDir path should be absolute")
+	}
+	return nil
+}
+
+// QueueTarget - target which saves events in a queue directory and
+// replays them to the underlying target in order, retrying with
+// backoff while the underlying target is not reachable.
+type QueueTarget struct {
+	id        event.TargetID
+	store     *queueStore
+	newTarget func() (event.Target, error)
+	logger    func(error)
+
+	// Underlying target, created on first replay so events are queued
+	// even when the target is not reachable at startup.
+	target event.Target
+
+	// Set while queued events can not be sent to the underlying target.
+	offline uint32
+
+	putCh        chan struct{}
+	doneCh       chan struct{}
+	replayDoneCh chan struct{}
+	closeOnce    sync.Once
+}
+
+// ID - returns target ID.
+func (target *QueueTarget) ID() event.TargetID {
+	return target.id
+}
+
+// IsOnline - returns false while queued events can not be sent to the
+// underlying target.
+func (target *QueueTarget) IsOnline() bool {
+	return atomic.LoadUint32(&target.offline) == 0
+}
+
+// Send - saves event to the queue directory, the event is sent to the
+// underlying target in background.
+func (target *QueueTarget) Send(eventData event.Event) error {
+	if err := target.store.Put(eventData); err != nil {
+		return err
+	}
+
+	// Wake up replay without blocking, one pending signal is enough.
+	select {
+	case target.putCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Close - stops replaying events and waits until an event being sent is
+// done and the underlying target is closed. Queued events are kept and
+// replayed when the target is created again.
+func (target *QueueTarget) Close() error {
+	target.closeOnce.Do(func() {
+		close(target.doneCh)
+	})
+	<-target.replayDoneCh
+	return nil
+}
+
+// wait - waits for duration, returns false if target is closed meanwhile.
+func (target *QueueTarget) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-target.doneCh:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// send - sends event to the underlying target until it succeeds,
+// returns false if target is closed meanwhile.
+func (target *QueueTarget) send(eventData event.Event) bool {
+	retryInterval := minRetryInterval
+	for {
+		err := func() error {
+			if target.target == nil {
+				t, err := target.newTarget()
+				if err != nil {
+					return err
+				}
+				target.target = t
+			}
+
+			if err := target.target.Send(eventData); err != nil {
+				// Underlying target is created again on retry, as not
+				// all targets reconnect by themselves.
+				target.target.Close()
+				target.target = nil
+				return err
+			}
+			return nil
+		}()
+		if err == nil {
+			atomic.StoreUint32(&target.offline, 0)
+			return true
+		}
+
+		atomic.StoreUint32(&target.offline, 1)
+		target.logger(fmt.Errorf("unable to send queued event to target %v, retrying in %v: %v", target.id, retryInterval, err))
+		if !target.wait(retryInterval) {
+			return false
+		}
+
+		retryInterval *= 2
+		if retryInterval > maxRetryInterval {
+			retryInterval = maxRetryInterval
+		}
+	}
+}
+
+// replay - sends queued events in order and waits for new events.
+func (target *QueueTarget) replay() {
+	defer func() {
+		if target.target != nil {
+			target.target.Close()
+		}
+		close(target.replayDoneCh)
+	}()
+
+	for {
+		keys, err := target.store.List()
+		if err != nil {
+			target.logger(err)
+			if !target.wait(maxRetryInterval) {
+				return
+			}
+			continue
+		}
+
+		for _, key := range keys {
+			select {
+			case <-target.doneCh:
+				return
+			default:
+			}
+
+			eventData, err := target.store.Get(key)
+			if err != nil {
+				// Unreadable events are dropped, they never become readable.
+				target.logger(fmt.Errorf("unable to read queued event %v of target %v: %v", key, target.id, err))
+			} else if !target.send(eventData) {
+				return
+			}
+
+			if err = target.store.Del(key); err != nil {
+				target.logger(err)
+			}
+		}
+
+		if len(keys) > 0 {
+			// Events put meanwhile are listed in the next iteration.
+			continue
+		}
+
+		select {
+		case <-target.doneCh:
+			return
+		case <-target.putCh:
+		}
+	}
+}
+
+// NewQueueTarget - creates new queue target, events are queued in a
+// directory named by target ID in queueDir. newTarget creates the
+// underlying target and is retried until it succeeds, logger is called
+// with errors of sending queued events.
+func NewQueueTarget(id event.TargetID, queueDir string, queueLimit uint64, newTarget func() (event.Target, error), logger func(error)) (*QueueTarget, error) {
+	if queueDir == "" {
+		return nil, errors.New("empty queueDir")
+	}
+	if err := validateQueueDir(queueDir); err != nil {
+		return nil, err
+	}
+
+	store := newQueueStore(filepath.Join(queueDir, "minio-"+id.Name+"-"+id.ID), queueLimit)
+	if err := store.Open(); err != nil {
+		return nil, err
+	}
+
+	target := &QueueTarget{
+		id:           id,
+		store:        store,
+		newTarget:    newTarget,
+		logger:       logger,
+		putCh:        make(chan struct{}, 1),
+		doneCh:       make(chan struct{}),
+		replayDoneCh: make(chan struct{}),
+	}
+
+	go target.replay()
+
+	return target, nil
+}
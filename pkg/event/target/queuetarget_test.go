@@ -0,0 +1,181 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scriptburn/minio/pkg/event"
+)
+
+// testTarget - records events sent, fails while unreachable is set.
+type testTarget struct {
+	sync.Mutex
+	unreachable bool
+	events      []event.Event
+	sentCh      chan struct{}
+}
+
+func (target *testTarget) ID() event.TargetID {
+	return event.TargetID{ID: "1", Name: "test"}
+}
+
+func (target *testTarget) Send(eventData event.Event) error {
+	target.Lock()
+	defer target.Unlock()
+
+	if target.unreachable {
+		return errors.New("target not reachable")
+	}
+
+	target.events = append(target.events, eventData)
+	target.sentCh <- struct{}{}
+	return nil
+}
+
+func (target *testTarget) Close() error {
+	return nil
+}
+
+func TestQueueTarget(t *testing.T) {
+	dir, err := ioutil.TempDir("", "queuetarget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testTarget := &testTarget{unreachable: true, sentCh: make(chan struct{}, 10)}
+	var newTargetCalls int
+	newTarget := func() (event.Target, error) {
+		newTargetCalls++
+		if newTargetCalls == 1 {
+			return nil, errors.New("target not reachable at startup")
+		}
+		return testTarget, nil
+	}
+
+	if _, err = NewQueueTarget(testTarget.ID(), "queue", 0, newTarget, func(error) {}); err == nil {
+		t.Fatal("expected error for relative queueDir")
+	}
+
+	queueTarget, err := NewQueueTarget(testTarget.ID(), dir, 0, newTarget, func(error) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer queueTarget.Close()
+
+	for i := 0; i < 3; i++ {
+		if err = queueTarget.Send(newTestEvent(fmt.Sprintf("object%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitForOnline := func(online bool) {
+		for i := 0; queueTarget.IsOnline() != online; i++ {
+			if i == 1000 {
+				t.Fatalf("timed out waiting for online status %v", online)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitForOnline(false)
+
+	testTarget.Lock()
+	testTarget.unreachable = false
+	testTarget.Unlock()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-testTarget.sentCh:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for queued events")
+		}
+	}
+
+	waitForOnline(true)
+
+	testTarget.Lock()
+	defer testTarget.Unlock()
+	for i, eventData := range testTarget.events {
+		if expected := fmt.Sprintf("object%d", i); eventData.S3.Object.Key != expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, eventData.S3.Object.Key)
+		}
+	}
+}
+
+func TestQueueTargetClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "queuetarget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testTarget := &testTarget{unreachable: true, sentCh: make(chan struct{}, 10)}
+	newTarget := func() (event.Target, error) {
+		return testTarget, nil
+	}
+
+	queueTarget, err := NewQueueTarget(testTarget.ID(), dir, 0, newTarget, func(error) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = queueTarget.Send(newTestEvent(fmt.Sprintf("object%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Replay has stopped once Close returns, so the queued events are
+	// replayed only by the target created again.
+	queueTarget.Close()
+	testTarget.Lock()
+	testTarget.unreachable = false
+	testTarget.Unlock()
+
+	if queueTarget, err = NewQueueTarget(testTarget.ID(), dir, 0, newTarget, func(error) {}); err != nil {
+		t.Fatal(err)
+	}
+	defer queueTarget.Close()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-testTarget.sentCh:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for queued events")
+		}
+	}
+	select {
+	case <-testTarget.sentCh:
+		t.Fatal("expected queued events to be sent once")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	testTarget.Lock()
+	defer testTarget.Unlock()
+	for i, eventData := range testTarget.events {
+		if expected := fmt.Sprintf("object%d", i); eventData.S3.Object.Key != expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, eventData.S3.Object.Key)
+		}
+	}
+}
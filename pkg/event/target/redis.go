@@ -30,11 +30,13 @@ import (
 
 // RedisArgs - Redis target arguments.
 type RedisArgs struct {
-	Enable   bool      `json:"enable"`
-	Format   string    `json:"format"`
-	Addr     xnet.Host `json:"address"`
-	Password string    `json:"password"`
-	Key      string    `json:"key"`
+	Enable     bool      `json:"enable"`
+	Format     string    `json:"format"`
+	Addr       xnet.Host `json:"address"`
+	Password   string    `json:"password"`
+	Key        string    `json:"key"`
+	QueueDir   string    `json:"queueDir"`
+	QueueLimit uint64    `json:"queueLimit"`
 }
 
 // Validate RedisArgs fields
@@ -54,7 +56,7 @@ func (r RedisArgs) Validate() error {
 		return fmt.Errorf("empty key")
 	}
 
-	return nil
+	return validateQueueDir(r.QueueDir)
 }
 
 // RedisTarget - Redis target.
@@ -109,9 +111,9 @@ func (target *RedisTarget) Send(eventData event.Event) error {
 	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - closes underneath connection pool to Redis.
 func (target *RedisTarget) Close() error {
-	return nil
+	return target.pool.Close()
 }
 
 // NewRedisTarget - creates new Redis target.
@@ -36,9 +36,11 @@ import (
 
 // WebhookArgs - Webhook target arguments.
 type WebhookArgs struct {
-	Enable   bool           `json:"enable"`
-	Endpoint xnet.URL       `json:"endpoint"`
-	RootCAs  *x509.CertPool `json:"-"`
+	Enable     bool           `json:"enable"`
+	Endpoint   xnet.URL       `json:"endpoint"`
+	RootCAs    *x509.CertPool `json:"-"`
+	QueueDir   string         `json:"queueDir"`
+	QueueLimit uint64         `json:"queueLimit"`
 }
 
 // Validate WebhookArgs fields
@@ -49,7 +51,7 @@ func (w WebhookArgs) Validate() error {
 	if w.Endpoint.IsEmpty() {
 		return errors.New("endpoint empty")
 	}
-	return nil
+	return validateQueueDir(w.QueueDir)
 }
 
 // WebhookTarget - Webhook target.
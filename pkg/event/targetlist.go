@@ -56,6 +56,15 @@ func (list *TargetList) Exists(id TargetID) bool {
 	return found
 }
 
+// Get - returns target by target ID.
+func (list *TargetList) Get(id TargetID) (Target, bool) {
+	list.RLock()
+	defer list.RUnlock()
+
+	target, found := list.targets[id]
+	return target, found
+}
+
 // TargetIDErr returns error associated for a targetID
 type TargetIDErr struct {
 	// ID where the remove or send were initiated.
@@ -144,6 +144,35 @@ func TestTargetListExists(t *testing.T) {
 	}
 }
 
+func TestTargetListGet(t *testing.T) {
+	target := &ExampleTarget{TargetID{"1", "testcase"}, false, false}
+	targetList := NewTargetList()
+	if err := targetList.Add(target); err != nil {
+		panic(err)
+	}
+
+	testCases := []struct {
+		targetID       TargetID
+		expectedResult Target
+		expectedFound  bool
+	}{
+		{TargetID{"1", "webhook"}, nil, false},
+		{TargetID{"1", "testcase"}, target, true},
+	}
+
+	for i, testCase := range testCases {
+		result, found := targetList.Get(testCase.targetID)
+
+		if found != testCase.expectedFound {
+			t.Fatalf("test %v: found: expected: %v, got: %v", i+1, testCase.expectedFound, found)
+		}
+
+		if result != testCase.expectedResult {
+			t.Fatalf("test %v: data: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestTargetListRemove(t *testing.T) {
 	targetListCase1 := NewTargetList()
 
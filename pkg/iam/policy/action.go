@@ -85,31 +85,124 @@ const (
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
 
+	// DeleteObjectVersionAction - DeleteObjectVersion Rest API action.
+	DeleteObjectVersionAction = "s3:DeleteObjectVersion"
+
+	// GetBucketVersioningAction - GetBucketVersioning Rest API action.
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// GetObjectVersionAction - GetObject Rest API action on a specific object version.
+	GetObjectVersionAction = "s3:GetObjectVersion"
+
+	// ListBucketVersionsAction - ListObjectVersions Rest API action.
+	ListBucketVersionsAction = "s3:ListBucketVersions"
+
+	// PutBucketVersioningAction - PutBucketVersioning Rest API action.
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+
+	// GetBucketLifecycleAction - GetBucketLifecycle Rest API action.
+	GetBucketLifecycleAction = "s3:GetLifecycleConfiguration"
+
+	// PutBucketLifecycleAction - PutBucketLifecycle and DeleteBucketLifecycle Rest API action.
+	PutBucketLifecycleAction = "s3:PutLifecycleConfiguration"
+
+	// GetObjectTaggingAction - GetObjectTagging Rest API action.
+	GetObjectTaggingAction = "s3:GetObjectTagging"
+
+	// PutObjectTaggingAction - PutObjectTagging Rest API action.
+	PutObjectTaggingAction = "s3:PutObjectTagging"
+
+	// DeleteObjectTaggingAction - DeleteObjectTagging Rest API action.
+	DeleteObjectTaggingAction = "s3:DeleteObjectTagging"
+
+	// GetBucketObjectLockConfigurationAction - GetBucketObjectLockConfiguration Rest API action.
+	GetBucketObjectLockConfigurationAction = "s3:GetBucketObjectLockConfiguration"
+
+	// PutBucketObjectLockConfigurationAction - PutBucketObjectLockConfiguration Rest API action.
+	PutBucketObjectLockConfigurationAction = "s3:PutBucketObjectLockConfiguration"
+
+	// GetObjectRetentionAction - GetObjectRetention Rest API action.
+	GetObjectRetentionAction = "s3:GetObjectRetention"
+
+	// PutObjectRetentionAction - PutObjectRetention Rest API action.
+	PutObjectRetentionAction = "s3:PutObjectRetention"
+
+	// GetObjectLegalHoldAction - GetObjectLegalHold Rest API action.
+	GetObjectLegalHoldAction = "s3:GetObjectLegalHold"
+
+	// PutObjectLegalHoldAction - PutObjectLegalHold Rest API action.
+	PutObjectLegalHoldAction = "s3:PutObjectLegalHold"
+
+	// BypassGovernanceRetentionAction - allows deleting, overwriting or
+	// shortening the retention of objects in GOVERNANCE mode.
+	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
+
+	// GetBucketCORSAction - GetBucketCors Rest API action.
+	GetBucketCORSAction = "s3:GetBucketCORS"
+
+	// PutBucketCORSAction - PutBucketCors and DeleteBucketCors Rest API action.
+	PutBucketCORSAction = "s3:PutBucketCORS"
+
+	// GetBucketEncryptionAction - GetBucketEncryption Rest API action.
+	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
+	// PutBucketEncryptionAction - PutBucketEncryption and DeleteBucketEncryption Rest API action.
+	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
+
+	// GetBucketReplicationAction - GetBucketReplication Rest API action.
+	GetBucketReplicationAction = "s3:GetReplicationConfiguration"
+
+	// PutBucketReplicationAction - PutBucketReplication and DeleteBucketReplication Rest API action.
+	PutBucketReplicationAction = "s3:PutReplicationConfiguration"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
 
 // List of all supported actions.
 var supportedActions = map[Action]struct{}{
-	AllActions:                       {},
-	AbortMultipartUploadAction:       {},
-	CreateBucketAction:               {},
-	DeleteBucketAction:               {},
-	DeleteBucketPolicyAction:         {},
-	DeleteObjectAction:               {},
-	GetBucketLocationAction:          {},
-	GetBucketNotificationAction:      {},
-	GetBucketPolicyAction:            {},
-	GetObjectAction:                  {},
-	HeadBucketAction:                 {},
-	ListAllMyBucketsAction:           {},
-	ListBucketAction:                 {},
-	ListBucketMultipartUploadsAction: {},
-	ListenBucketNotificationAction:   {},
-	ListMultipartUploadPartsAction:   {},
-	PutBucketNotificationAction:      {},
-	PutBucketPolicyAction:            {},
-	PutObjectAction:                  {},
+	AllActions:                             {},
+	AbortMultipartUploadAction:             {},
+	CreateBucketAction:                     {},
+	DeleteBucketAction:                     {},
+	DeleteBucketPolicyAction:               {},
+	DeleteObjectAction:                     {},
+	GetBucketLocationAction:                {},
+	GetBucketNotificationAction:            {},
+	GetBucketPolicyAction:                  {},
+	GetObjectAction:                        {},
+	HeadBucketAction:                       {},
+	ListAllMyBucketsAction:                 {},
+	ListBucketAction:                       {},
+	ListBucketMultipartUploadsAction:       {},
+	ListenBucketNotificationAction:         {},
+	ListMultipartUploadPartsAction:         {},
+	PutBucketNotificationAction:            {},
+	PutBucketPolicyAction:                  {},
+	PutObjectAction:                        {},
+	DeleteObjectVersionAction:              {},
+	GetBucketVersioningAction:              {},
+	GetObjectVersionAction:                 {},
+	ListBucketVersionsAction:               {},
+	PutBucketVersioningAction:              {},
+	GetBucketLifecycleAction:               {},
+	PutBucketLifecycleAction:               {},
+	GetObjectTaggingAction:                 {},
+	PutObjectTaggingAction:                 {},
+	DeleteObjectTaggingAction:              {},
+	GetBucketObjectLockConfigurationAction: {},
+	PutBucketObjectLockConfigurationAction: {},
+	GetObjectRetentionAction:               {},
+	PutObjectRetentionAction:               {},
+	GetObjectLegalHoldAction:               {},
+	PutObjectLegalHoldAction:               {},
+	BypassGovernanceRetentionAction:        {},
+	GetBucketCORSAction:                    {},
+	PutBucketCORSAction:                    {},
+	GetBucketEncryptionAction:              {},
+	PutBucketEncryptionAction:              {},
+	GetBucketReplicationAction:             {},
+	PutBucketReplicationAction:             {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -118,6 +211,14 @@ func (action Action) isObjectAction() bool {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
 	case ListMultipartUploadPartsAction, PutObjectAction, AllActions:
+		fallthrough
+	case DeleteObjectVersionAction, GetObjectVersionAction:
+		fallthrough
+	case GetObjectTaggingAction, PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case GetObjectRetentionAction, PutObjectRetentionAction, BypassGovernanceRetentionAction:
+		fallthrough
+	case GetObjectLegalHoldAction, PutObjectLegalHoldAction:
 		return true
 	}
 
@@ -131,8 +232,11 @@ func (action Action) Match(a Action) bool {
 
 // IsValid - checks if action is valid or not.
 func (action Action) IsValid() bool {
-	_, ok := supportedActions[action]
-	return ok
+	if _, ok := supportedActions[action]; ok {
+		return true
+	}
+
+	return action.isAdminAction()
 }
 
 // MarshalJSON - encodes Action to JSON data.
@@ -172,58 +276,124 @@ func parseAction(s string) (Action, error) {
 	return action, fmt.Errorf("unsupported action '%v'", s)
 }
 
+// iamCommonKeys - is list of condition keys supported for all actions, identity
+// keys are supported by IAM policies along with common keys.
+var iamCommonKeys = append(append([]condition.Key{}, condition.CommonKeys...), condition.IdentityKeys...)
+
 // actionConditionKeyMap - holds mapping of supported condition key for an action.
 var actionConditionKeyMap = map[Action]condition.KeySet{
-	AllActions: condition.NewKeySet(condition.AllSupportedKeys...),
+	AllActions: condition.NewKeySet(
+		append(append(append([]condition.Key{}, condition.AllSupportedKeys...), condition.TagKeys...), condition.IdentityKeys...)...),
 
-	AbortMultipartUploadAction: condition.NewKeySet(condition.CommonKeys...),
+	AbortMultipartUploadAction: condition.NewKeySet(iamCommonKeys...),
 
-	CreateBucketAction: condition.NewKeySet(condition.CommonKeys...),
+	CreateBucketAction: condition.NewKeySet(iamCommonKeys...),
 
-	DeleteBucketPolicyAction: condition.NewKeySet(condition.CommonKeys...),
+	DeleteBucketPolicyAction: condition.NewKeySet(iamCommonKeys...),
 
-	DeleteObjectAction: condition.NewKeySet(condition.CommonKeys...),
+	DeleteObjectAction: condition.NewKeySet(iamCommonKeys...),
 
-	GetBucketLocationAction: condition.NewKeySet(condition.CommonKeys...),
+	GetBucketLocationAction: condition.NewKeySet(iamCommonKeys...),
 
-	GetBucketNotificationAction: condition.NewKeySet(condition.CommonKeys...),
+	GetBucketNotificationAction: condition.NewKeySet(iamCommonKeys...),
 
-	GetBucketPolicyAction: condition.NewKeySet(condition.CommonKeys...),
+	GetBucketPolicyAction: condition.NewKeySet(iamCommonKeys...),
 
 	GetObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzStorageClass,
-		}, condition.CommonKeys...)...),
+		}, iamCommonKeys...)...),
 
-	HeadBucketAction: condition.NewKeySet(condition.CommonKeys...),
+	HeadBucketAction: condition.NewKeySet(iamCommonKeys...),
 
-	ListAllMyBucketsAction: condition.NewKeySet(condition.CommonKeys...),
+	ListAllMyBucketsAction: condition.NewKeySet(iamCommonKeys...),
 
 	ListBucketAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3Prefix,
 			condition.S3Delimiter,
 			condition.S3MaxKeys,
-		}, condition.CommonKeys...)...),
+		}, iamCommonKeys...)...),
 
-	ListBucketMultipartUploadsAction: condition.NewKeySet(condition.CommonKeys...),
+	ListBucketMultipartUploadsAction: condition.NewKeySet(iamCommonKeys...),
 
-	ListenBucketNotificationAction: condition.NewKeySet(condition.CommonKeys...),
+	ListenBucketNotificationAction: condition.NewKeySet(iamCommonKeys...),
 
-	ListMultipartUploadPartsAction: condition.NewKeySet(condition.CommonKeys...),
+	ListMultipartUploadPartsAction: condition.NewKeySet(iamCommonKeys...),
 
-	PutBucketNotificationAction: condition.NewKeySet(condition.CommonKeys...),
+	PutBucketNotificationAction: condition.NewKeySet(iamCommonKeys...),
 
-	PutBucketPolicyAction: condition.NewKeySet(condition.CommonKeys...),
+	PutBucketPolicyAction: condition.NewKeySet(iamCommonKeys...),
 
 	PutObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3RequestObjectTag,
 			condition.S3XAmzCopySource,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
-		}, condition.CommonKeys...)...),
+		}, iamCommonKeys...)...),
+
+	DeleteObjectVersionAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetBucketVersioningAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetObjectVersionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, iamCommonKeys...)...),
+
+	ListBucketVersionsAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketVersioningAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetBucketLifecycleAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketLifecycleAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, iamCommonKeys...)...),
+
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+			condition.S3RequestObjectTag,
+		}, iamCommonKeys...)...),
+
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, iamCommonKeys...)...),
+
+	GetBucketObjectLockConfigurationAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketObjectLockConfigurationAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetObjectRetentionAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutObjectRetentionAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetObjectLegalHoldAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutObjectLegalHoldAction: condition.NewKeySet(iamCommonKeys...),
+
+	BypassGovernanceRetentionAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetBucketCORSAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketCORSAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetBucketEncryptionAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketEncryptionAction: condition.NewKeySet(iamCommonKeys...),
+
+	GetBucketReplicationAction: condition.NewKeySet(iamCommonKeys...),
+
+	PutBucketReplicationAction: condition.NewKeySet(iamCommonKeys...),
 }
@@ -50,6 +50,9 @@ func TestActionIsValid(t *testing.T) {
 		expectedResult bool
 	}{
 		{AbortMultipartUploadAction, true},
+		{HealAdminAction, true},
+		{AllAdminActions, true},
+		{Action("admin:foo"), false},
 		{Action("foo"), false},
 	}
 
@@ -0,0 +1,127 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iampolicy
+
+import (
+	"github.com/scriptburn/minio/pkg/policy/condition"
+)
+
+// Admin API actions, these are Minio extensions which are not
+// bound to any resource.
+const (
+	// HealAdminAction - allows heal command
+	HealAdminAction Action = "admin:Heal"
+
+	// ServerInfoAdminAction - allow listing server info, version,
+	// service status and performance info.
+	ServerInfoAdminAction = "admin:ServerInfo"
+
+	// ProfilingAdminAction - allows profiling
+	ProfilingAdminAction = "admin:Profiling"
+
+	// ServiceRestartAdminAction - allows restarting Minio service.
+	ServiceRestartAdminAction = "admin:ServiceRestart"
+
+	// ServiceStopAdminAction - allows stopping Minio service.
+	ServiceStopAdminAction = "admin:ServiceStop"
+
+	// ConfigUpdateAdminAction - allows reading and updating Minio config
+	ConfigUpdateAdminAction = "admin:ConfigUpdate"
+
+	// CreateUserAdminAction - allows creating Minio user
+	CreateUserAdminAction = "admin:CreateUser"
+
+	// DeleteUserAdminAction - allows deleting Minio user
+	DeleteUserAdminAction = "admin:DeleteUser"
+
+	// ListUsersAdminAction - allows listing users
+	ListUsersAdminAction = "admin:ListUsers"
+
+	// EnableUserAdminAction - allows enable user
+	EnableUserAdminAction = "admin:EnableUser"
+
+	// DisableUserAdminAction - allows disable user
+	DisableUserAdminAction = "admin:DisableUser"
+
+	// AddUserToGroupAdminAction - allow adding user to group
+	AddUserToGroupAdminAction = "admin:AddUserToGroup"
+
+	// RemoveUserFromGroupAdminAction - allow removing user from group
+	RemoveUserFromGroupAdminAction = "admin:RemoveUserFromGroup"
+
+	// GetGroupAdminAction - allow getting group info
+	GetGroupAdminAction = "admin:GetGroup"
+
+	// ListGroupsAdminAction - allow list groups
+	ListGroupsAdminAction = "admin:ListGroups"
+
+	// CreatePolicyAdminAction - allow create policy
+	CreatePolicyAdminAction = "admin:CreatePolicy"
+
+	// DeletePolicyAdminAction - allows deleting policy
+	DeletePolicyAdminAction = "admin:DeletePolicy"
+
+	// GetPolicyAdminAction - allows getting and listing policies
+	GetPolicyAdminAction = "admin:GetPolicy"
+
+	// AttachPolicyAdminAction - allows attaching a policy to a user or group
+	AttachPolicyAdminAction = "admin:AttachUserOrGroupPolicy"
+
+	// SetBucketQuotaAdminAction - allows setting and removing bucket quota
+	SetBucketQuotaAdminAction = "admin:SetBucketQuota"
+
+	// GetBucketQuotaAdminAction - allows getting bucket quota
+	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
+
+	// AllAdminActions - provides all admin permissions
+	AllAdminActions = "admin:*"
+)
+
+// List of all supported admin actions.
+var supportedAdminActions = map[Action]struct{}{
+	AllAdminActions:                {},
+	HealAdminAction:                {},
+	ServerInfoAdminAction:          {},
+	ProfilingAdminAction:           {},
+	ServiceRestartAdminAction:      {},
+	ServiceStopAdminAction:         {},
+	ConfigUpdateAdminAction:        {},
+	CreateUserAdminAction:          {},
+	DeleteUserAdminAction:          {},
+	ListUsersAdminAction:           {},
+	EnableUserAdminAction:          {},
+	DisableUserAdminAction:         {},
+	AddUserToGroupAdminAction:      {},
+	RemoveUserFromGroupAdminAction: {},
+	GetGroupAdminAction:            {},
+	ListGroupsAdminAction:          {},
+	CreatePolicyAdminAction:        {},
+	DeletePolicyAdminAction:        {},
+	GetPolicyAdminAction:           {},
+	AttachPolicyAdminAction:        {},
+	SetBucketQuotaAdminAction:      {},
+	GetBucketQuotaAdminAction:      {},
+}
+
+// isAdminAction - returns whether action is an admin action or not.
+func (action Action) isAdminAction() bool {
+	_, ok := supportedAdminActions[action]
+	return ok
+}
+
+// adminActionConditionKeys - condition keys supported for admin actions.
+var adminActionConditionKeys = condition.NewKeySet(iamCommonKeys...)
@@ -73,6 +73,24 @@ func (iamp Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// Merge - returns a policy with statements of both policies, merged
+// policy is evaluated with explicit deny of any statement overriding
+// allow of other statements.
+func (iamp Policy) Merge(input Policy) Policy {
+	mergedPolicy := Policy{
+		ID:      iamp.ID,
+		Version: iamp.Version,
+	}
+	if mergedPolicy.Version == "" {
+		mergedPolicy.Version = input.Version
+	}
+
+	mergedPolicy.Statements = append(mergedPolicy.Statements, iamp.Statements...)
+	mergedPolicy.Statements = append(mergedPolicy.Statements, input.Statements...)
+
+	return mergedPolicy
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (iamp Policy) IsEmpty() bool {
 	return len(iamp.Statements) == 0
@@ -213,6 +213,93 @@ func TestPolicyIsEmpty(t *testing.T) {
 	}
 }
 
+func TestPolicyMerge(t *testing.T) {
+	readPolicy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Allow,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("*", "")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	writePolicy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Allow,
+				NewActionSet(PutObjectAction),
+				NewResourceSet(NewResource("mybucket", "/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	denyPolicy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Deny,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/secret*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	getObjectActionArgs := Args{
+		AccountName: "Q3AM3UQ867SPQQA43P2F",
+		Action:      GetObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "myobject",
+	}
+
+	getSecretObjectActionArgs := Args{
+		AccountName: "Q3AM3UQ867SPQQA43P2F",
+		Action:      GetObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "secretobject",
+	}
+
+	putObjectActionArgs := Args{
+		AccountName: "Q3AM3UQ867SPQQA43P2F",
+		Action:      PutObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "myobject",
+	}
+
+	testCases := []struct {
+		policy         Policy
+		args           Args
+		expectedResult bool
+	}{
+		{readPolicy.Merge(writePolicy), getObjectActionArgs, true},
+		{readPolicy.Merge(writePolicy), putObjectActionArgs, true},
+		{readPolicy.Merge(writePolicy), getSecretObjectActionArgs, true},
+		{Policy{}.Merge(writePolicy), getObjectActionArgs, false},
+		// Explicit deny of any policy wins.
+		{readPolicy.Merge(denyPolicy), getObjectActionArgs, true},
+		{readPolicy.Merge(denyPolicy), getSecretObjectActionArgs, false},
+		{denyPolicy.Merge(readPolicy).Merge(writePolicy), getSecretObjectActionArgs, false},
+		{denyPolicy.Merge(readPolicy).Merge(writePolicy), putObjectActionArgs, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.policy.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	if merged := (Policy{}).Merge(readPolicy); merged.Version != DefaultVersion || len(merged.Statements) != 1 {
+		t.Fatalf("unexpected merged policy %v", merged)
+	}
+}
+
 func TestPolicyIsValid(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
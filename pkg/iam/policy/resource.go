@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/scriptburn/minio/pkg/policy/condition"
 	"github.com/scriptburn/minio/pkg/wildcard"
 )
 
@@ -46,12 +47,23 @@ func (r Resource) IsValid() bool {
 	return r.Pattern != ""
 }
 
-// Match - matches object name with resource pattern.
-func (r Resource) Match(resource string) bool {
-	if strings.HasPrefix(resource, r.Pattern) {
+// Match - matches object name with resource pattern, policy variables in
+// the pattern are substituted by given condition values. Variables which
+// can not be substituted match nothing, or any value if matchUnresolved
+// is set.
+func (r Resource) Match(resource string, conditionValues map[string][]string, matchUnresolved bool) bool {
+	pattern, ok := condition.SubstituteVariables(r.Pattern, conditionValues)
+	if !ok {
+		if !matchUnresolved {
+			return false
+		}
+		pattern = condition.WildcardVariables(r.Pattern, conditionValues)
+	}
+
+	if strings.HasPrefix(resource, pattern) {
 		return true
 	}
-	return wildcard.Match(r.Pattern, resource)
+	return wildcard.Match(pattern, resource)
 }
 
 // MarshalJSON - encodes Resource to JSON data.
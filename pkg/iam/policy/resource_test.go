@@ -124,7 +124,40 @@ func TestResourceMatch(t *testing.T) {
 	}
 
 	for i, testCase := range testCases {
-		result := testCase.resource.Match(testCase.objectName)
+		result := testCase.resource.Match(testCase.objectName, nil, false)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestResourceMatchPolicyVariables(t *testing.T) {
+	conditionValues := map[string][]string{
+		"username": {"alice"},
+		"jwt:sub":  {"2f8b2c1e"},
+	}
+
+	testCases := []struct {
+		resource        Resource
+		objectName      string
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		{NewResource("home", "${aws:username}/*"), "home/alice/myobject", conditionValues, true},
+		{NewResource("home", "${aws:username}/*"), "home/bob/myobject", conditionValues, false},
+		{NewResource("home", "${jwt:sub}/*"), "home/2f8b2c1e/myobject", conditionValues, true},
+		// Variables without values do not match anything.
+		{NewResource("home", "${jwt:email}/*"), "home/${jwt:email}/myobject", conditionValues, false},
+		{NewResource("home", "${aws:username}/*"), "home/alice/myobject", nil, false},
+		// Values with wildcard characters are not substituted.
+		{NewResource("home", "${aws:username}/*"), "home/bob/myobject", map[string][]string{"username": {"*"}}, false},
+		// Unknown variables are matched literally.
+		{NewResource("home", "${foo}/*"), "home/${foo}/myobject", conditionValues, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.resource.Match(testCase.objectName, testCase.conditionValues, false)
 
 		if result != testCase.expectedResult {
 			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
@@ -30,17 +30,32 @@ type Statement struct {
 	SID        policy.ID           `json:"Sid,omitempty"`
 	Effect     policy.Effect       `json:"Effect"`
 	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource"`
+	Resources  ResourceSet         `json:"Resource,omitempty"`
 	Conditions condition.Functions `json:"Condition,omitempty"`
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
+	// Policy variables which can not be substituted fail closed, they
+	// match any value in Deny statements and none in Allow statements.
+	isDeny := statement.Effect == policy.Deny
+	evaluateConditions := func() bool {
+		if statement.Conditions.HasUnresolvedVariables(args.ConditionValues) {
+			return isDeny
+		}
+		return statement.Conditions.Evaluate(args.ConditionValues)
+	}
+
 	check := func() bool {
 		if !statement.Actions.Match(args.Action) {
 			return false
 		}
 
+		// Admin actions are not bound to any resource.
+		if args.Action.isAdminAction() {
+			return evaluateConditions()
+		}
+
 		resource := args.BucketName
 		if args.ObjectName != "" {
 			if !strings.HasPrefix(args.ObjectName, "/") {
@@ -52,16 +67,27 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += "/"
 		}
 
-		if !statement.Resources.Match(resource) {
+		if !statement.Resources.Match(resource, args.ConditionValues, isDeny) {
 			return false
 		}
 
-		return statement.Conditions.Evaluate(args.ConditionValues)
+		return evaluateConditions()
 	}
 
 	return statement.Effect.IsAllowed(check())
 }
 
+// isAdmin - returns whether statement has any admin action.
+func (statement Statement) isAdmin() bool {
+	for action := range statement.Actions {
+		if action.isAdminAction() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isValid - checks whether statement is valid or not.
 func (statement Statement) isValid() error {
 	if !statement.Effect.IsValid() {
@@ -72,6 +98,26 @@ func (statement Statement) isValid() error {
 		return fmt.Errorf("Action must not be empty")
 	}
 
+	if statement.isAdmin() {
+		for action := range statement.Actions {
+			if !action.isAdminAction() {
+				return fmt.Errorf("action '%v' must not be mixed with admin actions", action)
+			}
+		}
+
+		if len(statement.Resources) != 0 {
+			return fmt.Errorf("Resource must be empty for admin actions")
+		}
+
+		keys := statement.Conditions.Keys()
+		keyDiff := keys.Difference(adminActionConditionKeys)
+		if !keyDiff.IsEmpty() {
+			return fmt.Errorf("unsupported condition keys '%v' used for admin actions", keyDiff)
+		}
+
+		return nil
+	}
+
 	if len(statement.Resources) == 0 {
 		return fmt.Errorf("Resource must not be empty")
 	}
@@ -162,6 +162,144 @@ func TestStatementIsAllowed(t *testing.T) {
 	}
 }
 
+func TestStatementIsAllowedPolicyVariables(t *testing.T) {
+	homeResources := NewResourceSet(NewResource("home", "${aws:username}/*"))
+	case1Statement := NewStatement(policy.Allow, NewActionSet(GetObjectAction), homeResources, condition.NewFunctions())
+	case2Statement := NewStatement(policy.Deny, NewActionSet(GetObjectAction), homeResources, condition.NewFunctions())
+
+	func1, err := condition.NewStringNotEqualsFunc(condition.AWSUserID, "${jwt:sub}")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	case3Statement := NewStatement(policy.Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("*", "")), condition.NewFunctions(func1))
+	case4Statement := NewStatement(policy.Deny, NewActionSet(GetObjectAction), NewResourceSet(NewResource("*", "")), condition.NewFunctions(func1))
+
+	aliceArgs := Args{
+		Action:          GetObjectAction,
+		BucketName:      "home",
+		ObjectName:      "alice/myobject",
+		ConditionValues: map[string][]string{"username": {"alice"}, "userid": {"alice"}, "jwt:sub": {"alice"}},
+	}
+	bobArgs := Args{
+		Action:          GetObjectAction,
+		BucketName:      "home",
+		ObjectName:      "alice/myobject",
+		ConditionValues: map[string][]string{"username": {"bob"}, "userid": {"bob"}, "jwt:sub": {"alice"}},
+	}
+	unresolvedArgs := Args{
+		Action:          GetObjectAction,
+		BucketName:      "home",
+		ObjectName:      "alice/myobject",
+		ConditionValues: map[string][]string{"userid": {"alice"}},
+	}
+	otherBucketArgs := Args{
+		Action:          GetObjectAction,
+		BucketName:      "mybucket",
+		ObjectName:      "myobject",
+		ConditionValues: map[string][]string{},
+	}
+
+	testCases := []struct {
+		statement      Statement
+		args           Args
+		expectedResult bool
+	}{
+		{case1Statement, aliceArgs, true},
+		{case1Statement, bobArgs, false},
+		{case1Statement, unresolvedArgs, false},
+
+		// Deny statements with unresolved variables deny.
+		{case2Statement, aliceArgs, false},
+		{case2Statement, bobArgs, true},
+		{case2Statement, unresolvedArgs, false},
+		{case2Statement, otherBucketArgs, true},
+
+		{case3Statement, aliceArgs, false},
+		{case3Statement, bobArgs, true},
+		{case3Statement, unresolvedArgs, false},
+
+		{case4Statement, aliceArgs, true},
+		{case4Statement, bobArgs, false},
+		{case4Statement, unresolvedArgs, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.statement.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestStatementIsAllowedAdminActions(t *testing.T) {
+	_, IPNet1, err := net.ParseCIDR("192.168.1.0/24")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func1, err := condition.NewIPAddressFunc(
+		condition.AWSSourceIP,
+		IPNet1,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case1Statement := NewStatement(
+		policy.Allow,
+		NewActionSet(ServerInfoAdminAction, HealAdminAction),
+		NewResourceSet(),
+		condition.NewFunctions(),
+	)
+
+	case2Statement := NewStatement(
+		policy.Allow,
+		NewActionSet(AllAdminActions),
+		NewResourceSet(),
+		condition.NewFunctions(func1),
+	)
+
+	case3Statement := NewStatement(
+		policy.Allow,
+		NewActionSet(AllActions),
+		NewResourceSet(NewResource("*", "")),
+		condition.NewFunctions(),
+	)
+
+	serverInfoArgs := Args{
+		AccountName:     "Q3AM3UQ867SPQQA43P2F",
+		Action:          ServerInfoAdminAction,
+		ConditionValues: map[string][]string{"SourceIp": {"192.168.1.10"}},
+	}
+
+	configUpdateArgs := Args{
+		AccountName:     "Q3AM3UQ867SPQQA43P2F",
+		Action:          ConfigUpdateAdminAction,
+		ConditionValues: map[string][]string{"SourceIp": {"10.1.10.1"}},
+	}
+
+	testCases := []struct {
+		statement      Statement
+		args           Args
+		expectedResult bool
+	}{
+		{case1Statement, serverInfoArgs, true},
+		{case1Statement, configUpdateArgs, false},
+		{case2Statement, serverInfoArgs, true},
+		{case2Statement, configUpdateArgs, false},
+		// s3:* does not allow admin actions.
+		{case3Statement, serverInfoArgs, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.statement.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestStatementIsValid(t *testing.T) {
 	_, IPNet1, err := net.ParseCIDR("192.168.1.0/24")
 	if err != nil {
@@ -233,6 +371,34 @@ func TestStatementIsValid(t *testing.T) {
 			NewResourceSet(NewResource("mybucket", "myobject*")),
 			condition.NewFunctions(func1),
 		), false},
+		// Admin actions without resource.
+		{NewStatement(
+			policy.Allow,
+			NewActionSet(ServerInfoAdminAction, HealAdminAction),
+			NewResourceSet(),
+			condition.NewFunctions(func1),
+		), false},
+		// Admin actions with resource error.
+		{NewStatement(
+			policy.Allow,
+			NewActionSet(ServerInfoAdminAction),
+			NewResourceSet(NewResource("*", "")),
+			condition.NewFunctions(),
+		), true},
+		// Admin actions mixed with S3 actions error.
+		{NewStatement(
+			policy.Allow,
+			NewActionSet(ServerInfoAdminAction, GetObjectAction),
+			NewResourceSet(NewResource("*", "")),
+			condition.NewFunctions(),
+		), true},
+		// Unsupported conditions for admin actions.
+		{NewStatement(
+			policy.Allow,
+			NewActionSet(AllAdminActions),
+			NewResourceSet(),
+			condition.NewFunctions(func2),
+		), true},
 	}
 
 	for i, testCase := range testCases {
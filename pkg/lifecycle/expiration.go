@@ -0,0 +1,127 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+var (
+	errLifecycleInvalidDate       = errors.New("Date must be provided in ISO 8601 format at midnight UTC")
+	errLifecycleInvalidDays       = errors.New("Days must be a positive integer")
+	errLifecycleInvalidExpiration = errors.New("Exactly one of Days or Date must be specified in Expiration")
+)
+
+// ExpirationDate - date at which objects expire, always at midnight UTC.
+type ExpirationDate struct {
+	time.Time
+}
+
+// UnmarshalXML - decodes an ISO 8601 date which has to be at midnight UTC.
+func (eDate *ExpirationDate) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var dateStr string
+	if err := d.DecodeElement(&dateStr, &startElement); err != nil {
+		return err
+	}
+
+	// AWS S3 accepts both a full timestamp and a plain date.
+	expDate, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		if expDate, err = time.Parse("2006-01-02", dateStr); err != nil {
+			return errLifecycleInvalidDate
+		}
+	}
+
+	// Allow only date timestamp specifying midnight GMT.
+	hr, min, sec := expDate.Clock()
+	nsec := expDate.Nanosecond()
+	loc := expDate.Location()
+	if !(hr == 0 && min == 0 && sec == 0 && nsec == 0 && loc.String() == time.UTC.String()) {
+		return errLifecycleInvalidDate
+	}
+
+	*eDate = ExpirationDate{expDate}
+	return nil
+}
+
+// MarshalXML - encodes the date in ISO 8601 format, a zero date is omitted.
+func (eDate ExpirationDate) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if eDate.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(eDate.Format(time.RFC3339), startElement)
+}
+
+// Expiration - expiration action of a rule.
+type Expiration struct {
+	XMLName xml.Name       `xml:"Expiration"`
+	Days    int            `xml:"Days,omitempty"`
+	Date    ExpirationDate `xml:"Date,omitempty"`
+}
+
+// Validate - validates the expiration action.
+func (e Expiration) Validate() error {
+	if e.Days < 0 {
+		return errLifecycleInvalidDays
+	}
+
+	// Exactly one of Days or Date has to be present.
+	if (e.Days == 0) == e.Date.IsZero() {
+		return errLifecycleInvalidExpiration
+	}
+
+	return nil
+}
+
+// IsExpired - returns true if an object modified at modTime has
+// expired at the given time.
+func (e Expiration) IsExpired(modTime, now time.Time) bool {
+	if !e.Date.IsZero() {
+		return !now.Before(e.Date.Time)
+	}
+	return !now.Before(expectedExpiryTime(modTime, e.Days))
+}
+
+// AbortIncompleteMultipartUpload - abort action for incomplete
+// multipart uploads of a rule.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation"`
+}
+
+// Validate - validates the abort incomplete multipart upload action.
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if a.DaysAfterInitiation <= 0 {
+		return errLifecycleInvalidDays
+	}
+	return nil
+}
+
+// IsExpired - returns true if a multipart upload initiated at the given
+// time has to be aborted at now.
+func (a AbortIncompleteMultipartUpload) IsExpired(initiated, now time.Time) bool {
+	return !now.Before(expectedExpiryTime(initiated, a.DaysAfterInitiation))
+}
+
+// expectedExpiryTime - as per AWS S3, the expiry time is the midnight UTC
+// following the given number of days after the given time.
+func expectedExpiryTime(modTime time.Time, days int) time.Time {
+	t := modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour)
+	return t.Truncate(24 * time.Hour)
+}
@@ -0,0 +1,124 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"time"
+)
+
+// Maximum number of rules allowed in a lifecycle configuration as per
+// AWS S3 specification.
+const maxRules = 1000
+
+var (
+	errLifecycleNoRule       = errors.New("Lifecycle configuration should have at least one rule")
+	errLifecycleTooManyRules = errors.New("Lifecycle configuration allows a maximum of 1000 rules")
+	errLifecycleDuplicateID  = errors.New("Lifecycle configuration has rule with the same ID. Rule ID must be unique")
+)
+
+// Action - action to be taken on an object.
+type Action int
+
+const (
+	// NoneAction - no action needs to be taken.
+	NoneAction Action = iota
+	// DeleteAction - the object needs to be deleted.
+	DeleteAction
+)
+
+// Lifecycle - represents <LifecycleConfiguration>...</LifecycleConfiguration>
+type Lifecycle struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"LifecycleConfiguration"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the lifecycle configuration.
+func (lc Lifecycle) Validate() error {
+	if len(lc.Rules) == 0 {
+		return errLifecycleNoRule
+	}
+	if len(lc.Rules) > maxRules {
+		return errLifecycleTooManyRules
+	}
+
+	ids := make(map[string]struct{}, len(lc.Rules))
+	for _, r := range lc.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if r.ID == "" {
+			continue
+		}
+		if _, ok := ids[r.ID]; ok {
+			return errLifecycleDuplicateID
+		}
+		ids[r.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+// FilterRules - returns the enabled rules which apply to the given object name.
+func (lc Lifecycle) FilterRules(objName string) []Rule {
+	var rules []Rule
+	for _, r := range lc.Rules {
+		if r.Match(objName) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// ComputeAction - returns the action to be taken on an object with the
+// given name and modification time at now.
+func (lc Lifecycle) ComputeAction(objName string, modTime, now time.Time) Action {
+	for _, r := range lc.FilterRules(objName) {
+		if r.Expiration != nil && r.Expiration.IsExpired(modTime, now) {
+			return DeleteAction
+		}
+	}
+	return NoneAction
+}
+
+// ComputeUploadAction - returns the action to be taken on an incomplete
+// multipart upload of the given object name initiated at the given time.
+func (lc Lifecycle) ComputeUploadAction(objName string, initiated, now time.Time) Action {
+	for _, r := range lc.FilterRules(objName) {
+		if r.AbortIncompleteMultipartUpload != nil && r.AbortIncompleteMultipartUpload.IsExpired(initiated, now) {
+			return DeleteAction
+		}
+	}
+	return NoneAction
+}
+
+// ParseLifecycleConfig - parses data in given reader to Lifecycle.
+func ParseLifecycleConfig(reader io.Reader) (*Lifecycle, error) {
+	var lc Lifecycle
+	if err := xml.NewDecoder(reader).Decode(&lc); err != nil {
+		return nil, err
+	}
+
+	if err := lc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &lc, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLifecycleConfig(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		// Expiration in days with a filter prefix.
+		{`<LifecycleConfiguration><Rule><ID>logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`, false},
+		// Expiration at a date with a legacy rule prefix.
+		{`<LifecycleConfiguration><Rule><Prefix>tmp/</Prefix><Status>Disabled</Status><Expiration><Date>2019-04-20T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`, false},
+		// Abort incomplete multipart uploads only.
+		{`<LifecycleConfiguration><Rule><Filter><Prefix></Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`, false},
+		// No rules.
+		{`<LifecycleConfiguration></LifecycleConfiguration>`, true},
+		// No action.
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status></Rule></LifecycleConfiguration>`, true},
+		// Invalid status.
+		{`<LifecycleConfiguration><Rule><Status>enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Both days and date.
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Days>1</Days><Date>2019-04-20T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Negative days.
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Days>-1</Days></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Date not at midnight.
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Date>2019-04-20T10:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Duplicate rule IDs.
+		{`<LifecycleConfiguration><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>2</Days></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Prefix both in filter and rule.
+		{`<LifecycleConfiguration><Rule><Prefix>a</Prefix><Filter><Prefix>a</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Tag filters are not supported.
+		{`<LifecycleConfiguration><Rule><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, true},
+		// Transitions are not supported.
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>GLACIER</StorageClass></Transition></Rule></LifecycleConfiguration>`, true},
+		// Malformed XML.
+		{`<LifecycleConfiguration><Rule>`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseLifecycleConfig(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestMarshalLifecycleConfig(t *testing.T) {
+	data := `<LifecycleConfiguration><Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Date>2019-04-20T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`
+	lc, err := ParseLifecycleConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshaled, err := xml.Marshal(lc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(marshaled, []byte(data)) {
+		t.Fatalf("expected: %s, got: %s", data, marshaled)
+	}
+}
+
+func TestComputeAction(t *testing.T) {
+	modTime := time.Date(2019, time.April, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		data           string
+		objName        string
+		now            time.Time
+		expectedAction Action
+	}{
+		// Expires at midnight after the 30th day.
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`,
+			"logs/a", time.Date(2019, time.May, 2, 0, 0, 0, 0, time.UTC), DeleteAction},
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`,
+			"logs/a", time.Date(2019, time.May, 1, 23, 59, 0, 0, time.UTC), NoneAction},
+		// Prefix does not match.
+		{`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			"data/a", time.Date(2020, time.May, 2, 0, 0, 0, 0, time.UTC), NoneAction},
+		// Disabled rule.
+		{`<LifecycleConfiguration><Rule><Status>Disabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			"logs/a", time.Date(2020, time.May, 2, 0, 0, 0, 0, time.UTC), NoneAction},
+		// Expiration date.
+		{`<LifecycleConfiguration><Rule><Prefix></Prefix><Status>Enabled</Status><Expiration><Date>2019-04-20T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`,
+			"a", time.Date(2019, time.April, 20, 0, 0, 0, 0, time.UTC), DeleteAction},
+		{`<LifecycleConfiguration><Rule><Prefix></Prefix><Status>Enabled</Status><Expiration><Date>2019-04-20T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`,
+			"a", time.Date(2019, time.April, 19, 0, 0, 0, 0, time.UTC), NoneAction},
+	}
+
+	for i, testCase := range testCases {
+		lc, err := ParseLifecycleConfig(strings.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("test %v: unexpected error: %v", i+1, err)
+		}
+		if action := lc.ComputeAction(testCase.objName, modTime, testCase.now); action != testCase.expectedAction {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedAction, action)
+		}
+	}
+}
+
+func TestComputeUploadAction(t *testing.T) {
+	data := `<LifecycleConfiguration><Rule><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`
+	lc, err := ParseLifecycleConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiated := time.Date(2019, time.April, 1, 10, 0, 0, 0, time.UTC)
+	if action := lc.ComputeUploadAction("uploads/a", initiated, time.Date(2019, time.April, 9, 0, 0, 0, 0, time.UTC)); action != DeleteAction {
+		t.Fatalf("expected upload to be aborted, got: %v", action)
+	}
+	if action := lc.ComputeUploadAction("uploads/a", initiated, time.Date(2019, time.April, 8, 0, 0, 0, 0, time.UTC)); action != NoneAction {
+		t.Fatalf("expected upload to be kept, got: %v", action)
+	}
+	// Expiration rules do not apply to incomplete uploads.
+	if action := lc.ComputeAction("uploads/a", initiated, time.Date(2020, time.April, 9, 0, 0, 0, 0, time.UTC)); action != NoneAction {
+		t.Fatalf("expected object to be kept, got: %v", action)
+	}
+}
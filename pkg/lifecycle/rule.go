@@ -0,0 +1,150 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+// Status - status of a lifecycle rule.
+type Status string
+
+// Supported rule statuses.
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// Maximum length of a rule ID as per AWS S3 specification.
+const maxRuleIDLength = 255
+
+var (
+	errInvalidRuleID         = errors.New("ID must be less than 255 characters")
+	errInvalidRuleStatus     = errors.New("Status must be set to either Enabled or Disabled")
+	errMissingRuleAction     = errors.New("At least one action needs to be specified in a rule")
+	errAmbiguousRulePrefix   = errors.New("Prefix must be specified either in Filter or in Rule, not both")
+	errTagFilterUnsupported  = errors.New("Tag based filters are not supported")
+	errTransitionUnsupported = errors.New("Transition actions are not supported")
+	errNoncurrentUnsupported = errors.New("Noncurrent version actions are not supported")
+)
+
+// unsupportedElement - element which is only parsed to be rejected
+// during validation.
+type unsupportedElement struct {
+	XMLName xml.Name
+}
+
+// Filter - prefix filter of a rule.
+type Filter struct {
+	XMLName xml.Name            `xml:"Filter"`
+	Prefix  string              `xml:"Prefix"`
+	And     *unsupportedElement `xml:"And"`
+	Tag     *unsupportedElement `xml:"Tag"`
+}
+
+// Validate - validates the filter, only prefix filters are supported.
+func (f Filter) Validate() error {
+	if f.And != nil || f.Tag != nil {
+		return errTagFilterUnsupported
+	}
+	return nil
+}
+
+// Rule - a lifecycle rule.
+type Rule struct {
+	XMLName    xml.Name    `xml:"Rule"`
+	ID         string      `xml:"ID,omitempty"`
+	Status     Status      `xml:"Status"`
+	Filter     *Filter     `xml:"Filter,omitempty"`
+	Prefix     *string     `xml:"Prefix,omitempty"`
+	Expiration *Expiration `xml:"Expiration,omitempty"`
+
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+
+	// Unsupported actions, rejected during validation.
+	Transition                  *unsupportedElement `xml:"Transition"`
+	NoncurrentVersionExpiration *unsupportedElement `xml:"NoncurrentVersionExpiration"`
+	NoncurrentVersionTransition *unsupportedElement `xml:"NoncurrentVersionTransition"`
+}
+
+// Validate - validates the rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > maxRuleIDLength {
+		return errInvalidRuleID
+	}
+
+	switch r.Status {
+	case Enabled, Disabled:
+	default:
+		return errInvalidRuleStatus
+	}
+
+	if r.Filter != nil {
+		if r.Prefix != nil {
+			return errAmbiguousRulePrefix
+		}
+		if err := r.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.Transition != nil || r.NoncurrentVersionTransition != nil {
+		return errTransitionUnsupported
+	}
+
+	if r.NoncurrentVersionExpiration != nil {
+		return errNoncurrentUnsupported
+	}
+
+	if r.Expiration == nil && r.AbortIncompleteMultipartUpload == nil {
+		return errMissingRuleAction
+	}
+
+	if r.Expiration != nil {
+		if err := r.Expiration.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.AbortIncompleteMultipartUpload != nil {
+		if err := r.AbortIncompleteMultipartUpload.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetPrefix - returns the prefix the rule applies to, either from the
+// filter or from the legacy rule level prefix.
+func (r Rule) GetPrefix() string {
+	if r.Filter != nil {
+		return r.Filter.Prefix
+	}
+	if r.Prefix != nil {
+		return *r.Prefix
+	}
+	return ""
+}
+
+// Match - returns true if the rule is enabled and applies to the given
+// object name.
+func (r Rule) Match(objName string) bool {
+	return r.Status == Enabled && strings.HasPrefix(objName, r.GetPrefix())
+}
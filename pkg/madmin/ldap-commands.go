@@ -0,0 +1,86 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SetLDAPPolicy - maps canned policies to an LDAP user or group DN,
+// replacing the previously mapped policies. No policy names remove
+// the mapping.
+func (adm *AdminClient) SetLDAPPolicy(dn string, policyNames ...string) error {
+	queryValues := url.Values{}
+	queryValues.Set("dn", dn)
+	queryValues.Set("name", strings.Join(policyNames, ","))
+
+	reqData := requestData{
+		relPath:     "/v1/set-ldap-policy",
+		queryValues: queryValues,
+	}
+
+	// Execute PUT on /minio/admin/v1/set-ldap-policy to set policies.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListLDAPPolicies - lists canned policies mapped to LDAP user and
+// group DNs, DNs are returned in normalized form.
+func (adm *AdminClient) ListLDAPPolicies() (map[string][]string, error) {
+	reqData := requestData{
+		relPath: "/v1/list-ldap-policies",
+	}
+
+	// Execute GET on /minio/admin/v1/list-ldap-policies
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ldapPolicies := map[string][]string{}
+	if err = json.Unmarshal(respBytes, &ldapPolicies); err != nil {
+		return nil, err
+	}
+
+	return ldapPolicies, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// NotificationTarget - notification target configured in the server.
+type NotificationTarget struct {
+	// Type of the target, e.g. "webhook" or "kafka".
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	ARN  string `json:"arn"`
+
+	Enabled bool `json:"enabled"`
+
+	// Online is set if the target is loaded in all servers and events
+	// are not failing to reach it.
+	Online bool `json:"online"`
+}
+
+// ListNotificationTargets - lists notification targets configured in the server.
+func (adm *AdminClient) ListNotificationTargets() ([]NotificationTarget, error) {
+	reqData := requestData{
+		relPath: "/v1/list-notification-targets",
+	}
+
+	// Execute GET on /minio/admin/v1/list-notification-targets
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []NotificationTarget
+	if err = json.Unmarshal(respBytes, &targets); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// SetNotificationTarget - adds or updates notification target of type and
+// ID, config is the JSON configuration of the target as in the notify
+// section of the server config, e.g. {"enable":true,"endpoint":"..."} for
+// a webhook target.
+func (adm *AdminClient) SetNotificationTarget(targetType, id string, config []byte) error {
+	econfigBytes, err := EncryptData(adm.secretAccessKey, config)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("type", targetType)
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     "/v1/set-notification-target",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v1/set-notification-target to add or update target.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// TestNotificationTarget - sends a test event to notification target of
+// type and ID, returns error if the event can not be sent.
+func (adm *AdminClient) TestNotificationTarget(targetType, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("type", targetType)
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     "/v1/test-notification-target",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v1/test-notification-target to send test event.
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RemoveNotificationTarget - removes notification target of type and ID.
+func (adm *AdminClient) RemoveNotificationTarget(targetType, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("type", targetType)
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     "/v1/remove-notification-target",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/remove-notification-target to remove target.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
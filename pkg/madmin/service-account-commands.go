@@ -0,0 +1,159 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/scriptburn/minio/pkg/auth"
+)
+
+// AddServiceAccountReq is the request body of adding a service account.
+type AddServiceAccountReq struct {
+	// Parent user of the service account, only admin credentials
+	// may add service accounts of other users.
+	Parent string `json:"parent,omitempty"`
+	// Optional session policy restricting permissions inherited
+	// from the parent user.
+	Policy json.RawMessage `json:"policy,omitempty"`
+}
+
+// AddServiceAccountResp is the response body of adding a service account.
+type AddServiceAccountResp struct {
+	Credentials auth.Credentials `json:"credentials"`
+}
+
+// ListServiceAccountsResp is the response body of listing service accounts.
+type ListServiceAccountsResp struct {
+	Accounts []string `json:"accounts"`
+}
+
+// AddServiceAccount - creates a service account with new credentials
+// derived from the parent user. Parent may be empty when the client
+// uses credentials of the parent user.
+func (adm *AdminClient) AddServiceAccount(parent string, policy string) (auth.Credentials, error) {
+	serviceAccountReq := AddServiceAccountReq{Parent: parent}
+	if policy != "" {
+		serviceAccountReq.Policy = json.RawMessage(policy)
+	}
+
+	data, err := json.Marshal(serviceAccountReq)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	reqData := requestData{
+		relPath: "/v1/add-service-account",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v1/add-service-account to add a service account.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return auth.Credentials{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err = DecryptData(adm.secretAccessKey, resp.Body)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	var serviceAccountResp AddServiceAccountResp
+	if err = json.Unmarshal(data, &serviceAccountResp); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	return serviceAccountResp.Credentials, nil
+}
+
+// ListServiceAccounts - lists access keys of service accounts of a
+// user. User may be empty to list service accounts of the client.
+func (adm *AdminClient) ListServiceAccounts(user string) ([]string, error) {
+	queryValues := url.Values{}
+	if user != "" {
+		queryValues.Set("user", user)
+	}
+
+	reqData := requestData{
+		relPath:     "/v1/list-service-accounts",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/list-service-accounts
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResp ListServiceAccountsResp
+	if err = json.Unmarshal(respBytes, &listResp); err != nil {
+		return nil, err
+	}
+
+	return listResp.Accounts, nil
+}
+
+// DeleteServiceAccount - deletes a service account.
+func (adm *AdminClient) DeleteServiceAccount(serviceAccount string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", serviceAccount)
+
+	reqData := requestData{
+		relPath:     "/v1/delete-service-account",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/delete-service-account to delete a service account.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
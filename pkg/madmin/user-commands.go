@@ -19,8 +19,10 @@ package madmin
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/scriptburn/minio/pkg/auth"
 )
@@ -39,6 +41,9 @@ type UserInfo struct {
 	SecretKey  string        `json:"secretKey,omitempty"`
 	PolicyName string        `json:"policyName,omitempty"`
 	Status     AccountStatus `json:"status"`
+	MemberOf   []string      `json:"memberOf,omitempty"`
+
+	HasInlinePolicy bool `json:"hasInlinePolicy,omitempty"`
 }
 
 // RemoveUser - remove a user.
@@ -149,11 +154,12 @@ func (adm *AdminClient) AddUser(accessKey, secretKey string) error {
 	return adm.SetUser(accessKey, secretKey, AccountEnabled)
 }
 
-// SetUserPolicy - adds a policy for a user.
-func (adm *AdminClient) SetUserPolicy(accessKey, policyName string) error {
+// SetUserPolicy - sets canned policies for a user, replacing the
+// policies set before. No policy names remove all policies of the user.
+func (adm *AdminClient) SetUserPolicy(accessKey string, policyNames ...string) error {
 	queryValues := url.Values{}
 	queryValues.Set("accessKey", accessKey)
-	queryValues.Set("name", policyName)
+	queryValues.Set("name", strings.Join(policyNames, ","))
 
 	reqData := requestData{
 		relPath:     "/v1/set-user-policy",
@@ -200,3 +206,80 @@ func (adm *AdminClient) SetUserStatus(accessKey string, status AccountStatus) er
 
 	return nil
 }
+
+// SetUserInlinePolicy - sets an inline policy document for a user, which
+// is evaluated along with canned policies of the user.
+func (adm *AdminClient) SetUserInlinePolicy(accessKey, policy string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	reqData := requestData{
+		relPath:     "/v1/set-user-inline-policy",
+		queryValues: queryValues,
+		content:     []byte(policy),
+	}
+
+	// Execute PUT on /minio/admin/v1/set-user-inline-policy to set inline policy.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetUserInlinePolicy - gets the inline policy document of a user.
+func (adm *AdminClient) GetUserInlinePolicy(accessKey string) ([]byte, error) {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	reqData := requestData{
+		relPath:     "/v1/user-inline-policy",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/user-inline-policy
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// RemoveUserInlinePolicy - removes the inline policy document of a user.
+func (adm *AdminClient) RemoveUserInlinePolicy(accessKey string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	reqData := requestData{
+		relPath:     "/v1/remove-user-inline-policy",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/remove-user-inline-policy to remove inline policy.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"time"
+)
+
+// Mode - object retention mode.
+type Mode string
+
+// Supported retention modes.
+const (
+	// Governance - retention can be shortened or removed by users with
+	// the s3:BypassGovernanceRetention permission.
+	Governance Mode = "GOVERNANCE"
+
+	// Compliance - retention cannot be shortened or removed by any user
+	// until it expires.
+	Compliance Mode = "COMPLIANCE"
+)
+
+// IsValid - returns true if the retention mode is supported.
+func (mode Mode) IsValid() bool {
+	switch mode {
+	case Governance, Compliance:
+		return true
+	}
+	return false
+}
+
+// LegalHoldStatus - object legal hold status.
+type LegalHoldStatus string
+
+// Supported legal hold states.
+const (
+	LegalHoldOn  LegalHoldStatus = "ON"
+	LegalHoldOff LegalHoldStatus = "OFF"
+)
+
+// IsValid - returns true if the legal hold status is supported.
+func (status LegalHoldStatus) IsValid() bool {
+	switch status {
+	case LegalHoldOn, LegalHoldOff:
+		return true
+	}
+	return false
+}
+
+// Enabled - the only valid value of ObjectLockEnabled, object lock
+// cannot be disabled once enabled on a bucket.
+const Enabled = "Enabled"
+
+var (
+	errInvalidObjectLockEnabled = errors.New("ObjectLockEnabled must be Enabled")
+	errInvalidMode              = errors.New("invalid retention mode, must be one of GOVERNANCE or COMPLIANCE")
+	errInvalidPeriod            = errors.New("exactly one of Days or Years must be specified as a positive integer in DefaultRetention")
+	errInvalidRetainUntilDate   = errors.New("RetainUntilDate must be provided with Mode in ISO 8601 format")
+	errInvalidLegalHoldStatus   = errors.New("invalid legal hold status, must be one of ON or OFF")
+)
+
+// DefaultRetention - represents <DefaultRetention>...</DefaultRetention>
+type DefaultRetention struct {
+	XMLName xml.Name `xml:"DefaultRetention"`
+	Mode    Mode     `xml:"Mode"`
+	Days    int      `xml:"Days,omitempty"`
+	Years   int      `xml:"Years,omitempty"`
+}
+
+// Validate - validates the default retention.
+func (dr DefaultRetention) Validate() error {
+	if !dr.Mode.IsValid() {
+		return errInvalidMode
+	}
+
+	if dr.Days < 0 || dr.Years < 0 || (dr.Days == 0) == (dr.Years == 0) {
+		return errInvalidPeriod
+	}
+
+	return nil
+}
+
+// RetainUntil - returns the date until which an object created at the
+// given time is retained.
+func (dr DefaultRetention) RetainUntil(created time.Time) time.Time {
+	if dr.Years != 0 {
+		return created.AddDate(dr.Years, 0, 0)
+	}
+	return created.AddDate(0, 0, dr.Days)
+}
+
+// Rule - represents <Rule>...</Rule>
+type Rule struct {
+	XMLName          xml.Name         `xml:"Rule"`
+	DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+}
+
+// Config - represents <ObjectLockConfiguration>...</ObjectLockConfiguration>
+type Config struct {
+	XMLNS             string   `xml:"xmlns,attr,omitempty"`
+	XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string   `xml:"ObjectLockEnabled"`
+	Rule              *Rule    `xml:"Rule,omitempty"`
+}
+
+// Validate - validates the object lock configuration.
+func (config Config) Validate() error {
+	if config.ObjectLockEnabled != Enabled {
+		return errInvalidObjectLockEnabled
+	}
+
+	if config.Rule != nil {
+		return config.Rule.DefaultRetention.Validate()
+	}
+
+	return nil
+}
+
+// DefaultRetention - returns the default retention applied to new
+// objects, ok is false if the configuration has no rule.
+func (config Config) DefaultRetention() (dr DefaultRetention, ok bool) {
+	if config.Rule == nil {
+		return dr, false
+	}
+	return config.Rule.DefaultRetention, true
+}
+
+// ParseObjectLockConfig - parses data in given reader to Config.
+func ParseObjectLockConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Retention - represents <Retention>...</Retention>
+type Retention struct {
+	XMLNS           string    `xml:"xmlns,attr,omitempty"`
+	XMLName         xml.Name  `xml:"Retention"`
+	Mode            Mode      `xml:"Mode,omitempty"`
+	RetainUntilDate time.Time `xml:"RetainUntilDate,omitempty"`
+}
+
+// Validate - validates the retention, an empty retention removes the
+// retention of an object.
+func (r Retention) Validate() error {
+	if r.Mode == "" && r.RetainUntilDate.IsZero() {
+		return nil
+	}
+
+	if !r.Mode.IsValid() {
+		return errInvalidMode
+	}
+
+	if r.RetainUntilDate.IsZero() {
+		return errInvalidRetainUntilDate
+	}
+
+	return nil
+}
+
+// IsActive - returns true if the retention still protects the object
+// at the given time.
+func (r Retention) IsActive(now time.Time) bool {
+	return r.Mode.IsValid() && now.Before(r.RetainUntilDate)
+}
+
+// ParseObjectRetention - parses data in given reader to Retention.
+func ParseObjectRetention(reader io.Reader) (*Retention, error) {
+	var r Retention
+	if err := xml.NewDecoder(reader).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// LegalHold - represents <LegalHold>...</LegalHold>
+type LegalHold struct {
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name        `xml:"LegalHold"`
+	Status  LegalHoldStatus `xml:"Status"`
+}
+
+// ParseObjectLegalHold - parses data in given reader to LegalHold.
+func ParseObjectLegalHold(reader io.Reader) (*LegalHold, error) {
+	var lh LegalHold
+	if err := xml.NewDecoder(reader).Decode(&lh); err != nil {
+		return nil, err
+	}
+
+	if !lh.Status.IsValid() {
+		return nil, errInvalidLegalHoldStatus
+	}
+
+	return &lh, nil
+}
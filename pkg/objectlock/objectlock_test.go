@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseObjectLockConfig(t *testing.T) {
+	testCases := []struct {
+		data             string
+		expectedRule     bool
+		expectedRetainTo time.Time
+		expectErr        bool
+	}{
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`, false, time.Time{}, false},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			true, time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC), false},
+		{`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			true, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Disabled</ObjectLockEnabled></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>-1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>LOCKED</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, false, time.Time{}, true},
+		{`<ObjectLockConfiguration>`, false, time.Time{}, true},
+	}
+
+	created := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, testCase := range testCases {
+		config, err := ParseObjectLockConfig(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if testCase.expectErr {
+			continue
+		}
+
+		dr, ok := config.DefaultRetention()
+		if ok != testCase.expectedRule {
+			t.Fatalf("test %v: rule: expected: %v, got: %v", i+1, testCase.expectedRule, ok)
+		}
+		if ok {
+			if retainTo := dr.RetainUntil(created); !retainTo.Equal(testCase.expectedRetainTo) {
+				t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedRetainTo, retainTo)
+			}
+		}
+	}
+}
+
+func TestParseObjectRetention(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		data           string
+		expectedActive bool
+		expectErr      bool
+	}{
+		{`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>2019-02-01T00:00:00Z</RetainUntilDate></Retention>`, true, false},
+		{`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>2018-12-01T00:00:00Z</RetainUntilDate></Retention>`, false, false},
+		{`<Retention></Retention>`, false, false},
+		{`<Retention><Mode>GOVERNANCE</Mode></Retention>`, false, true},
+		{`<Retention><RetainUntilDate>2019-02-01T00:00:00Z</RetainUntilDate></Retention>`, false, true},
+		{`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>tomorrow</RetainUntilDate></Retention>`, false, true},
+	}
+
+	for i, testCase := range testCases {
+		r, err := ParseObjectRetention(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if active := r.IsActive(now); active != testCase.expectedActive {
+				t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedActive, active)
+			}
+		}
+	}
+}
+
+func TestParseObjectLegalHold(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedStatus LegalHoldStatus
+		expectErr      bool
+	}{
+		{`<LegalHold><Status>ON</Status></LegalHold>`, LegalHoldOn, false},
+		{`<LegalHold xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>OFF</Status></LegalHold>`, LegalHoldOff, false},
+		{`<LegalHold><Status>on</Status></LegalHold>`, "", true},
+		{`<LegalHold></LegalHold>`, "", true},
+	}
+
+	for i, testCase := range testCases {
+		lh, err := ParseObjectLegalHold(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr && lh.Status != testCase.expectedStatus {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedStatus, lh.Status)
+		}
+	}
+}
@@ -83,6 +83,76 @@ const (
 
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
+
+	// DeleteObjectVersionAction - DeleteObjectVersion Rest API action.
+	DeleteObjectVersionAction = "s3:DeleteObjectVersion"
+
+	// GetBucketVersioningAction - GetBucketVersioning Rest API action.
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// GetObjectVersionAction - GetObject Rest API action on a specific object version.
+	GetObjectVersionAction = "s3:GetObjectVersion"
+
+	// ListBucketVersionsAction - ListObjectVersions Rest API action.
+	ListBucketVersionsAction = "s3:ListBucketVersions"
+
+	// PutBucketVersioningAction - PutBucketVersioning Rest API action.
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+
+	// GetBucketLifecycleAction - GetBucketLifecycle Rest API action.
+	GetBucketLifecycleAction = "s3:GetLifecycleConfiguration"
+
+	// PutBucketLifecycleAction - PutBucketLifecycle and DeleteBucketLifecycle Rest API action.
+	PutBucketLifecycleAction = "s3:PutLifecycleConfiguration"
+
+	// GetObjectTaggingAction - GetObjectTagging Rest API action.
+	GetObjectTaggingAction = "s3:GetObjectTagging"
+
+	// PutObjectTaggingAction - PutObjectTagging Rest API action.
+	PutObjectTaggingAction = "s3:PutObjectTagging"
+
+	// DeleteObjectTaggingAction - DeleteObjectTagging Rest API action.
+	DeleteObjectTaggingAction = "s3:DeleteObjectTagging"
+
+	// GetBucketObjectLockConfigurationAction - GetBucketObjectLockConfiguration Rest API action.
+	GetBucketObjectLockConfigurationAction = "s3:GetBucketObjectLockConfiguration"
+
+	// PutBucketObjectLockConfigurationAction - PutBucketObjectLockConfiguration Rest API action.
+	PutBucketObjectLockConfigurationAction = "s3:PutBucketObjectLockConfiguration"
+
+	// GetObjectRetentionAction - GetObjectRetention Rest API action.
+	GetObjectRetentionAction = "s3:GetObjectRetention"
+
+	// PutObjectRetentionAction - PutObjectRetention Rest API action.
+	PutObjectRetentionAction = "s3:PutObjectRetention"
+
+	// GetObjectLegalHoldAction - GetObjectLegalHold Rest API action.
+	GetObjectLegalHoldAction = "s3:GetObjectLegalHold"
+
+	// PutObjectLegalHoldAction - PutObjectLegalHold Rest API action.
+	PutObjectLegalHoldAction = "s3:PutObjectLegalHold"
+
+	// BypassGovernanceRetentionAction - allows deleting, overwriting or
+	// shortening the retention of objects in GOVERNANCE mode.
+	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
+
+	// GetBucketCORSAction - GetBucketCors Rest API action.
+	GetBucketCORSAction = "s3:GetBucketCORS"
+
+	// PutBucketCORSAction - PutBucketCors and DeleteBucketCors Rest API action.
+	PutBucketCORSAction = "s3:PutBucketCORS"
+
+	// GetBucketEncryptionAction - GetBucketEncryption Rest API action.
+	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
+	// PutBucketEncryptionAction - PutBucketEncryption and DeleteBucketEncryption Rest API action.
+	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
+
+	// GetBucketReplicationAction - GetBucketReplication Rest API action.
+	GetBucketReplicationAction = "s3:GetReplicationConfiguration"
+
+	// PutBucketReplicationAction - PutBucketReplication and DeleteBucketReplication Rest API action.
+	PutBucketReplicationAction = "s3:PutReplicationConfiguration"
 )
 
 // isObjectAction - returns whether action is object type or not.
@@ -91,6 +161,14 @@ func (action Action) isObjectAction() bool {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
 	case ListMultipartUploadPartsAction, PutObjectAction:
+		fallthrough
+	case DeleteObjectVersionAction, GetObjectVersionAction:
+		fallthrough
+	case GetObjectTaggingAction, PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case GetObjectRetentionAction, PutObjectRetentionAction, BypassGovernanceRetentionAction:
+		fallthrough
+	case GetObjectLegalHoldAction, PutObjectLegalHoldAction:
 		return true
 	}
 
@@ -113,6 +191,26 @@ func (action Action) IsValid() bool {
 	case ListMultipartUploadPartsAction, PutBucketNotificationAction:
 		fallthrough
 	case PutBucketPolicyAction, PutObjectAction:
+		fallthrough
+	case DeleteObjectVersionAction, GetBucketVersioningAction, GetObjectVersionAction:
+		fallthrough
+	case ListBucketVersionsAction, PutBucketVersioningAction:
+		fallthrough
+	case GetBucketLifecycleAction, PutBucketLifecycleAction:
+		fallthrough
+	case GetObjectTaggingAction, PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case GetBucketObjectLockConfigurationAction, PutBucketObjectLockConfigurationAction:
+		fallthrough
+	case GetObjectRetentionAction, PutObjectRetentionAction, BypassGovernanceRetentionAction:
+		fallthrough
+	case GetObjectLegalHoldAction, PutObjectLegalHoldAction:
+		fallthrough
+	case GetBucketCORSAction, PutBucketCORSAction:
+		fallthrough
+	case GetBucketEncryptionAction, PutBucketEncryptionAction:
+		fallthrough
+	case GetBucketReplicationAction, PutBucketReplicationAction:
 		return true
 	}
 
@@ -168,6 +266,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	GetObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzStorageClass,
@@ -190,10 +289,70 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	PutObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3RequestObjectTag,
 			condition.S3XAmzCopySource,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 		}, condition.CommonKeys...)...),
+
+	DeleteObjectVersionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectVersionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	ListBucketVersionsAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketLifecycleAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketLifecycleAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+			condition.S3RequestObjectTag,
+		}, condition.CommonKeys...)...),
+
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	GetBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	BypassGovernanceRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketCORSAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketCORSAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketEncryptionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketEncryptionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketReplicationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketReplicationAction: condition.NewKeySet(condition.CommonKeys...),
 }
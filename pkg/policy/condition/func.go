@@ -55,6 +55,27 @@ func (functions Functions) Evaluate(values map[string][]string) bool {
 	return true
 }
 
+// HasUnresolvedVariables - returns whether any function has a value with
+// policy variables which can not be substituted by given values map.
+func (functions Functions) HasUnresolvedVariables(values map[string][]string) bool {
+	for _, f := range functions {
+		for _, valueSet := range f.toMap() {
+			for value := range valueSet {
+				s, err := value.GetString()
+				if err != nil {
+					continue
+				}
+
+				if _, ok := SubstituteVariables(s, values); !ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 // Keys - returns list of keys used in all functions.
 func (functions Functions) Keys() KeySet {
 	keySet := NewKeySet()
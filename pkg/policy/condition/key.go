@@ -76,6 +76,87 @@ const (
 
 	// AWSEpochTime - key representing the current epoch time.
 	AWSEpochTime = "aws:EpochTime"
+
+	// S3ExistingObjectTag - key representing the value of a tag of an existing object,
+	// used as s3:ExistingObjectTag/<tag-key>.
+	S3ExistingObjectTag = "s3:ExistingObjectTag"
+
+	// S3RequestObjectTag - key representing the value of a tag sent along with the request,
+	// used as s3:RequestObjectTag/<tag-key>.
+	S3RequestObjectTag = "s3:RequestObjectTag"
+
+	// AWSUsername - key representing the name of the user making the request, for
+	// service accounts and temporary credentials it is the name of their parent user.
+	AWSUsername = "aws:username"
+
+	// AWSUserID - key representing the access key of the credentials used for the request.
+	AWSUserID = "aws:userid"
+
+	// JWTSub - key representing the subject claim of the JWT.
+	JWTSub = "jwt:sub"
+
+	// JWTIss - key representing the issuer claim of the JWT.
+	JWTIss = "jwt:iss"
+
+	// JWTAud - key representing the audience claim of the JWT.
+	JWTAud = "jwt:aud"
+
+	// JWTJti - key representing the unique identifier claim of the JWT.
+	JWTJti = "jwt:jti"
+
+	// JWTUpn - key representing the user principal name claim of the JWT.
+	JWTUpn = "jwt:upn"
+
+	// JWTName - key representing the name claim of the JWT.
+	JWTName = "jwt:name"
+
+	// JWTGroups - key representing the groups claim of the JWT.
+	JWTGroups = "jwt:groups"
+
+	// JWTGivenName - key representing the given name claim of the JWT.
+	JWTGivenName = "jwt:given_name"
+
+	// JWTFamilyName - key representing the family name claim of the JWT.
+	JWTFamilyName = "jwt:family_name"
+
+	// JWTMiddleName - key representing the middle name claim of the JWT.
+	JWTMiddleName = "jwt:middle_name"
+
+	// JWTNickName - key representing the nickname claim of the JWT.
+	JWTNickName = "jwt:nickname"
+
+	// JWTPrefUsername - key representing the preferred username claim of the JWT.
+	JWTPrefUsername = "jwt:preferred_username"
+
+	// JWTProfile - key representing the profile claim of the JWT.
+	JWTProfile = "jwt:profile"
+
+	// JWTPicture - key representing the picture claim of the JWT.
+	JWTPicture = "jwt:picture"
+
+	// JWTWebsite - key representing the website claim of the JWT.
+	JWTWebsite = "jwt:website"
+
+	// JWTEmail - key representing the email claim of the JWT.
+	JWTEmail = "jwt:email"
+
+	// JWTGender - key representing the gender claim of the JWT.
+	JWTGender = "jwt:gender"
+
+	// JWTBirthdate - key representing the birthdate claim of the JWT.
+	JWTBirthdate = "jwt:birthdate"
+
+	// JWTPhoneNumber - key representing the phone number claim of the JWT.
+	JWTPhoneNumber = "jwt:phone_number"
+
+	// JWTAddress - key representing the address claim of the JWT.
+	JWTAddress = "jwt:address"
+
+	// JWTScope - key representing the scope claim of the JWT.
+	JWTScope = "jwt:scope"
+
+	// JWTClientID - key representing the client id claim of the JWT.
+	JWTClientID = "jwt:client_id"
 )
 
 // AllSupportedKeys - is list of all all supported keys.
@@ -98,6 +179,12 @@ var AllSupportedKeys = []Key{
 	// Add new supported condition keys.
 }
 
+// TagKeys - is list of keys which are qualified by a tag key.
+var TagKeys = []Key{
+	S3ExistingObjectTag,
+	S3RequestObjectTag,
+}
+
 // CommonKeys - is list of all common condition keys.
 var CommonKeys = []Key{
 	AWSReferer,
@@ -108,14 +195,70 @@ var CommonKeys = []Key{
 	AWSEpochTime,
 }
 
+// JWTKeys - is list of keys of claims of the JWT the temporary credentials
+// of the request were issued for.
+var JWTKeys = []Key{
+	JWTSub,
+	JWTIss,
+	JWTAud,
+	JWTJti,
+	JWTUpn,
+	JWTName,
+	JWTGroups,
+	JWTGivenName,
+	JWTFamilyName,
+	JWTMiddleName,
+	JWTNickName,
+	JWTPrefUsername,
+	JWTProfile,
+	JWTPicture,
+	JWTWebsite,
+	JWTEmail,
+	JWTGender,
+	JWTBirthdate,
+	JWTPhoneNumber,
+	JWTAddress,
+	JWTScope,
+	JWTClientID,
+}
+
+// IdentityKeys - is list of keys describing the identity making the request,
+// their values are set by the server and are available to IAM policies only.
+var IdentityKeys = append([]Key{
+	AWSUsername,
+	AWSUserID,
+}, JWTKeys...)
+
+// tagKey - returns the tag key qualified key like s3:ExistingObjectTag/<tag-key> is
+// based on, or an empty key otherwise.
+func (key Key) tagKey() Key {
+	for _, tagKey := range TagKeys {
+		if strings.HasPrefix(string(key), string(tagKey)+"/") && len(key) > len(tagKey)+1 {
+			return tagKey
+		}
+	}
+
+	return ""
+}
+
 // IsValid - checks if key is valid or not.
 func (key Key) IsValid() bool {
+	if key.tagKey() != "" {
+		return true
+	}
+
 	for _, supKey := range AllSupportedKeys {
 		if supKey == key {
 			return true
 		}
 	}
 
+	for _, identityKey := range IdentityKeys {
+		if identityKey == key {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -139,6 +282,11 @@ func (key Key) Name() string {
 	return strings.TrimPrefix(keyString, "s3:")
 }
 
+// VarName - returns variable name of the key used in policies like ${aws:username}.
+func (key Key) VarName() string {
+	return fmt.Sprintf("${%s}", key)
+}
+
 // UnmarshalJSON - decodes JSON data to Key.
 func (key *Key) UnmarshalJSON(data []byte) error {
 	var s string
@@ -173,7 +321,8 @@ func (set KeySet) Add(key Key) {
 	set[key] = struct{}{}
 }
 
-// Difference - returns a key set contains difference of two keys.
+// Difference - returns a key set contains difference of two keys. A tag key
+// qualified key like s3:ExistingObjectTag/<tag-key> is matched by its base key.
 // Example:
 //     keySet1 := ["one", "two", "three"]
 //     keySet2 := ["two", "four", "three"]
@@ -182,9 +331,15 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	nset := make(KeySet)
 
 	for k := range set {
-		if _, ok := sset[k]; !ok {
-			nset.Add(k)
+		if _, ok := sset[k]; ok {
+			continue
+		}
+		if tagKey := k.tagKey(); tagKey != "" {
+			if _, ok := sset[tagKey]; ok {
+				continue
+			}
 		}
+		nset.Add(k)
 	}
 
 	return nset
@@ -38,6 +38,10 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{Key("s3:ExistingObjectTag/pii"), true},
+		{Key("s3:RequestObjectTag/retention"), true},
+		{Key("s3:ExistingObjectTag/"), false},
+		{Key(S3RequestObjectTag), false},
 		{Key("foo"), false},
 	}
 
@@ -148,6 +152,7 @@ func TestKeySetDifference(t *testing.T) {
 	}{
 		{NewKeySet(), NewKeySet(S3XAmzCopySource), NewKeySet()},
 		{NewKeySet(S3Prefix, S3Delimiter, S3MaxKeys), NewKeySet(S3Delimiter, S3MaxKeys), NewKeySet(S3Prefix)},
+		{NewKeySet(Key("s3:ExistingObjectTag/pii"), Key("s3:RequestObjectTag/pii")), NewKeySet(S3ExistingObjectTag), NewKeySet(Key("s3:RequestObjectTag/pii"))},
 	}
 
 	for i, testCase := range testCases {
@@ -50,8 +50,9 @@ func (f stringEqualsIgnoreCaseFunc) evaluate(values map[string][]string) bool {
 		requestValue = values[f.k.Name()]
 	}
 
+	fvalues := substituteValues(f.values, values)
 	for _, v := range requestValue {
-		if !f.values.FuncMatch(strings.EqualFold, v).IsEmpty() {
+		if !fvalues.FuncMatch(strings.EqualFold, v).IsEmpty() {
 			return true
 		}
 	}
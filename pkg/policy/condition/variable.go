@@ -0,0 +1,92 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/set"
+)
+
+// policyVariableKeys - is list of keys which can be used as policy variables
+// like ${aws:username} in resources and condition values.
+var policyVariableKeys = append(append([]Key{}, CommonKeys...), IdentityKeys...)
+
+// SubstituteVariables - replaces policy variables in given string by values
+// of their keys in given values map. It returns false if a variable does not
+// have exactly one value or its value contains wildcard characters, in which
+// case the string must not match anything.
+func SubstituteVariables(s string, values map[string][]string) (string, bool) {
+	if s, ok := substituteVariables(s, values, ""); ok {
+		return s, true
+	}
+	return "", false
+}
+
+// WildcardVariables - replaces policy variables in given string like
+// SubstituteVariables, variables which can not be substituted are replaced
+// by '*' so that the string matches any of their values.
+func WildcardVariables(s string, values map[string][]string) string {
+	s, _ = substituteVariables(s, values, "*")
+	return s
+}
+
+// substituteVariables - replaces policy variables in given string by values
+// of their keys, or by unresolved if they can not be substituted in which
+// case it returns false.
+func substituteVariables(s string, values map[string][]string, unresolved string) (string, bool) {
+	if !strings.Contains(s, "${") {
+		return s, true
+	}
+
+	resolved := true
+	for _, key := range policyVariableKeys {
+		varName := key.VarName()
+		if !strings.Contains(s, varName) {
+			continue
+		}
+
+		requestValue, ok := values[http.CanonicalHeaderKey(key.Name())]
+		if !ok {
+			requestValue = values[key.Name()]
+		}
+		if len(requestValue) != 1 || requestValue[0] == "" || strings.ContainsAny(requestValue[0], "*?") {
+			resolved = false
+			s = strings.Replace(s, varName, unresolved, -1)
+			continue
+		}
+
+		s = strings.Replace(s, varName, requestValue[0], -1)
+	}
+
+	return s, resolved
+}
+
+// substituteValues - returns condition values with policy variables
+// substituted, values with variables which cannot be substituted are
+// dropped.
+func substituteValues(sset set.StringSet, values map[string][]string) set.StringSet {
+	nset := set.NewStringSet()
+	for _, s := range sset.ToSlice() {
+		if v, ok := SubstituteVariables(s, values); ok {
+			nset.Add(v)
+		}
+	}
+
+	return nset
+}
@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"testing"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	values := map[string][]string{
+		"username":           {"alice"},
+		"userid":             {"Q3AM3UQ867SPQQA43P2F"},
+		"jwt:sub":            {"2f8b2c1e"},
+		"jwt:groups":         {"developers", "testers"},
+		"jwt:email":          {""},
+		"jwt:nickname":       {"a*"},
+		"Referer":            {"https://example.com"},
+		"preferred_username": {"spoofed"},
+	}
+
+	testCases := []struct {
+		s              string
+		expectedResult string
+		expectedOk     bool
+	}{
+		{"home/alice/*", "home/alice/*", true},
+		{"home/${aws:username}/*", "home/alice/*", true},
+		{"home/${aws:username}/${aws:username}", "home/alice/alice", true},
+		{"${aws:userid}-${jwt:sub}", "Q3AM3UQ867SPQQA43P2F-2f8b2c1e", true},
+		{"${aws:Referer}", "https://example.com", true},
+		// Unknown variables are left as is.
+		{"home/${foo}/*", "home/${foo}/*", true},
+		// Variables without a single non empty value.
+		{"home/${jwt:preferred_username}/*", "", false},
+		{"home/${jwt:groups}/*", "", false},
+		{"home/${jwt:email}/*", "", false},
+		// Values with wildcard characters.
+		{"home/${jwt:nickname}/*", "", false},
+	}
+
+	for i, testCase := range testCases {
+		result, ok := SubstituteVariables(testCase.s, values)
+		if ok != testCase.expectedOk {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedOk, ok)
+		}
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestWildcardVariables(t *testing.T) {
+	values := map[string][]string{
+		"username":  {"alice"},
+		"jwt:email": {""},
+	}
+
+	testCases := []struct {
+		s              string
+		expectedResult string
+	}{
+		{"home/${aws:username}/*", "home/alice/*"},
+		{"home/${jwt:email}/*", "home/*/*"},
+		{"${aws:username}/${jwt:sub}", "alice/*"},
+		{"home/${foo}/*", "home/${foo}/*"},
+	}
+
+	for i, testCase := range testCases {
+		if result := WildcardVariables(testCase.s, values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestFunctionEvaluatePolicyVariables(t *testing.T) {
+	case1Function, err := newStringEqualsFunc(JWTPrefUsername, NewValueSet(NewStringValue("${aws:username}")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := newStringLikeFunc(S3Prefix, NewValueSet(NewStringValue("home/${aws:username}/*")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Function, err := newStringNotEqualsFunc(AWSUserID, NewValueSet(NewStringValue("${jwt:sub}")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{case1Function, map[string][]string{"username": {"alice"}, "jwt:preferred_username": {"alice"}}, true},
+		{case1Function, map[string][]string{"username": {"alice"}, "jwt:preferred_username": {"bob"}}, false},
+		{case1Function, map[string][]string{"jwt:preferred_username": {"${aws:username}"}}, false},
+
+		{case2Function, map[string][]string{"username": {"alice"}, "prefix": {"home/alice/photos"}}, true},
+		{case2Function, map[string][]string{"username": {"alice"}, "prefix": {"home/bob/photos"}}, false},
+
+		{case3Function, map[string][]string{"userid": {"alice"}, "jwt:sub": {"bob"}}, true},
+		{case3Function, map[string][]string{"userid": {"alice"}, "jwt:sub": {"alice"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Maximum number of rules allowed in a replication configuration as
+// per AWS S3 specification.
+const maxRules = 1000
+
+// Maximum length of a rule ID.
+const maxRuleIDLength = 255
+
+// Prefix of a destination bucket given as an ARN.
+const bucketARNPrefix = "arn:aws:s3:::"
+
+var (
+	errReplicationNoRule              = errors.New("Replication configuration should have at least one rule")
+	errReplicationTooManyRules        = errors.New("Replication configuration allows a maximum of 1000 rules")
+	errReplicationInvalidRuleID       = errors.New("Replication rule ID must not be longer than 255 characters")
+	errReplicationDuplicateRuleID     = errors.New("Replication rule ID must be unique")
+	errReplicationInvalidStatus       = errors.New("Replication rule Status must be set to either Enabled or Disabled")
+	errReplicationNoDestination       = errors.New("Replication rule Destination should have a bucket")
+	errReplicationInvalidEndpoint     = errors.New("Replication rule Destination should have an http or https endpoint")
+	errReplicationNoCredentials       = errors.New("Replication rule Destination should have an access key and a secret key")
+	errReplicationMultipleDestination = errors.New("Replication rules must all have the same Destination")
+)
+
+// Status - represents the status of a replication rule.
+type Status string
+
+// Supported status types
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// ObjectStatus - represents the replication status of an object.
+type ObjectStatus string
+
+// Supported object replication status types
+const (
+	Pending   ObjectStatus = "PENDING"
+	Completed ObjectStatus = "COMPLETED"
+	Failed    ObjectStatus = "FAILED"
+)
+
+// Destination - represents <Destination>...</Destination>, the endpoint
+// and the credentials of the remote S3 compatible target are Minio
+// extensions.
+type Destination struct {
+	XMLName   xml.Name `xml:"Destination"`
+	Bucket    string   `xml:"Bucket"`
+	Endpoint  string   `xml:"Endpoint"`
+	AccessKey string   `xml:"AccessKey"`
+	SecretKey string   `xml:"SecretKey,omitempty"`
+}
+
+// BucketName - returns the name of the destination bucket, the bucket
+// may be given as a name or as an ARN.
+func (d Destination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, bucketARNPrefix)
+}
+
+// EndpointURL - returns the parsed endpoint of the remote target.
+func (d Destination) EndpointURL() (*url.URL, error) {
+	u, err := url.Parse(d.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		(u.Path != "" && u.Path != "/") {
+		return nil, errReplicationInvalidEndpoint
+	}
+	return u, nil
+}
+
+// Validate - validates the replication destination.
+func (d Destination) Validate() error {
+	if d.BucketName() == "" {
+		return errReplicationNoDestination
+	}
+	if _, err := d.EndpointURL(); err != nil {
+		return errReplicationInvalidEndpoint
+	}
+	if d.AccessKey == "" || d.SecretKey == "" {
+		return errReplicationNoCredentials
+	}
+	return nil
+}
+
+// Rule - represents <Rule>...</Rule>
+type Rule struct {
+	XMLName     xml.Name    `xml:"Rule"`
+	ID          string      `xml:"ID,omitempty"`
+	Status      Status      `xml:"Status"`
+	Prefix      string      `xml:"Prefix"`
+	Destination Destination `xml:"Destination"`
+}
+
+// Validate - validates the replication rule.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return errReplicationInvalidRuleID
+	}
+	if rule.Status != Enabled && rule.Status != Disabled {
+		return errReplicationInvalidStatus
+	}
+	return rule.Destination.Validate()
+}
+
+// Config - represents <ReplicationConfiguration>...</ReplicationConfiguration>
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	Role    string   `xml:"Role,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the replication configuration.
+func (config Config) Validate() error {
+	if len(config.Rules) == 0 {
+		return errReplicationNoRule
+	}
+	if len(config.Rules) > maxRules {
+		return errReplicationTooManyRules
+	}
+
+	ids := make(map[string]struct{})
+	for _, rule := range config.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if rule.ID != "" {
+			if _, ok := ids[rule.ID]; ok {
+				return errReplicationDuplicateRuleID
+			}
+			ids[rule.ID] = struct{}{}
+		}
+		// All rules replicate to the same remote target.
+		if rule.Destination != config.Rules[0].Destination {
+			return errReplicationMultipleDestination
+		}
+	}
+
+	return nil
+}
+
+// Destination - returns the remote target of the configuration.
+func (config Config) Destination() Destination {
+	return config.Rules[0].Destination
+}
+
+// Match - returns the first enabled rule whose prefix matches the
+// given object name.
+func (config Config) Match(object string) (rule Rule, ok bool) {
+	for _, r := range config.Rules {
+		if r.Status == Enabled && strings.HasPrefix(object, r.Prefix) {
+			return r, true
+		}
+	}
+	return rule, false
+}
+
+// Redacted - returns a copy of the configuration without the secret
+// key of the remote target.
+func (config Config) Redacted() Config {
+	return config.WithSecretKey("")
+}
+
+// WithSecretKey - returns a copy of the configuration with the given
+// secret key for the remote target.
+func (config Config) WithSecretKey(secretKey string) Config {
+	rules := make([]Rule, len(config.Rules))
+	for i, rule := range config.Rules {
+		rule.Destination.SecretKey = secretKey
+		rules[i] = rule
+	}
+	config.Rules = rules
+	return config
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
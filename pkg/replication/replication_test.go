@@ -0,0 +1,102 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDestination = `<Destination><Bucket>arn:aws:s3:::backup</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination>`
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedBucket string
+		expectErr      bool
+	}{
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix></Prefix>` + testDestination + `</Rule></ReplicationConfiguration>`, "backup", false},
+		{`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role>arn:minio:replication</Role><Rule><ID>logs</ID><Status>Enabled</Status><Prefix>logs/</Prefix>` + testDestination + `</Rule><Rule><ID>docs</ID><Status>Disabled</Status><Prefix>docs/</Prefix>` + testDestination + `</Rule></ReplicationConfiguration>`, "backup", false},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>backup</Bucket><Endpoint>https://s3.example.com</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, "backup", false},
+		{`<ReplicationConfiguration></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>On</Status>` + testDestination + `</Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>backup</Bucket><Endpoint>localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>backup</Bucket><Endpoint>http://localhost:9001/path</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>backup</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey></Destination></Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><ID>a</ID><Status>Enabled</Status>` + testDestination + `</Rule><Rule><ID>a</ID><Status>Enabled</Status>` + testDestination + `</Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status>` + testDestination + `</Rule><Rule><Status>Enabled</Status><Destination><Bucket>other</Bucket><Endpoint>http://localhost:9001</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, "", true},
+		{`<ReplicationConfiguration>`, "", true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr && config.Destination().BucketName() != testCase.expectedBucket {
+			t.Fatalf("test %v: bucket: expected: %v, got: %v", i+1, testCase.expectedBucket, config.Destination().BucketName())
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<ReplicationConfiguration><Rule><ID>logs</ID><Status>Enabled</Status><Prefix>logs/</Prefix>` + testDestination + `</Rule><Rule><ID>docs</ID><Status>Disabled</Status><Prefix>docs/</Prefix>` + testDestination + `</Rule></ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		object     string
+		expectedID string
+		expectedOK bool
+	}{
+		{"logs/2019/01/01.log", "logs", true},
+		{"logs/", "logs", true},
+		{"docs/readme.md", "", false},
+		{"images/logo.png", "", false},
+		{"log", "", false},
+	}
+
+	for i, testCase := range testCases {
+		rule, ok := config.Match(testCase.object)
+		if ok != testCase.expectedOK {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedOK, ok)
+		}
+		if rule.ID != testCase.expectedID {
+			t.Fatalf("test %v: rule: expected: %v, got: %v", i+1, testCase.expectedID, rule.ID)
+		}
+	}
+}
+
+func TestConfigRedacted(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<ReplicationConfiguration><Rule><Status>Enabled</Status>` + testDestination + `</Rule></ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	redacted := config.Redacted()
+	if redacted.Rules[0].Destination.SecretKey != "" {
+		t.Fatalf("expected the secret key to be removed, got: %v", redacted.Rules[0].Destination.SecretKey)
+	}
+	if config.Rules[0].Destination.SecretKey != "minio123" {
+		t.Fatalf("expected the original configuration to keep its secret key, got: %v", config.Rules[0].Destination.SecretKey)
+	}
+}
@@ -42,14 +42,16 @@ func (rr *recordReader) Read(p []byte) (n int, err error) {
 		n, err = rr.reader.Read(p)
 	}
 
-	if err != nil {
+	// Data read along with an error like io.EOF is returned with the error.
+	if n == 0 {
 		return 0, err
 	}
 
 	if string(rr.recordDelimiter) == "\n" {
-		return n, nil
+		return n, err
 	}
 
+	p = p[:n]
 	for {
 		i := bytes.Index(p, rr.recordDelimiter)
 		if i < 0 {
@@ -63,12 +65,12 @@ func (rr *recordReader) Read(p []byte) (n int, err error) {
 	}
 
 	n = len(p)
-	if len(rr.recordDelimiter) == 1 || p[n-1] != rr.recordDelimiter[0] {
-		return n, nil
+	if len(rr.recordDelimiter) == 1 || n == 0 || p[n-1] != rr.recordDelimiter[0] || err != nil {
+		return n, err
 	}
 
-	if _, err = rr.reader.Read(rr.oneByte); err != nil {
-		return 0, err
+	if m, err := rr.reader.Read(rr.oneByte); m == 0 {
+		return n, err
 	}
 
 	if rr.oneByte[0] == rr.recordDelimiter[1] {
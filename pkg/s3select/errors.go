@@ -65,7 +65,7 @@ func errMalformedXML(err error) *s3Error {
 func errInvalidCompressionFormat(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidCompressionFormat",
-		message:    "The file is not in a supported compression format. Only GZIP and BZIP2 are supported.",
+		message:    "The file is not in a supported compression format. Only GZIP, BZIP2, ZSTD, SNAPPY and LZ4 are supported.",
 		statusCode: 400,
 		cause:      err,
 	}
@@ -0,0 +1,214 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+func newColumn(
+	columnChunk *parquet.ColumnChunk,
+	leaf *schemaNode,
+	getReaderFunc GetReaderFunc,
+	pageLocations []*parquet.PageLocation,
+) (*column, error) {
+	// Column spanning into another file is not supported.
+	if columnChunk.GetFilePath() != "" {
+		return nil, fmt.Errorf("column %v in file %v is not supported", leaf.pathName(), columnChunk.GetFilePath())
+	}
+
+	column := &column{
+		leaf:          leaf,
+		metadata:      columnChunk.GetMetaData(),
+		getReaderFunc: getReaderFunc,
+		pageLocations: pageLocations,
+	}
+
+	offset := column.metadata.GetDataPageOffset()
+	if column.metadata.DictionaryPageOffset != nil {
+		offset = column.metadata.GetDictionaryPageOffset()
+	}
+
+	if pageLocations == nil {
+		// Pages are read in sequence.
+		if err := column.open(offset, column.metadata.GetTotalCompressedSize()); err != nil {
+			return nil, err
+		}
+
+		return column, nil
+	}
+
+	// Pages are read by their locations, hence dictionary page is read
+	// upfront.
+	if offset < column.metadata.GetDataPageOffset() {
+		if err := column.open(offset, column.metadata.GetDataPageOffset()-offset); err != nil {
+			return nil, err
+		}
+
+		if err := column.readPage(); err != nil {
+			return nil, err
+		}
+	}
+
+	return column, nil
+}
+
+type column struct {
+	leaf          *schemaNode
+	metadata      *parquet.ColumnMetaData
+	getReaderFunc GetReaderFunc
+	pageLocations []*parquet.PageLocation // page locations if pages are read by rows.
+	pageIndex     int                     // index of next page location to read.
+	numValues     int64                   // number of values read.
+	rowIndex      int64                   // row index of first value in dataTable.
+	dictPage      *page
+	dataTable     *table
+	rc            io.ReadCloser
+	thriftReader  *thrift.TBufferedTransport
+}
+
+func (column *column) open(offset, size int64) (err error) {
+	column.close()
+
+	if column.rc, err = column.getReaderFunc(offset, size); err != nil {
+		return err
+	}
+
+	column.thriftReader = thrift.NewTBufferedTransport(thrift.NewStreamTransportR(column.rc), int(size))
+	return nil
+}
+
+func (column *column) close() (err error) {
+	if column.rc != nil {
+		err = column.rc.Close()
+		column.rc = nil
+	}
+
+	return err
+}
+
+// readPage - reads next page from opened reader and appends its values to
+// data table.
+func (column *column) readPage() error {
+	page, _, _, err := readPage(column.thriftReader, column.metadata, column.leaf)
+	if err != nil {
+		return err
+	}
+
+	if page.Header.GetType() == parquet.PageType_DICTIONARY_PAGE {
+		column.dictPage = page
+		return nil
+	}
+
+	page.decode(column.dictPage)
+
+	if column.dataTable == nil {
+		column.dataTable = newTableFromTable(page.DataTable)
+	}
+
+	column.dataTable.Merge(page.DataTable)
+	column.numValues += int64(len(page.DataTable.Values))
+	return nil
+}
+
+// hasNextPage - returns whether pages are remaining to be read in sequence.
+func (column *column) hasNextPage() bool {
+	if column.pageLocations != nil {
+		return column.pageIndex < len(column.pageLocations)
+	}
+
+	return column.numValues < column.metadata.GetNumValues()
+}
+
+// nextPage - reads next data page in sequence.
+func (column *column) nextPage() error {
+	if column.pageLocations == nil {
+		return column.readPage()
+	}
+
+	location := column.pageLocations[column.pageIndex]
+	column.pageIndex++
+	if err := column.open(location.GetOffset(), int64(location.GetCompressedPageSize())); err != nil {
+		return err
+	}
+
+	column.rowIndex = location.GetFirstRowIndex()
+	column.dataTable = nil
+	return column.readPage()
+}
+
+// seek - moves to the page containing row if pages are read by their
+// locations and the row is beyond current page.
+func (column *column) seek(rowIndex int64) error {
+	i := column.pageIndex
+	for i < len(column.pageLocations) && column.pageLocations[i].GetFirstRowIndex() <= rowIndex {
+		i++
+	}
+
+	if i == column.pageIndex {
+		return nil
+	}
+
+	column.pageIndex = i - 1
+	return column.nextPage()
+}
+
+// readRow - returns values, repetition levels and definition levels of
+// given row. Rows must be read in increasing order, rows in between are
+// skipped.
+func (column *column) readRow(rowIndex int64) (*table, error) {
+	if rowIndex < column.rowIndex {
+		return nil, fmt.Errorf("row %v of column %v is already read", rowIndex, column.leaf.pathName())
+	}
+
+	if err := column.seek(rowIndex); err != nil {
+		return nil, err
+	}
+
+	for {
+		if column.dataTable == nil || len(column.dataTable.Values) == 0 {
+			if !column.hasNextPage() {
+				return nil, fmt.Errorf("row %v of column %v not found", rowIndex, column.leaf.pathName())
+			}
+
+			if err := column.nextPage(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		// A row may span into next page if pages are read in sequence.
+		if column.pageLocations == nil && column.hasNextPage() && column.dataTable.rowEnd() < 0 {
+			if err := column.nextPage(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		row := column.dataTable.Pop(1)
+		column.rowIndex++
+		if column.rowIndex > rowIndex {
+			return row, nil
+		}
+	}
+}
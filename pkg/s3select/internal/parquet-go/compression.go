@@ -23,9 +23,9 @@ import (
 	"io/ioutil"
 
 	"github.com/golang/snappy"
-	"github.com/minio/parquet-go/gen-go/parquet"
 	"github.com/pierrec/lz4"
 	lzo "github.com/rasky/go-lzo"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
 )
 
 type compressionCodec parquet.CompressionCodec
@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"math"
 
-	"github.com/minio/parquet-go/gen-go/parquet"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
 )
 
 func uint32ToBytes(v uint32) []byte {
@@ -446,7 +446,7 @@ func readDataPageValues(
 		result, err = readValues(bytesReader, dataType, count, bitWidth)
 		return result, dataType, err
 
-	case parquet.Encoding_PLAIN_DICTIONARY:
+	case parquet.Encoding_PLAIN_DICTIONARY, parquet.Encoding_RLE_DICTIONARY:
 		b, err := bytesReader.ReadByte()
 		if err != nil {
 			return nil, -1, err
@@ -0,0 +1,236 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+// GetReaderFunc - function type returning io.ReadCloser for requested offset/length.
+type GetReaderFunc func(offset, length int64) (io.ReadCloser, error)
+
+func footerSize(getReaderFunc GetReaderFunc) (size int64, err error) {
+	rc, err := getReaderFunc(-8, 4)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	buf := make([]byte, 4)
+	if _, err = io.ReadFull(rc, buf); err != nil {
+		return 0, err
+	}
+
+	size = int64(binary.LittleEndian.Uint32(buf))
+
+	return size, nil
+}
+
+func fileMetadata(getReaderFunc GetReaderFunc) (*parquet.FileMetaData, error) {
+	size, err := footerSize(getReaderFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := getReaderFunc(-(8 + size), size)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	fileMeta := parquet.NewFileMetaData()
+
+	pf := thrift.NewTCompactProtocolFactory()
+	protocol := pf.GetProtocol(thrift.NewStreamTransportR(rc))
+	err = fileMeta.Read(protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileMeta, nil
+}
+
+// Value - denotes column value
+type Value struct {
+	Value interface{}
+	Type  parquet.Type
+}
+
+// MarshalJSON - encodes to JSON data
+func (value Value) MarshalJSON() (data []byte, err error) {
+	return json.Marshal(value.Value)
+}
+
+// File - denotes parquet file.
+type File struct {
+	getReaderFunc GetReaderFunc
+	schema        *schemaNode
+	leaves        map[string]*schemaNode
+	rowGroups     []*parquet.RowGroup
+	rowGroupIndex int
+
+	columnNames set.StringSet
+	filter      Filter
+	columns     map[string]*column
+	rowRanges   []rowRange
+}
+
+// Open - opens parquet file with given top level column names, all columns
+// are read if columnNames is nil. Row groups and pages are skipped if filter
+// returns false for their statistics.
+func Open(getReaderFunc GetReaderFunc, columnNames set.StringSet, filter Filter) (*File, error) {
+	fileMeta, err := fileMetadata(getReaderFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, leaves, err := newSchemaTree(fileMeta.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		getReaderFunc: getReaderFunc,
+		schema:        schema,
+		leaves:        leaves,
+		rowGroups:     fileMeta.GetRowGroups(),
+		columnNames:   columnNames,
+		filter:        filter,
+	}, nil
+}
+
+// FieldNames - returns top level field names to be read in schema order.
+func (file *File) FieldNames() (names []string) {
+	for _, child := range file.schema.children {
+		if file.columnNames == nil || file.columnNames.Contains(child.name()) {
+			names = append(names, child.name())
+		}
+	}
+
+	return names
+}
+
+// Read - reads single record.
+func (file *File) Read() (record map[string]Value, err error) {
+	for len(file.rowRanges) == 0 {
+		file.Close()
+
+		if file.rowGroupIndex >= len(file.rowGroups) {
+			return nil, io.EOF
+		}
+
+		rowGroup := file.rowGroups[file.rowGroupIndex]
+		file.rowGroupIndex++
+		if file.rowRanges, err = file.filterRows(rowGroup); err != nil {
+			return nil, err
+		}
+
+		if len(file.rowRanges) > 0 {
+			if err = file.openColumns(rowGroup); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rowIndex := file.rowRanges[0].start
+	if file.rowRanges[0].start++; file.rowRanges[0].start == file.rowRanges[0].end {
+		file.rowRanges = file.rowRanges[1:]
+	}
+
+	fields := make(map[string]interface{})
+	for _, column := range file.columns {
+		row, err := column.readRow(rowIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		assemble(fields, column.leaf, row.Values, row.RepetitionLevels, row.DefinitionLevels)
+	}
+
+	record = make(map[string]Value)
+	for _, child := range file.schema.children {
+		if value, found := fields[child.name()]; found {
+			record[child.name()] = child.fieldValue(value)
+		}
+	}
+
+	return record, nil
+}
+
+// openColumns - opens projected columns of row group. Pages are read by
+// their locations if rows are skipped.
+func (file *File) openColumns(rowGroup *parquet.RowGroup) error {
+	skipped := int64(0)
+	for _, rowRange := range file.rowRanges {
+		skipped += rowRange.end - rowRange.start
+	}
+	skipped = rowGroup.GetNumRows() - skipped
+
+	file.columns = make(map[string]*column)
+	for _, columnChunk := range rowGroup.GetColumns() {
+		path := columnChunk.GetMetaData().GetPathInSchema()
+		if file.columnNames != nil && !file.columnNames.Contains(path[0]) {
+			continue
+		}
+
+		leaf, found := file.leaves[strings.Join(path, ".")]
+		if !found {
+			return fmt.Errorf("column %v not found in schema", strings.Join(path, "."))
+		}
+
+		var pageLocations []*parquet.PageLocation
+		if skipped > 0 && columnChunk.IsSetOffsetIndexOffset() {
+			offsetIndex, err := file.readOffsetIndex(columnChunk)
+			if err != nil {
+				return err
+			}
+
+			pageLocations = offsetIndex.GetPageLocations()
+		}
+
+		column, err := newColumn(columnChunk, leaf, file.getReaderFunc, pageLocations)
+		if err != nil {
+			return err
+		}
+
+		file.columns[leaf.pathName()] = column
+	}
+
+	return nil
+}
+
+// Close - closes underneath readers.
+func (file *File) Close() (err error) {
+	for _, column := range file.columns {
+		if cerr := column.close(); cerr != nil {
+			err = cerr
+		}
+	}
+
+	file.columns = nil
+	file.rowRanges = nil
+
+	return err
+}
@@ -0,0 +1,579 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+// testSchema - is below schema.
+//
+//	message schema {
+//	  required int64 id;
+//	  optional binary name (UTF8);
+//	  optional group address {
+//	    required binary city (UTF8);
+//	    optional int32 zip;
+//	  }
+//	  optional group tags (LIST) {
+//	    repeated group list {
+//	      optional binary element (UTF8);
+//	    }
+//	  }
+//	  optional group attrs (MAP) {
+//	    repeated group key_value {
+//	      required binary key (UTF8);
+//	      optional int64 value;
+//	    }
+//	  }
+//	}
+func testSchema() []*parquet.SchemaElement {
+	element := func(name string, repetitionType parquet.FieldRepetitionType, numChildren int32,
+		valueType *parquet.Type, convertedType *parquet.ConvertedType) *parquet.SchemaElement {
+		schemaElement := &parquet.SchemaElement{
+			Name:           name,
+			Type:           valueType,
+			ConvertedType:  convertedType,
+			RepetitionType: parquet.FieldRepetitionTypePtr(repetitionType),
+		}
+		if numChildren > 0 {
+			schemaElement.NumChildren = &numChildren
+		}
+		return schemaElement
+	}
+
+	required := parquet.FieldRepetitionType_REQUIRED
+	optional := parquet.FieldRepetitionType_OPTIONAL
+	repeated := parquet.FieldRepetitionType_REPEATED
+	int32Type := parquet.TypePtr(parquet.Type_INT32)
+	int64Type := parquet.TypePtr(parquet.Type_INT64)
+	binaryType := parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+	utf8 := parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+
+	numChildren := int32(5)
+	return []*parquet.SchemaElement{
+		{Name: "schema", NumChildren: &numChildren},
+		element("id", required, 0, int64Type, nil),
+		element("name", optional, 0, binaryType, utf8),
+		element("address", optional, 2, nil, nil),
+		element("city", required, 0, binaryType, utf8),
+		element("zip", optional, 0, int32Type, nil),
+		element("tags", optional, 1, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_LIST)),
+		element("list", repeated, 1, nil, nil),
+		element("element", optional, 0, binaryType, utf8),
+		element("attrs", optional, 1, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_MAP)),
+		element("key_value", repeated, 2, nil, nil),
+		element("key", required, 0, binaryType, utf8),
+		element("value", optional, 0, int64Type, nil),
+	}
+}
+
+type testValue struct {
+	value    interface{}
+	repLevel int32
+	defLevel int32
+}
+
+// testColumnValues - returns values of i-th row of testSchema columns, rows
+// are like below.
+//
+//	{"id":1,"address":{"city":"c1"},"tags":null,"attrs":{"k":1}}
+//	{"id":2,"name":"n2","address":{"city":"c2","zip":2},"tags":[],"attrs":{"k":2}}
+//	{"id":3,"address":null,"tags":["a","b"],"attrs":{"k":3}}
+//	{"id":4,"name":"n4","address":{"city":"c4","zip":4},"tags":["a",null],"attrs":{"k":4}}
+//	{"id":5,"address":{"city":"c5"},"tags":["a","b"],"attrs":{"k":5,"l":null}}
+//	{"id":6,"name":"n6","address":{"city":"c6","zip":6},"tags":["a","b"],"attrs":{"k":6}}
+func testColumnValues(i int) map[string][]testValue {
+	values := map[string][]testValue{
+		"id": {{int64(i), 0, 0}},
+	}
+
+	if i%2 == 0 {
+		values["name"] = []testValue{{[]byte(fmt.Sprintf("n%v", i)), 0, 1}}
+	} else {
+		values["name"] = []testValue{{nil, 0, 0}}
+	}
+
+	switch {
+	case i == 3:
+		values["address.city"] = []testValue{{nil, 0, 0}}
+		values["address.zip"] = []testValue{{nil, 0, 0}}
+	case i%2 == 0:
+		values["address.city"] = []testValue{{[]byte(fmt.Sprintf("c%v", i)), 0, 1}}
+		values["address.zip"] = []testValue{{int32(i), 0, 2}}
+	default:
+		values["address.city"] = []testValue{{[]byte(fmt.Sprintf("c%v", i)), 0, 1}}
+		values["address.zip"] = []testValue{{nil, 0, 1}}
+	}
+
+	switch i {
+	case 1:
+		values["tags.list.element"] = []testValue{{nil, 0, 0}}
+	case 2:
+		values["tags.list.element"] = []testValue{{nil, 0, 1}}
+	case 4:
+		values["tags.list.element"] = []testValue{{[]byte("a"), 0, 3}, {nil, 1, 2}}
+	default:
+		values["tags.list.element"] = []testValue{{[]byte("a"), 0, 3}, {[]byte("b"), 1, 3}}
+	}
+
+	values["attrs.key_value.key"] = []testValue{{[]byte("k"), 0, 2}}
+	values["attrs.key_value.value"] = []testValue{{int64(i), 0, 3}}
+	if i == 5 {
+		values["attrs.key_value.key"] = append(values["attrs.key_value.key"], testValue{[]byte("l"), 1, 2})
+		values["attrs.key_value.value"] = append(values["attrs.key_value.value"], testValue{nil, 1, 2})
+	}
+
+	return values
+}
+
+func writeThrift(w *bytes.Buffer, v interface {
+	Write(thrift.TProtocol) error
+}) (int, error) {
+	buf := thrift.NewTMemoryBuffer()
+	protocol := thrift.NewTCompactProtocol(buf)
+	if err := v.Write(protocol); err != nil {
+		return 0, err
+	}
+	if err := protocol.Flush(context.Background()); err != nil {
+		return 0, err
+	}
+
+	return w.Write(buf.Bytes())
+}
+
+// encodeLevels - encodes levels as RLE runs of single value with length
+// prefix.
+func encodeLevels(levels []int32, maxLevel int32) []byte {
+	width := (getBitWidth(uint64(maxLevel)) + 7) / 8
+	var data []byte
+	for _, level := range levels {
+		data = append(data, 2)
+		for i := uint64(0); i < width; i++ {
+			data = append(data, byte(level>>(8*i)))
+		}
+	}
+
+	return append(uint32ToBytes(uint32(len(data))), data...)
+}
+
+func encodePlain(value interface{}) []byte {
+	switch value := value.(type) {
+	case int32:
+		return uint32ToBytes(uint32(value))
+	case int64:
+		return uint64ToBytes(uint64(value))
+	case []byte:
+		return append(uint32ToBytes(uint32(len(value))), value...)
+	}
+
+	panic(fmt.Errorf("unsupported value %v", value))
+}
+
+// statData - returns PLAIN encoded value without length prefix.
+func statData(value interface{}) []byte {
+	if data, ok := value.([]byte); ok {
+		return data
+	}
+
+	return encodePlain(value)
+}
+
+func lessThan(v1, v2 interface{}) bool {
+	switch v1 := v1.(type) {
+	case int32:
+		return v1 < v2.(int32)
+	case int64:
+		return v1 < v2.(int64)
+	case []byte:
+		return bytes.Compare(v1, v2.([]byte)) < 0
+	}
+
+	return false
+}
+
+// writeTestFile - writes rows of testColumnValues from 1 to numRows into
+// row groups of rowGroupSize rows having pages of pageSize rows. Statistics
+// of top level columns and page indexes are written if withPageIndex is set.
+func writeTestFile(numRows, rowGroupSize, pageSize int, withPageIndex bool) ([]byte, error) {
+	schema := testSchema()
+	_, leaves, err := newSchemaTree(schema)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{"id", "name", "address.city", "address.zip", "tags.list.element", "attrs.key_value.key", "attrs.key_value.value"}
+
+	buf := bytes.NewBufferString("PAR1")
+	type chunkIndex struct {
+		columnChunk   *parquet.ColumnChunk
+		columnIndex   *parquet.ColumnIndex
+		pageLocations []*parquet.PageLocation
+	}
+	var chunkIndexes []*chunkIndex
+
+	var rowGroups []*parquet.RowGroup
+	for start := 1; start <= numRows; start += rowGroupSize {
+		end := start + rowGroupSize
+		if end > numRows+1 {
+			end = numRows + 1
+		}
+
+		rowGroup := &parquet.RowGroup{NumRows: int64(end - start)}
+		for _, name := range names {
+			leaf := leaves[name]
+			metadata := &parquet.ColumnMetaData{
+				Type:           leaf.element.GetType(),
+				Encodings:      []parquet.Encoding{parquet.Encoding_PLAIN, parquet.Encoding_RLE},
+				PathInSchema:   strings.Split(name, "."),
+				Codec:          parquet.CompressionCodec_UNCOMPRESSED,
+				DataPageOffset: int64(buf.Len()),
+			}
+			index := &chunkIndex{columnIndex: &parquet.ColumnIndex{}}
+
+			var chunkMin, chunkMax interface{}
+			for pageStart := start; pageStart < end; pageStart += pageSize {
+				pageEnd := pageStart + pageSize
+				if pageEnd > end {
+					pageEnd = end
+				}
+
+				var values []testValue
+				for i := pageStart; i < pageEnd; i++ {
+					values = append(values, testColumnValues(i)[name]...)
+				}
+
+				var repLevels, defLevels []int32
+				var data []byte
+				var pageMin, pageMax interface{}
+				for _, v := range values {
+					repLevels = append(repLevels, v.repLevel)
+					defLevels = append(defLevels, v.defLevel)
+					if v.value == nil {
+						continue
+					}
+
+					data = append(data, encodePlain(v.value)...)
+					if pageMin == nil || lessThan(v.value, pageMin) {
+						pageMin = v.value
+					}
+					if pageMax == nil || lessThan(pageMax, v.value) {
+						pageMax = v.value
+					}
+				}
+
+				if pageMin != nil {
+					if chunkMin == nil || lessThan(pageMin, chunkMin) {
+						chunkMin = pageMin
+					}
+					if chunkMax == nil || lessThan(chunkMax, pageMax) {
+						chunkMax = pageMax
+					}
+					index.columnIndex.NullPages = append(index.columnIndex.NullPages, false)
+					index.columnIndex.MinValues = append(index.columnIndex.MinValues, statData(pageMin))
+					index.columnIndex.MaxValues = append(index.columnIndex.MaxValues, statData(pageMax))
+				} else {
+					index.columnIndex.NullPages = append(index.columnIndex.NullPages, true)
+					index.columnIndex.MinValues = append(index.columnIndex.MinValues, []byte{})
+					index.columnIndex.MaxValues = append(index.columnIndex.MaxValues, []byte{})
+				}
+
+				if leaf.maxRepLevel > 0 {
+					data = append(encodeLevels(defLevels, leaf.maxDefLevel), data...)
+					data = append(encodeLevels(repLevels, leaf.maxRepLevel), data...)
+				} else if leaf.maxDefLevel > 0 {
+					data = append(encodeLevels(defLevels, leaf.maxDefLevel), data...)
+				}
+
+				header := &parquet.PageHeader{
+					Type:                 parquet.PageType_DATA_PAGE,
+					UncompressedPageSize: int32(len(data)),
+					CompressedPageSize:   int32(len(data)),
+					DataPageHeader: &parquet.DataPageHeader{
+						NumValues:               int32(len(values)),
+						Encoding:                parquet.Encoding_PLAIN,
+						DefinitionLevelEncoding: parquet.Encoding_RLE,
+						RepetitionLevelEncoding: parquet.Encoding_RLE,
+					},
+				}
+
+				offset := int64(buf.Len())
+				headerSize, err := writeThrift(buf, header)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(data)
+
+				index.pageLocations = append(index.pageLocations, &parquet.PageLocation{
+					Offset:             offset,
+					CompressedPageSize: int32(headerSize + len(data)),
+					FirstRowIndex:      int64(pageStart - start),
+				})
+				metadata.NumValues += int64(len(values))
+			}
+
+			metadata.TotalCompressedSize = int64(buf.Len()) - metadata.DataPageOffset
+			metadata.TotalUncompressedSize = metadata.TotalCompressedSize
+			if withPageIndex && chunkMin != nil && len(metadata.PathInSchema) == 1 {
+				metadata.Statistics = &parquet.Statistics{
+					MinValue: statData(chunkMin),
+					MaxValue: statData(chunkMax),
+				}
+			}
+
+			index.columnChunk = &parquet.ColumnChunk{
+				FileOffset: metadata.DataPageOffset,
+				MetaData:   metadata,
+			}
+			rowGroup.Columns = append(rowGroup.Columns, index.columnChunk)
+			chunkIndexes = append(chunkIndexes, index)
+		}
+
+		rowGroups = append(rowGroups, rowGroup)
+	}
+
+	if withPageIndex {
+		for _, index := range chunkIndexes {
+			if index.columnChunk.MetaData.Statistics != nil {
+				offset := int64(buf.Len())
+				length, err := writeThrift(buf, index.columnIndex)
+				if err != nil {
+					return nil, err
+				}
+				index.columnChunk.ColumnIndexOffset = &offset
+				index.columnChunk.ColumnIndexLength = thrift.Int32Ptr(int32(length))
+			}
+
+			offset := int64(buf.Len())
+			length, err := writeThrift(buf, &parquet.OffsetIndex{PageLocations: index.pageLocations})
+			if err != nil {
+				return nil, err
+			}
+			index.columnChunk.OffsetIndexOffset = &offset
+			index.columnChunk.OffsetIndexLength = thrift.Int32Ptr(int32(length))
+		}
+	}
+
+	fileMeta := &parquet.FileMetaData{
+		Version:   1,
+		Schema:    schema,
+		NumRows:   int64(numRows),
+		RowGroups: rowGroups,
+	}
+
+	footerOffset := buf.Len()
+	if _, err = writeThrift(buf, fileMeta); err != nil {
+		return nil, err
+	}
+	footerSize := make([]byte, 4)
+	binary.LittleEndian.PutUint32(footerSize, uint32(buf.Len()-footerOffset))
+	buf.Write(footerSize)
+	buf.WriteString("PAR1")
+
+	return buf.Bytes(), nil
+}
+
+// testReader - returns GetReaderFunc of data recording offsets read.
+func testReader(data []byte, offsets *[]int64) GetReaderFunc {
+	return func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset += int64(len(data))
+		}
+		*offsets = append(*offsets, offset)
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}
+}
+
+func toTestJSON(v Value) interface{} {
+	switch value := v.Value.(type) {
+	case []byte:
+		return string(value)
+	case []Value:
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = toTestJSON(value[i])
+		}
+		return values
+	case map[string]Value:
+		values := make(map[string]interface{})
+		for name := range value {
+			values[name] = toTestJSON(value[name])
+		}
+		return values
+	}
+
+	return v.Value
+}
+
+func readTestRecords(file *File) ([]string, error) {
+	var records []string
+	for {
+		record, err := file.Read()
+		if err == io.EOF {
+			return records, file.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]interface{})
+		for name := range record {
+			values[name] = toTestJSON(record[name])
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, string(data))
+	}
+}
+
+func TestReadNestedTypes(t *testing.T) {
+	expectedRecords := []string{
+		`{"address":{"city":"c1","zip":null},"attrs":{"k":1},"id":1,"name":null,"tags":null}`,
+		`{"address":{"city":"c2","zip":2},"attrs":{"k":2},"id":2,"name":"n2","tags":[]}`,
+		`{"address":null,"attrs":{"k":3},"id":3,"name":null,"tags":["a","b"]}`,
+		`{"address":{"city":"c4","zip":4},"attrs":{"k":4},"id":4,"name":"n4","tags":["a",null]}`,
+		`{"address":{"city":"c5","zip":null},"attrs":{"k":5,"l":null},"id":5,"name":null,"tags":["a","b"]}`,
+		`{"address":{"city":"c6","zip":6},"attrs":{"k":6},"id":6,"name":"n6","tags":["a","b"]}`,
+	}
+
+	testCases := []struct {
+		rowGroupSize  int
+		pageSize      int
+		withPageIndex bool
+	}{
+		{6, 6, false},
+		{6, 1, false},
+		{4, 3, false},
+		{2, 1, true},
+	}
+
+	for i, testCase := range testCases {
+		data, err := writeTestFile(6, testCase.rowGroupSize, testCase.pageSize, testCase.withPageIndex)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		var offsets []int64
+		file, err := Open(testReader(data, &offsets), nil, nil)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if names := file.FieldNames(); !reflect.DeepEqual(names, []string{"id", "name", "address", "tags", "attrs"}) {
+			t.Fatalf("case %v: field names: expected: %v, got: %v", i+1, []string{"id", "name", "address", "tags", "attrs"}, names)
+		}
+
+		records, err := readTestRecords(file)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if !reflect.DeepEqual(records, expectedRecords) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedRecords, records)
+		}
+	}
+}
+
+func TestReadWithFilter(t *testing.T) {
+	// idFilter - returns filter of id between min and max.
+	idFilter := func(min, max int64) Filter {
+		return func(stats ColumnStats) bool {
+			minValue, maxValue, ok := stats("id")
+			return !ok || (maxValue.Value.(int64) >= min && minValue.Value.(int64) <= max)
+		}
+	}
+
+	testCases := []struct {
+		rowGroupSize    int
+		pageSize        int
+		columnNames     set.StringSet
+		filter          Filter
+		expectedRecords []string
+		skippedColumns  []string // columns whose first page must not be read.
+	}{
+		// Row groups are skipped.
+		{2, 2, nil, idFilter(5, 6), []string{
+			`{"address":{"city":"c5","zip":null},"attrs":{"k":5,"l":null},"id":5,"name":null,"tags":["a","b"]}`,
+			`{"address":{"city":"c6","zip":6},"attrs":{"k":6},"id":6,"name":"n6","tags":["a","b"]}`,
+		}, []string{"id", "name", "address.city", "tags.list.element", "attrs.key_value.value"}},
+		// Pages are skipped.
+		{6, 1, set.CreateStringSet("id", "tags"), idFilter(3, 4), []string{
+			`{"id":3,"tags":["a","b"]}`,
+			`{"id":4,"tags":["a",null]}`,
+		}, []string{"id", "name", "tags.list.element", "attrs.key_value.key"}},
+		{6, 2, set.CreateStringSet("name"), idFilter(6, 6), []string{
+			`{"name":null}`,
+			`{"name":"n6"}`,
+		}, []string{"id", "name", "address.zip"}},
+		// Nothing is skipped.
+		{3, 1, set.CreateStringSet("id"), idFilter(1, 6), []string{
+			`{"id":1}`, `{"id":2}`, `{"id":3}`, `{"id":4}`, `{"id":5}`, `{"id":6}`,
+		}, []string{"name"}},
+		// Everything is skipped.
+		{3, 1, nil, idFilter(7, 8), nil, []string{"id", "name"}},
+	}
+
+	for i, testCase := range testCases {
+		data, err := writeTestFile(6, testCase.rowGroupSize, testCase.pageSize, true)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		var offsets []int64
+		file, err := Open(testReader(data, &offsets), testCase.columnNames, testCase.filter)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		records, err := readTestRecords(file)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if !reflect.DeepEqual(records, testCase.expectedRecords) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedRecords, records)
+		}
+
+		for _, columnChunk := range file.rowGroups[0].GetColumns() {
+			name := strings.Join(columnChunk.GetMetaData().GetPathInSchema(), ".")
+			if !set.CreateStringSet(testCase.skippedColumns...).Contains(name) {
+				continue
+			}
+
+			for _, offset := range offsets {
+				if offset == columnChunk.GetMetaData().GetDataPageOffset() {
+					t.Fatalf("case %v: first page of column %v is read", i+1, name)
+				}
+			}
+		}
+	}
+}
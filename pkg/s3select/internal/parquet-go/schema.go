@@ -0,0 +1,265 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+// schemaNode - denotes a node of schema tree, leaf nodes are columns.
+type schemaNode struct {
+	element     *parquet.SchemaElement
+	children    []*schemaNode
+	nodes       []*schemaNode // nodes from top level field to this node.
+	maxDefLevel int32
+	maxRepLevel int32
+}
+
+func (node *schemaNode) name() string {
+	return node.element.GetName()
+}
+
+func (node *schemaNode) pathName() string {
+	names := make([]string, len(node.nodes))
+	for i := range node.nodes {
+		names[i] = node.nodes[i].name()
+	}
+
+	return strings.Join(names, ".")
+}
+
+func (node *schemaNode) isLeaf() bool {
+	return node.element.GetNumChildren() == 0
+}
+
+func (node *schemaNode) isRepeated() bool {
+	return node.element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED
+}
+
+func newSchemaNode(elements []*parquet.SchemaElement, index *int, parent *schemaNode, leaves map[string]*schemaNode) (*schemaNode, error) {
+	if *index >= len(elements) {
+		return nil, fmt.Errorf("schema element %v not found", *index)
+	}
+
+	node := &schemaNode{element: elements[*index]}
+	*index++
+
+	if parent != nil {
+		node.nodes = append(append([]*schemaNode{}, parent.nodes...), node)
+		node.maxDefLevel = parent.maxDefLevel
+		node.maxRepLevel = parent.maxRepLevel
+		switch node.element.GetRepetitionType() {
+		case parquet.FieldRepetitionType_OPTIONAL:
+			node.maxDefLevel++
+		case parquet.FieldRepetitionType_REPEATED:
+			node.maxDefLevel++
+			node.maxRepLevel++
+		}
+	}
+
+	for i := int32(0); i < node.element.GetNumChildren(); i++ {
+		child, err := newSchemaNode(elements, index, node, leaves)
+		if err != nil {
+			return nil, err
+		}
+
+		node.children = append(node.children, child)
+	}
+
+	if parent != nil && node.isLeaf() {
+		leaves[node.pathName()] = node
+	}
+
+	return node, nil
+}
+
+// newSchemaTree - returns schema tree of depth first ordered schema elements
+// and its leaf nodes by their path.
+func newSchemaTree(elements []*parquet.SchemaElement) (root *schemaNode, leaves map[string]*schemaNode, err error) {
+	leaves = make(map[string]*schemaNode)
+	index := 0
+	if root, err = newSchemaNode(elements, &index, nil, leaves); err != nil {
+		return nil, nil, err
+	}
+
+	return root, leaves, nil
+}
+
+// assemble - adds values of a row of leaf column to record. Repeated node
+// values are stored as []interface{} and group values are stored as
+// map[string]interface{}, elements of repeated nodes are located by
+// repetition levels and undefined nodes are located by definition levels.
+func assemble(record map[string]interface{}, leaf *schemaNode, values []interface{}, repLevels, defLevels []int32) {
+	indexes := make([]int, len(leaf.nodes))
+	for i := range values {
+		for j, node := range leaf.nodes {
+			if !node.isRepeated() {
+				continue
+			}
+
+			switch {
+			case i == 0 || node.maxRepLevel > repLevels[i]:
+				indexes[j] = 0
+			case node.maxRepLevel == repLevels[i]:
+				indexes[j]++
+			}
+		}
+
+		fields := record
+		for j, node := range leaf.nodes {
+			name := node.name()
+			if defLevels[i] < node.maxDefLevel {
+				if _, found := fields[name]; !found {
+					if node.isRepeated() {
+						fields[name] = []interface{}{}
+					} else {
+						fields[name] = nil
+					}
+				}
+				break
+			}
+
+			isLeaf := j == len(leaf.nodes)-1
+			if node.isRepeated() {
+				list, _ := fields[name].([]interface{})
+				for len(list) <= indexes[j] {
+					if isLeaf {
+						list = append(list, nil)
+					} else {
+						list = append(list, map[string]interface{}{})
+					}
+				}
+				fields[name] = list
+
+				if isLeaf {
+					list[indexes[j]] = values[i]
+				} else {
+					fields = list[indexes[j]].(map[string]interface{})
+				}
+				continue
+			}
+
+			if isLeaf {
+				fields[name] = values[i]
+				continue
+			}
+
+			child, ok := fields[name].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				fields[name] = child
+			}
+			fields = child
+		}
+	}
+}
+
+// isList - returns whether the group is LIST annotated.
+func (node *schemaNode) isList() bool {
+	return len(node.children) == 1 && node.children[0].isRepeated() &&
+		(node.element.GetConvertedType() == parquet.ConvertedType_LIST ||
+			(node.element.LogicalType != nil && node.element.LogicalType.IsSetLIST()))
+}
+
+// isMap - returns whether the group is MAP annotated.
+func (node *schemaNode) isMap() bool {
+	if len(node.children) != 1 || !node.children[0].isRepeated() || len(node.children[0].children) != 2 {
+		return false
+	}
+
+	switch node.element.GetConvertedType() {
+	case parquet.ConvertedType_MAP, parquet.ConvertedType_MAP_KEY_VALUE:
+		return true
+	}
+
+	return node.element.LogicalType != nil && node.element.LogicalType.IsSetMAP()
+}
+
+// fieldValue - returns Value of assembled field value of node.
+func (node *schemaNode) fieldValue(v interface{}) Value {
+	if !node.isRepeated() {
+		return node.elementValue(v)
+	}
+
+	list, _ := v.([]interface{})
+	values := make([]Value, len(list))
+	for i := range list {
+		values[i] = node.elementValue(list[i])
+	}
+
+	return Value{Value: values}
+}
+
+// elementValue - returns Value of assembled single value of node. Values of
+// LIST and MAP annotated groups are []Value and map[string]Value of their
+// elements, values of other groups are map[string]Value of their fields.
+func (node *schemaNode) elementValue(v interface{}) Value {
+	if node.isLeaf() {
+		return Value{v, node.element.GetType()}
+	}
+
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return Value{}
+	}
+
+	switch {
+	case node.isList():
+		repeated := node.children[0]
+		list, _ := fields[repeated.name()].([]interface{})
+		values := make([]Value, len(list))
+		for i := range list {
+			// Element of three level list is the only field of
+			// repeated group. Two level list with repeated group of
+			// a field named 'array' or '<list>_tuple' has the group
+			// as element.
+			if len(repeated.children) == 1 && repeated.name() != "array" && repeated.name() != node.name()+"_tuple" {
+				element := repeated.children[0]
+				elementFields, _ := list[i].(map[string]interface{})
+				values[i] = element.fieldValue(elementFields[element.name()])
+			} else {
+				values[i] = repeated.elementValue(list[i])
+			}
+		}
+		return Value{Value: values}
+
+	case node.isMap():
+		repeated := node.children[0]
+		keyNode, valueNode := repeated.children[0], repeated.children[1]
+		list, _ := fields[repeated.name()].([]interface{})
+		values := make(map[string]Value)
+		for i := range list {
+			keyValueFields, _ := list[i].(map[string]interface{})
+			key := keyNode.fieldValue(keyValueFields[keyNode.name()]).Value
+			if data, ok := key.([]byte); ok {
+				key = string(data)
+			}
+			values[fmt.Sprint(key)] = valueNode.fieldValue(keyValueFields[valueNode.name()])
+		}
+		return Value{Value: values}
+	}
+
+	values := make(map[string]Value)
+	for _, child := range node.children {
+		values[child.name()] = child.fieldValue(fields[child.name()])
+	}
+
+	return Value{Value: values}
+}
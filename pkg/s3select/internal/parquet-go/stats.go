@@ -0,0 +1,305 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"math"
+	"sort"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+// ColumnStats - returns minimum and maximum values of top level column of
+// a set of rows, ok is false if they are unknown.
+type ColumnStats func(name string) (min, max Value, ok bool)
+
+// Filter - returns whether any of the rows having given column statistics
+// may be required. Rows are skipped if it returns false.
+type Filter func(stats ColumnStats) bool
+
+// hasSignedOrder - returns whether statistics of the leaf are comparable
+// in the order of decoded values.
+func (node *schemaNode) hasSignedOrder() bool {
+	switch node.element.GetType() {
+	case parquet.Type_INT32, parquet.Type_INT64:
+		if !node.element.IsSetConvertedType() {
+			return true
+		}
+
+		switch node.element.GetConvertedType() {
+		case parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16,
+			parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64:
+			return false
+		}
+
+		return true
+
+	case parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		return true
+
+	case parquet.Type_BYTE_ARRAY:
+		// Byte arrays are unsigned ordered which is the order of
+		// strings they are read as.
+		if !node.element.IsSetConvertedType() {
+			return true
+		}
+
+		switch node.element.GetConvertedType() {
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON:
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeStatValue - decodes PLAIN encoded minimum or maximum value.
+func (node *schemaNode) decodeStatValue(data []byte) (interface{}, bool) {
+	valueType := node.element.GetType()
+	if valueType == parquet.Type_BYTE_ARRAY {
+		return data, true
+	}
+
+	values, err := readValues(bytes.NewReader(data), valueType, 1, 0)
+	if err != nil {
+		return nil, false
+	}
+
+	switch values := values.(type) {
+	case []int32:
+		return values[0], true
+	case []int64:
+		return values[0], true
+	case []float32:
+		if math.IsNaN(float64(values[0])) {
+			return nil, false
+		}
+		return values[0], true
+	case []float64:
+		if math.IsNaN(values[0]) {
+			return nil, false
+		}
+		return values[0], true
+	}
+
+	return nil, false
+}
+
+// statValues - returns decoded minimum and maximum values of top level
+// non-repeated leaf.
+func (node *schemaNode) statValues(minData, maxData []byte) (min, max Value, ok bool) {
+	if len(node.nodes) != 1 || node.isRepeated() || !node.hasSignedOrder() || minData == nil || maxData == nil {
+		return min, max, false
+	}
+
+	minValue, ok := node.decodeStatValue(minData)
+	if !ok {
+		return min, max, false
+	}
+
+	maxValue, ok := node.decodeStatValue(maxData)
+	if !ok {
+		return min, max, false
+	}
+
+	valueType := node.element.GetType()
+	return Value{minValue, valueType}, Value{maxValue, valueType}, true
+}
+
+// chunkStats - returns minimum and maximum values from column chunk
+// statistics. Deprecated min and max fields are used only for numeric types
+// as they were written in signed order.
+func (node *schemaNode) chunkStats(statistics *parquet.Statistics) (min, max Value, ok bool) {
+	if statistics == nil {
+		return min, max, false
+	}
+
+	if statistics.MinValue != nil && statistics.MaxValue != nil {
+		return node.statValues(statistics.MinValue, statistics.MaxValue)
+	}
+
+	if node.element.GetType() == parquet.Type_BYTE_ARRAY {
+		return min, max, false
+	}
+
+	return node.statValues(statistics.Min, statistics.Max)
+}
+
+// rowRange - denotes rows from start to end, excluding end, of a row group.
+type rowRange struct {
+	start, end int64
+}
+
+type pageIndex struct {
+	columnIndex   *parquet.ColumnIndex
+	pageLocations []*parquet.PageLocation
+}
+
+// pageStats - returns minimum and maximum values of the page containing
+// given row.
+func (node *schemaNode) pageStats(index *pageIndex, rowIndex int64) (min, max Value, ok bool) {
+	i := sort.Search(len(index.pageLocations), func(i int) bool {
+		return index.pageLocations[i].GetFirstRowIndex() > rowIndex
+	}) - 1
+	if i < 0 || index.columnIndex.NullPages[i] {
+		return min, max, false
+	}
+
+	return node.statValues(index.columnIndex.MinValues[i], index.columnIndex.MaxValues[i])
+}
+
+func (file *File) readColumnIndex(columnChunk *parquet.ColumnChunk) (*parquet.ColumnIndex, error) {
+	rc, err := file.getReaderFunc(columnChunk.GetColumnIndexOffset(), int64(columnChunk.GetColumnIndexLength()))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	columnIndex := parquet.NewColumnIndex()
+	if err = columnIndex.Read(thrift.NewTCompactProtocol(thrift.NewStreamTransportR(rc))); err != nil {
+		return nil, err
+	}
+
+	return columnIndex, nil
+}
+
+func (file *File) readOffsetIndex(columnChunk *parquet.ColumnChunk) (*parquet.OffsetIndex, error) {
+	rc, err := file.getReaderFunc(columnChunk.GetOffsetIndexOffset(), int64(columnChunk.GetOffsetIndexLength()))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	offsetIndex := parquet.NewOffsetIndex()
+	if err = offsetIndex.Read(thrift.NewTCompactProtocol(thrift.NewStreamTransportR(rc))); err != nil {
+		return nil, err
+	}
+
+	return offsetIndex, nil
+}
+
+// filterRows - returns ranges of rows of row group to be read. Row group is
+// skipped if filter returns false for its column chunk statistics. Pages are
+// skipped if filter returns false for statistics of pages from column
+// indexes of columns used by the filter.
+func (file *File) filterRows(rowGroup *parquet.RowGroup) ([]rowRange, error) {
+	numRows := rowGroup.GetNumRows()
+	if numRows <= 0 {
+		return nil, nil
+	}
+
+	rowRanges := []rowRange{{0, numRows}}
+	if file.filter == nil {
+		return rowRanges, nil
+	}
+
+	columnChunks := make(map[string]*parquet.ColumnChunk)
+	for _, columnChunk := range rowGroup.GetColumns() {
+		if path := columnChunk.GetMetaData().GetPathInSchema(); len(path) == 1 {
+			columnChunks[path[0]] = columnChunk
+		}
+	}
+
+	names := set.NewStringSet()
+	chunkStats := func(name string) (min, max Value, ok bool) {
+		names.Add(name)
+		columnChunk, found := columnChunks[name]
+		if !found {
+			return min, max, false
+		}
+
+		return file.leaves[name].chunkStats(columnChunk.GetMetaData().GetStatistics())
+	}
+
+	if !file.filter(chunkStats) {
+		return nil, nil
+	}
+
+	pageIndexes := make(map[string]*pageIndex)
+	boundaries := make(map[int64]struct{})
+	firstRowIndexes := []int64{}
+	for name := range names {
+		columnChunk, found := columnChunks[name]
+		if !found || !columnChunk.IsSetColumnIndexOffset() || !columnChunk.IsSetOffsetIndexOffset() {
+			continue
+		}
+
+		columnIndex, err := file.readColumnIndex(columnChunk)
+		if err != nil {
+			return nil, err
+		}
+
+		offsetIndex, err := file.readOffsetIndex(columnChunk)
+		if err != nil {
+			return nil, err
+		}
+
+		pageLocations := offsetIndex.GetPageLocations()
+		if len(pageLocations) == 0 || len(columnIndex.NullPages) != len(pageLocations) ||
+			len(columnIndex.MinValues) != len(pageLocations) || len(columnIndex.MaxValues) != len(pageLocations) {
+			continue
+		}
+
+		pageIndexes[name] = &pageIndex{columnIndex, pageLocations}
+		for _, pageLocation := range pageLocations {
+			if _, found := boundaries[pageLocation.GetFirstRowIndex()]; !found {
+				boundaries[pageLocation.GetFirstRowIndex()] = struct{}{}
+				firstRowIndexes = append(firstRowIndexes, pageLocation.GetFirstRowIndex())
+			}
+		}
+	}
+
+	if len(pageIndexes) == 0 {
+		return rowRanges, nil
+	}
+
+	// Rows between boundaries of pages of all filtered columns have same
+	// page statistics.
+	sort.Slice(firstRowIndexes, func(i, j int) bool { return firstRowIndexes[i] < firstRowIndexes[j] })
+	firstRowIndexes = append(firstRowIndexes, numRows)
+
+	rowRanges = nil
+	for i := 0; i < len(firstRowIndexes)-1; i++ {
+		start, end := firstRowIndexes[i], firstRowIndexes[i+1]
+		if start >= numRows {
+			break
+		}
+
+		pageStats := func(name string) (min, max Value, ok bool) {
+			if index, found := pageIndexes[name]; found {
+				return file.leaves[name].pageStats(index, start)
+			}
+
+			return chunkStats(name)
+		}
+
+		if !file.filter(pageStats) {
+			continue
+		}
+
+		if n := len(rowRanges); n > 0 && rowRanges[n-1].end == start {
+			rowRanges[n-1].end = end
+		} else {
+			rowRanges = append(rowRanges, rowRange{start, end})
+		}
+	}
+
+	return rowRanges, nil
+}
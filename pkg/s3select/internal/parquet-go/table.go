@@ -16,7 +16,7 @@
 
 package parquet
 
-import "github.com/minio/parquet-go/gen-go/parquet"
+import "github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
 
 func getTableValues(values interface{}, valueType parquet.Type) (tableValues []interface{}) {
 	switch valueType {
@@ -122,3 +122,15 @@ func (table *table) Pop(numRows int64) *table {
 
 	return result
 }
+
+// rowEnd - returns index of the value starting second row, -1 if the table
+// has single row or no rows.
+func (table *table) rowEnd() int {
+	for i := 1; i < len(table.RepetitionLevels); i++ {
+		if table.RepetitionLevels[i] == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
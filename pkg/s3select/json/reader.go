@@ -18,6 +18,7 @@ package json
 
 import (
 	"bytes"
+	gojson "encoding/json"
 	"io"
 	"io/ioutil"
 	"strconv"
@@ -27,38 +28,6 @@ import (
 	"github.com/tidwall/sjson"
 )
 
-func toSingleLineJSON(input string, currentKey string, result gjson.Result) (output string, err error) {
-	switch {
-	case result.IsObject():
-		result.ForEach(func(key, value gjson.Result) bool {
-			jsonKey := key.String()
-			if currentKey != "" {
-				jsonKey = currentKey + "." + key.String()
-			}
-			output, err = toSingleLineJSON(input, jsonKey, value)
-			input = output
-			return err == nil
-		})
-	case result.IsArray():
-		i := 0
-		result.ForEach(func(key, value gjson.Result) bool {
-			if currentKey == "" {
-				panic("currentKey is empty")
-			}
-
-			indexKey := currentKey + "." + strconv.Itoa(i)
-			output, err = toSingleLineJSON(input, indexKey, value)
-			input = output
-			i++
-			return err == nil
-		})
-	default:
-		output, err = sjson.Set(input, currentKey, result.Value())
-	}
-
-	return output, err
-}
-
 type objectReader struct {
 	reader io.Reader
 	err    error
@@ -69,7 +38,7 @@ type objectReader struct {
 
 	escaped     bool
 	quoteOpened bool
-	curlyCount  uint64
+	depth       uint64
 	endOfObject bool
 }
 
@@ -87,16 +56,16 @@ func (or *objectReader) objectEndIndex(p []byte, length int) int {
 		or.escaped = false
 
 		switch p[i] {
-		case '{':
+		case '{', '[':
 			if !or.quoteOpened {
-				or.curlyCount++
+				or.depth++
 			}
-		case '}':
-			if or.quoteOpened || or.curlyCount == 0 {
+		case '}', ']':
+			if or.quoteOpened || or.depth == 0 {
 				break
 			}
 
-			if or.curlyCount--; or.curlyCount == 0 {
+			if or.depth--; or.depth == 0 {
 				return i + 1
 			}
 		}
@@ -162,44 +131,113 @@ func (or *objectReader) Reset() error {
 	return or.err
 }
 
+// selectValues - returns the values selected by path from result. The
+// [*] wildcard selects each element of an array, or the value itself if
+// it is not an array.
+func selectValues(result gjson.Result, path sql.JSONPath) []gjson.Result {
+	if len(path) == 0 {
+		return []gjson.Result{result}
+	}
+
+	element := path[0]
+	if element.Wildcard {
+		if !result.IsArray() {
+			return selectValues(result, path[1:])
+		}
+
+		var results []gjson.Result
+		for _, value := range result.Array() {
+			results = append(results, selectValues(value, path[1:])...)
+		}
+		return results
+	}
+
+	var value gjson.Result
+	switch {
+	case element.Key != "" && result.IsObject():
+		value = result.Get(escapeKey(element.Key))
+	case element.Key == "" && result.IsArray():
+		value = result.Get(strconv.Itoa(element.Index))
+	}
+
+	if !value.Exists() {
+		return nil
+	}
+
+	return selectValues(value, path[1:])
+}
+
+// newRecord - creates new record of JSON value, value other than object
+// is stored as column _1.
+func newRecord(value gjson.Result) (*Record, error) {
+	if value.IsObject() {
+		return &Record{data: []byte(value.Raw)}, nil
+	}
+
+	data, err := sjson.SetRawBytes([]byte("{}"), "_1", []byte(value.Raw))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{data: data}, nil
+}
+
 // Reader - JSON record reader for S3Select.
 type Reader struct {
 	args         *ReaderArgs
+	tablePath    sql.JSONPath
 	objectReader *objectReader
 	readCloser   io.ReadCloser
+	records      []*Record
 }
 
-// Read - reads single record.
-func (r *Reader) Read() (sql.Record, error) {
+func (r *Reader) readRecords() error {
 	if err := r.objectReader.Reset(); err != nil {
-		return nil, err
+		return err
 	}
 
 	data, err := ioutil.ReadAll(r.objectReader)
 	if err != nil {
-		return nil, errJSONParsingError(err)
+		return errJSONParsingError(err)
 	}
 
 	data = bytes.TrimSpace(data)
 	if len(data) == 0 {
-		return nil, io.EOF
+		return io.EOF
 	}
 
 	if !gjson.ValidBytes(data) {
-		return nil, errJSONParsingError(err)
+		return errJSONParsingError(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err = gojson.Compact(buf, data); err != nil {
+		return errJSONParsingError(err)
+	}
+	data = buf.Bytes()
+
+	for _, value := range selectValues(gjson.ParseBytes(data), r.tablePath) {
+		record, err := newRecord(value)
+		if err != nil {
+			return errJSONParsingError(err)
+		}
+		r.records = append(r.records, record)
 	}
 
-	if bytes.Count(data, []byte("\n")) > 0 {
-		var s string
-		if s, err = toSingleLineJSON("", "", gjson.ParseBytes(data)); err != nil {
-			return nil, errJSONParsingError(err)
+	return nil
+}
+
+// Read - reads single record.
+func (r *Reader) Read() (sql.Record, error) {
+	for len(r.records) == 0 {
+		if err := r.readRecords(); err != nil {
+			return nil, err
 		}
-		data = []byte(s)
 	}
 
-	return &Record{
-		data: data,
-	}, nil
+	record := r.records[0]
+	r.records = r.records[1:]
+	return record, nil
 }
 
 // Close - closes underlaying reader.
@@ -207,10 +245,12 @@ func (r *Reader) Close() error {
 	return r.readCloser.Close()
 }
 
-// NewReader - creates new JSON reader using readCloser.
-func NewReader(readCloser io.ReadCloser, args *ReaderArgs) *Reader {
+// NewReader - creates new JSON reader using readCloser. Records are the
+// values selected by tablePath from each JSON value of the input.
+func NewReader(readCloser io.ReadCloser, args *ReaderArgs, tablePath sql.JSONPath) *Reader {
 	return &Reader{
 		args:         args,
+		tablePath:    tablePath,
 		objectReader: &objectReader{reader: readCloser},
 		readCloser:   readCloser,
 	}
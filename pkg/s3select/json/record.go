@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/scriptburn/minio/pkg/s3select/sql"
@@ -32,9 +33,43 @@ type Record struct {
 	data []byte
 }
 
-// Get - gets the value for a column name.
+// escapeKey - escapes object key to not be taken as gjson path syntax.
+func escapeKey(key string) string {
+	var sb strings.Builder
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(`\.*?#`, key[i]) >= 0 {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(key[i])
+	}
+
+	return sb.String()
+}
+
+// toGJSONPath - converts JSON path to gjson path.
+func toGJSONPath(path sql.JSONPath) string {
+	parts := make([]string, len(path))
+	for i, element := range path {
+		if element.Key != "" {
+			parts[i] = escapeKey(element.Key)
+		} else {
+			parts[i] = strconv.Itoa(element.Index)
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// Get - gets the value for a column name. The column name is a path to
+// nested value like a.b[0].c, nested object or array is returned as JSON
+// text which is set unquoted to output records.
 func (r *Record) Get(name string) (*sql.Value, error) {
-	result := gjson.GetBytes(r.data, name)
+	path, err := sql.ParseJSONPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := gjson.GetBytes(r.data, toGJSONPath(path))
 	switch result.Type {
 	case gjson.Null:
 		return sql.NewNull(), nil
@@ -46,6 +81,8 @@ func (r *Record) Get(name string) (*sql.Value, error) {
 		return sql.NewString(result.String()), nil
 	case gjson.True:
 		return sql.NewBool(true), nil
+	case gjson.JSON:
+		return sql.NewJSON(result.Raw), nil
 	}
 
 	return nil, fmt.Errorf("unsupported gjson value %v; %v", result, result.Type)
@@ -70,6 +107,11 @@ func (r *Record) Set(name string, value *sql.Value) (err error) {
 	}
 
 	name = strings.Replace(name, "*", "__ALL__", -1)
+	// Nested objects and arrays are kept as JSON.
+	if value.IsJSON() {
+		r.data, err = sjson.SetRawBytes(r.data, name, []byte(value.StringValue()))
+		return err
+	}
 	r.data, err = sjson.SetBytes(r.data, name, v)
 	return err
 }
@@ -109,3 +151,10 @@ func NewRecord() *Record {
 		data: []byte("{}"),
 	}
 }
+
+// NewRawRecord - creates new JSON record of given JSON object data.
+func NewRawRecord(data []byte) *Record {
+	return &Record{
+		data: data,
+	}
+}
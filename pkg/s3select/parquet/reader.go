@@ -17,10 +17,15 @@
 package parquet
 
 import (
+	"bytes"
+	gojson "encoding/json"
+	"fmt"
 	"io"
 
-	parquetgo "github.com/minio/parquet-go"
-	parquetgen "github.com/minio/parquet-go/gen-go/parquet"
+	"github.com/minio/minio-go/pkg/set"
+
+	parquetgo "github.com/scriptburn/minio/pkg/s3select/internal/parquet-go"
+	parquetgen "github.com/scriptburn/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
 	"github.com/scriptburn/minio/pkg/s3select/json"
 	"github.com/scriptburn/minio/pkg/s3select/sql"
 )
@@ -31,6 +36,53 @@ type Reader struct {
 	file *parquetgo.File
 }
 
+// toSQLValue - converts primitive Parquet value to SQL value.
+func toSQLValue(v parquetgo.Value) (*sql.Value, error) {
+	if v.Value == nil {
+		return sql.NewNull(), nil
+	}
+
+	switch v.Type {
+	case parquetgen.Type_BOOLEAN:
+		return sql.NewBool(v.Value.(bool)), nil
+	case parquetgen.Type_INT32:
+		return sql.NewInt(int64(v.Value.(int32))), nil
+	case parquetgen.Type_INT64:
+		return sql.NewInt(v.Value.(int64)), nil
+	case parquetgen.Type_FLOAT:
+		return sql.NewFloat(float64(v.Value.(float32))), nil
+	case parquetgen.Type_DOUBLE:
+		return sql.NewFloat(v.Value.(float64)), nil
+	case parquetgen.Type_INT96, parquetgen.Type_BYTE_ARRAY, parquetgen.Type_FIXED_LEN_BYTE_ARRAY:
+		return sql.NewString(string(v.Value.([]byte))), nil
+	}
+
+	return nil, fmt.Errorf("unsupported parquet value %v of type %v", v.Value, v.Type)
+}
+
+// toJSONValue - converts Parquet value to JSON encodable value, nested
+// groups and lists are converted to objects and arrays.
+func toJSONValue(v parquetgo.Value) interface{} {
+	switch value := v.Value.(type) {
+	case []byte:
+		return string(value)
+	case []parquetgo.Value:
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = toJSONValue(value[i])
+		}
+		return values
+	case map[string]parquetgo.Value:
+		values := make(map[string]interface{})
+		for name := range value {
+			values[name] = toJSONValue(value[name])
+		}
+		return values
+	}
+
+	return v.Value
+}
+
 // Read - reads single record.
 func (r *Reader) Read() (sql.Record, error) {
 	parquetRecord, err := r.file.Read()
@@ -42,40 +94,33 @@ func (r *Reader) Read() (sql.Record, error) {
 		return nil, err
 	}
 
-	record := json.NewRecord()
-	for name, v := range parquetRecord {
-		if v.Value == nil {
-			if err = record.Set(name, sql.NewNull()); err != nil {
-				return nil, errParquetParsingError(err)
-			}
-
+	// Fields are written in schema order.
+	buf := bytes.NewBufferString("{")
+	for _, name := range r.file.FieldNames() {
+		v, found := parquetRecord[name]
+		if !found {
 			continue
 		}
 
-		var value *sql.Value
-		switch v.Type {
-		case parquetgen.Type_BOOLEAN:
-			value = sql.NewBool(v.Value.(bool))
-		case parquetgen.Type_INT32:
-			value = sql.NewInt(int64(v.Value.(int32)))
-		case parquetgen.Type_INT64:
-			value = sql.NewInt(v.Value.(int64))
-		case parquetgen.Type_FLOAT:
-			value = sql.NewFloat(float64(v.Value.(float32)))
-		case parquetgen.Type_DOUBLE:
-			value = sql.NewFloat(v.Value.(float64))
-		case parquetgen.Type_INT96, parquetgen.Type_BYTE_ARRAY, parquetgen.Type_FIXED_LEN_BYTE_ARRAY:
-			value = sql.NewString(string(v.Value.([]byte)))
-		default:
-			return nil, errParquetParsingError(nil)
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
 		}
 
-		if err = record.Set(name, value); err != nil {
+		data, err := gojson.Marshal(name)
+		if err != nil {
 			return nil, errParquetParsingError(err)
 		}
+		buf.Write(data)
+		buf.WriteByte(':')
+
+		if data, err = gojson.Marshal(toJSONValue(v)); err != nil {
+			return nil, errParquetParsingError(err)
+		}
+		buf.Write(data)
 	}
+	buf.WriteByte('}')
 
-	return record, nil
+	return json.NewRawRecord(buf.Bytes()), nil
 }
 
 // Close - closes underlaying readers.
@@ -83,9 +128,33 @@ func (r *Reader) Close() error {
 	return r.file.Close()
 }
 
-// NewReader - creates new Parquet reader using readerFunc callback.
-func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs) (*Reader, error) {
-	file, err := parquetgo.Open(getReaderFunc, nil)
+// NewReader - creates new Parquet reader using readerFunc callback. Only the
+// columns used by statement are read, and row groups and pages are skipped
+// if their column statistics rule out the WHERE clause of statement.
+func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs, statement *sql.Select) (*Reader, error) {
+	var columnNames set.StringSet
+	if names := statement.ColumnNames(); names != nil {
+		columnNames = set.CreateStringSet(names...)
+	}
+
+	filter := func(stats parquetgo.ColumnStats) bool {
+		return statement.MayMatch(func(name string) (min, max *sql.Value, ok bool) {
+			minValue, maxValue, ok := stats(name)
+			if !ok {
+				return nil, nil, false
+			}
+
+			if min, err := toSQLValue(minValue); err == nil {
+				if max, err := toSQLValue(maxValue); err == nil {
+					return min, max, true
+				}
+			}
+
+			return nil, nil, false
+		})
+	}
+
+	file, err := parquetgo.Open(getReaderFunc, columnNames, filter)
 	if err != nil {
 		if err != io.EOF {
 			return nil, errParquetParsingError(err)
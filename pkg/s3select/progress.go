@@ -22,7 +22,10 @@ import (
 	"io"
 	"sync/atomic"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/pierrec/lz4"
 )
 
 type countUpReader struct {
@@ -50,6 +53,7 @@ type progressReader struct {
 	rc              io.ReadCloser
 	scannedReader   *countUpReader
 	processedReader *countUpReader
+	closeFunc       func() // releases resources of decompressor.
 }
 
 func (pr *progressReader) Read(p []byte) (n int, err error) {
@@ -57,6 +61,9 @@ func (pr *progressReader) Read(p []byte) (n int, err error) {
 }
 
 func (pr *progressReader) Close() error {
+	if pr.closeFunc != nil {
+		pr.closeFunc()
+	}
 	return pr.rc.Close()
 }
 
@@ -67,6 +74,7 @@ func (pr *progressReader) Stats() (bytesScanned, bytesProcessed int64) {
 func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressReader, error) {
 	scannedReader := newCountUpReader(rc)
 	var r io.Reader
+	var closeFunc func()
 	var err error
 
 	switch compType {
@@ -78,6 +86,19 @@ func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressRea
 		}
 	case bzip2Type:
 		r = bzip2.NewReader(scannedReader)
+	case zstdType:
+		// Frames are decoded in sequence.
+		decoder, err := zstd.NewReader(scannedReader, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errTruncatedInput(err)
+		}
+		r, closeFunc = decoder, decoder.Close
+	case snappyType:
+		// Snappy framing format.
+		r = snappy.NewReader(scannedReader)
+	case lz4Type:
+		// LZ4 frame format.
+		r = lz4.NewReader(scannedReader)
 	default:
 		return nil, errInvalidCompressionFormat(fmt.Errorf("unknown compression type '%v'", compType))
 	}
@@ -86,5 +107,6 @@ func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressRea
 		rc:              rc,
 		scannedReader:   scannedReader,
 		processedReader: newCountUpReader(r),
+		closeFunc:       closeFunc,
 	}, nil
 }
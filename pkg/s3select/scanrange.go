@@ -0,0 +1,222 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ScanRange - represents elements inside <ScanRange/> in request XML.
+type ScanRange struct {
+	Start *uint64 `xml:"Start"`
+	End   *uint64 `xml:"End"`
+}
+
+// IsEmpty - returns whether scan range is empty or not.
+func (scanRange *ScanRange) IsEmpty() bool {
+	return scanRange.Start == nil && scanRange.End == nil
+}
+
+// Validate - validates the scan range.
+func (scanRange *ScanRange) Validate() error {
+	if scanRange.Start != nil && scanRange.End != nil && *scanRange.Start > *scanRange.End {
+		return errInvalidRequestParameter(fmt.Errorf("ScanRange Start %v is after End %v", *scanRange.Start, *scanRange.End))
+	}
+
+	return nil
+}
+
+// objectSizer - is implemented by object readers which know the size
+// of the object being read.
+type objectSizer interface {
+	ObjectSize() int64
+}
+
+// scanRangeReader - reads the records starting inside [start, end] of
+// an object. A record starts at offset 0 or right after a record
+// delimiter, records starting inside the range are read in full even
+// if they end after the range.
+type scanRangeReader struct {
+	rc     io.ReadCloser
+	reader *bufio.Reader
+	delim  []byte
+	window []byte // last len(delim) bytes read.
+
+	offset int64 // object offset of the next byte to read.
+	start  int64
+	end    int64 // -1 reads till the end of the object.
+
+	aligned bool
+	done    bool
+	pending []byte
+}
+
+func (sr *scanRangeReader) updateWindow(p []byte) {
+	n := len(sr.delim)
+	if len(p) >= n {
+		sr.window = append(sr.window[:0], p[len(p)-n:]...)
+		return
+	}
+
+	sr.window = append(sr.window, p...)
+	if len(sr.window) > n {
+		sr.window = sr.window[len(sr.window)-n:]
+	}
+}
+
+func (sr *scanRangeReader) next() error {
+	p, err := sr.reader.ReadSlice(sr.delim[len(sr.delim)-1])
+	switch err {
+	case nil, bufio.ErrBufferFull:
+	case io.EOF:
+		// Last record may not end with a record delimiter.
+		sr.done = true
+		if sr.aligned {
+			sr.pending = p
+		}
+		return nil
+	default:
+		return err
+	}
+
+	sr.offset += int64(len(p))
+	sr.updateWindow(p)
+	atBoundary := err == nil && bytes.Equal(sr.window, sr.delim)
+
+	if !sr.aligned {
+		// Skip the partial record before the range.
+		if atBoundary && sr.offset >= sr.start {
+			sr.aligned = true
+			sr.done = sr.end >= 0 && sr.offset > sr.end
+		}
+		return nil
+	}
+
+	sr.pending = p
+	if atBoundary && sr.end >= 0 && sr.offset > sr.end {
+		sr.done = true
+	}
+
+	return nil
+}
+
+func (sr *scanRangeReader) Read(p []byte) (n int, err error) {
+	for len(sr.pending) == 0 {
+		if sr.done {
+			return 0, io.EOF
+		}
+
+		if err = sr.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, sr.pending)
+	sr.pending = sr.pending[n:]
+	return n, nil
+}
+
+func (sr *scanRangeReader) Close() error {
+	return sr.rc.Close()
+}
+
+// newScanRangeReader - returns a reader of the records starting inside
+// [start, end] of an object, rc reads the object from given offset.
+func newScanRangeReader(rc io.ReadCloser, offset, start, end int64, recordDelimiter string) *scanRangeReader {
+	return &scanRangeReader{
+		rc:      rc,
+		reader:  bufio.NewReader(rc),
+		delim:   []byte(recordDelimiter),
+		offset:  offset,
+		start:   start,
+		end:     end,
+		aligned: start == 0,
+	}
+}
+
+// openScanRange - opens the object for reading the records of the scan
+// range, a partial record preceding the range is read to find the start
+// of the first record inside the range.
+func openScanRange(getReader func(offset, length int64) (io.ReadCloser, error),
+	scanRange *ScanRange, recordDelimiter string) (*scanRangeReader, error) {
+	delimLen := int64(len(recordDelimiter))
+
+	if scanRange.Start == nil {
+		// Only End is given, scan the last End bytes of the object.
+		length := int64(*scanRange.End)
+		rc, err := getReader(-(length + delimLen), -1)
+		if err != nil {
+			return nil, err
+		}
+
+		// Without the object size, the object is assumed to be
+		// larger than the bytes read.
+		offset, start := int64(0), delimLen
+		if sizer, ok := rc.(objectSizer); ok {
+			size := sizer.ObjectSize()
+			if offset = size - length - delimLen; offset < 0 {
+				offset = 0
+			}
+			if start = size - length; start < 0 {
+				start = 0
+			}
+		}
+
+		return newScanRangeReader(rc, offset, start, -1, recordDelimiter), nil
+	}
+
+	start, end := int64(*scanRange.Start), int64(-1)
+	if scanRange.End != nil {
+		end = int64(*scanRange.End)
+	}
+
+	offset := start - delimLen
+	if offset < 0 {
+		offset = 0
+	}
+
+	rc, err := getReader(offset, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanRangeReader(rc, offset, start, end, recordDelimiter), nil
+}
+
+// headerReadCloser - reads the CSV header row of the object followed by
+// the records of the scan range.
+type headerReadCloser struct {
+	io.Reader
+	header io.Closer
+	rc     io.Closer
+}
+
+func (hrc *headerReadCloser) Close() error {
+	hrc.header.Close()
+	return hrc.rc.Close()
+}
+
+func newHeaderReadCloser(header, rc io.ReadCloser) *headerReadCloser {
+	return &headerReadCloser{
+		Reader: io.MultiReader(header, rc),
+		header: header,
+		rc:     rc,
+	}
+}
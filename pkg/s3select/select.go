@@ -44,9 +44,12 @@ const (
 type CompressionType string
 
 const (
-	noneType  CompressionType = "none"
-	gzipType  CompressionType = "gzip"
-	bzip2Type CompressionType = "bzip2"
+	noneType   CompressionType = "none"
+	gzipType   CompressionType = "gzip"
+	bzip2Type  CompressionType = "bzip2"
+	zstdType   CompressionType = "zstd"
+	snappyType CompressionType = "snappy"
+	lz4Type    CompressionType = "lz4"
 )
 
 // UnmarshalXML - decodes XML data.
@@ -62,7 +65,7 @@ func (c *CompressionType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 	}
 
 	switch parsedType {
-	case noneType, gzipType, bzip2Type:
+	case noneType, gzipType, bzip2Type, zstdType, snappyType, lz4Type:
 	default:
 		return errInvalidCompressionFormat(fmt.Errorf("invalid compression format '%v'", s))
 	}
@@ -177,6 +180,7 @@ type S3Select struct {
 	Input          InputSerialization  `xml:"InputSerialization"`
 	Output         OutputSerialization `xml:"OutputSerialization"`
 	Progress       RequestProgress     `xml:"RequestProgress"`
+	ScanRange      ScanRange           `xml:"ScanRange"`
 
 	statement      *sql.Select
 	progressReader *progressReader
@@ -209,11 +213,30 @@ func (s3Select *S3Select) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 		return errMissingRequiredParameter(fmt.Errorf("OutputSerialization must be provided"))
 	}
 
+	if !parsedS3Select.ScanRange.IsEmpty() {
+		if err := parsedS3Select.ScanRange.Validate(); err != nil {
+			return err
+		}
+
+		input := parsedS3Select.Input
+		if input.CompressionType != noneType && input.CompressionType != "" {
+			return errInvalidRequestParameter(fmt.Errorf("ScanRange is only supported for uncompressed input"))
+		}
+
+		if input.format == parquetFormat || (input.format == jsonFormat && input.JSONArgs.ContentType != "lines") {
+			return errInvalidRequestParameter(fmt.Errorf("ScanRange is only supported for CSV and JSON LINES input"))
+		}
+	}
+
 	statement, err := sql.NewSelect(parsedS3Select.Expression)
 	if err != nil {
 		return err
 	}
 
+	if len(statement.TablePath()) > 0 && parsedS3Select.Input.format != jsonFormat {
+		return errInvalidRequestParameter(fmt.Errorf("path in FROM clause is only supported for JSON input"))
+	}
+
 	parsedS3Select.statement = statement
 
 	*s3Select = S3Select(parsedS3Select)
@@ -239,12 +262,40 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 	return -1, -1
 }
 
+// openCSV - opens S3 object for reading CSV records. For a scan range
+// not starting at the beginning of the object, the header row is read
+// from the beginning of the object before the records of the range.
+func (s3Select *S3Select) openCSV(getReader func(offset, length int64) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if s3Select.ScanRange.IsEmpty() {
+		return getReader(0, -1)
+	}
+
+	args := &s3Select.Input.CSVArgs
+	rc, err := openScanRange(getReader, &s3Select.ScanRange, args.RecordDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.FileHeaderInfo == "none" || rc.start == 0 {
+		return rc, nil
+	}
+
+	headerRC, err := getReader(0, -1)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+
+	header := newScanRangeReader(headerRC, 0, 0, 0, args.RecordDelimiter)
+	return newHeaderReadCloser(header, rc), nil
+}
+
 // Open - opens S3 object by using callback for SQL selection query.
 // Currently CSV, JSON and Apache Parquet formats are supported.
 func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadCloser, error)) error {
 	switch s3Select.Input.format {
 	case csvFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openCSV(getReader)
 		if err != nil {
 			return err
 		}
@@ -261,7 +312,13 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 
 		return nil
 	case jsonFormat:
-		rc, err := getReader(0, -1)
+		var rc io.ReadCloser
+		var err error
+		if s3Select.ScanRange.IsEmpty() {
+			rc, err = getReader(0, -1)
+		} else {
+			rc, err = openScanRange(getReader, &s3Select.ScanRange, "\n")
+		}
 		if err != nil {
 			return err
 		}
@@ -271,11 +328,11 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 			return err
 		}
 
-		s3Select.recordReader = json.NewReader(s3Select.progressReader, &s3Select.Input.JSONArgs)
+		s3Select.recordReader = json.NewReader(s3Select.progressReader, &s3Select.Input.JSONArgs, s3Select.statement.TablePath())
 		return nil
 	case parquetFormat:
 		var err error
-		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs)
+		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs, s3Select.statement)
 		return err
 	}
 
@@ -371,7 +428,11 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 	}
 
 	if err != nil {
-		if serr := writer.SendError("InternalError", err.Error()); serr != nil {
+		errorCode, errorMessage := "InternalError", err.Error()
+		if selectErr, ok := err.(SelectError); ok {
+			errorCode, errorMessage = selectErr.ErrorCode(), selectErr.ErrorMessage()
+		}
+		if serr := writer.SendError(errorCode, errorMessage); serr != nil {
 			// FIXME: log errors.
 		}
 	}
@@ -18,6 +18,8 @@ package s3select
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
 	"go/build"
 	"io"
 	"io/ioutil"
@@ -25,7 +27,12 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
 )
 
 type testResponseWriter struct {
@@ -217,3 +224,347 @@ func TestParquetInput(t *testing.T) {
 		t.Fatalf("received response does not match with expected reply")
 	}
 }
+
+type testObjectReader struct {
+	*bytes.Reader
+	size int64
+}
+
+func (r *testObjectReader) Close() error {
+	return nil
+}
+
+func (r *testObjectReader) ObjectSize() int64 {
+	return r.size
+}
+
+func testGetObjectReader(data []byte) func(offset, length int64) (io.ReadCloser, error) {
+	return func(offset, length int64) (io.ReadCloser, error) {
+		size := int64(len(data))
+		if offset < 0 {
+			if offset += size; offset < 0 {
+				offset = 0
+			}
+		}
+		if offset > size {
+			offset = size
+		}
+		return &testObjectReader{bytes.NewReader(data[offset:]), size}, nil
+	}
+}
+
+// testRecords - returns the payloads of the Records messages of given response.
+func testRecords(t *testing.T, response []byte) string {
+	var records []byte
+	for len(response) > 0 {
+		totalLen := int(binary.BigEndian.Uint32(response[0:4]))
+		headerLen := int(binary.BigEndian.Uint32(response[4:8]))
+		headers := response[12 : 12+headerLen]
+		payload := response[12+headerLen : totalLen-4]
+		if bytes.Contains(headers, []byte("Records")) {
+			records = append(records, payload...)
+		}
+		if bytes.Contains(headers, []byte("error-message")) {
+			t.Fatalf("unexpected error response %q", headers)
+		}
+		response = response[totalLen:]
+	}
+
+	return string(records)
+}
+
+func TestEvaluateError(t *testing.T) {
+	var requestXML = []byte(`
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT CAST(name AS INT) from S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>
+`)
+
+	s3Select, err := NewS3Select(bytes.NewReader(requestXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s3Select.Open(testGetObjectReader([]byte("id,name\n1,a\n"))); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &testResponseWriter{}
+	s3Select.Evaluate(w)
+	s3Select.Close()
+
+	// The error code of the failed evaluation is sent in the error message.
+	if !bytes.Contains(w.response, []byte("CastFailed")) {
+		t.Fatalf("expected CastFailed error, got %q", w.response)
+	}
+}
+
+func TestScanRange(t *testing.T) {
+	requestXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT %s from S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>%s</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>
+`
+	csvInput := `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`
+	csvNoHeaderInput := `<CSV><FileHeaderInfo>NONE</FileHeaderInfo></CSV>`
+	csvCRLFInput := `<CSV><FileHeaderInfo>USE</FileHeaderInfo><RecordDelimiter>&#13;&#10;</RecordDelimiter></CSV>`
+	jsonInput := `<JSON><Type>LINES</Type></JSON>`
+
+	// Records start at offsets 0, 8, 12, 17 and 23.
+	csvData := []byte("id,name\n1,a\n2,bb\n3,ccc\n4,dddd\n")
+	csvCRLFData := []byte("id,name\r\n1,a\r\n2,bb\r\n3,ccc\r\n")
+	// Records start at offsets 0, 9 and 18.
+	jsonData := []byte("{\"id\":1}\n{\"id\":2}\n{\"id\":3}")
+
+	testCases := []struct {
+		columns         string
+		compressionType string
+		input           string
+		scanRange       string
+		data            []byte
+		expectedRecords string
+		expectErr       bool
+	}{
+		{"id", "NONE", csvInput, "<Start>0</Start><End>8</End>", csvData, "1\n", false},
+		{"id", "NONE", csvInput, "<Start>9</Start><End>17</End>", csvData, "2\n3\n", false},
+		{"id", "NONE", csvInput, "<Start>12</Start><End>12</End>", csvData, "2\n", false},
+		{"id", "NONE", csvInput, "<Start>13</Start><End>16</End>", csvData, "", false},
+		{"id", "NONE", csvInput, "<Start>18</Start>", csvData, "4\n", false},
+		{"id", "NONE", csvInput, "<Start>100</Start>", csvData, "", false},
+		{"id", "NONE", csvInput, "<End>7</End>", csvData, "4\n", false},
+		{"id", "NONE", csvInput, "<End>100</End>", csvData, "1\n2\n3\n4\n", false},
+		{"_2", "NONE", csvNoHeaderInput, "<Start>1</Start><End>8</End>", csvData, "a\n", false},
+		{"id", "NONE", csvCRLFInput, "<Start>10</Start>", csvCRLFData, "2\n3\n", false},
+		{"id", "NONE", jsonInput, "<Start>1</Start><End>9</End>", jsonData, "2\n", false},
+		{"id", "NONE", jsonInput, "<Start>9</Start>", jsonData, "2\n3\n", false},
+		{"id", "NONE", csvInput, "<Start>10</Start><End>9</End>", csvData, "", true},
+		{"id", "GZIP", csvInput, "<Start>0</Start>", csvData, "", true},
+		{"id", "NONE", `<JSON><Type>DOCUMENT</Type></JSON>`, "<Start>0</Start>", jsonData, "", true},
+		{"id", "NONE", `<Parquet></Parquet>`, "<Start>0</Start>", jsonData, "", true},
+	}
+
+	for i, testCase := range testCases {
+		request := fmt.Sprintf(requestXML, testCase.columns, testCase.compressionType, testCase.input, testCase.scanRange)
+		s3Select, err := NewS3Select(strings.NewReader(request))
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if err = s3Select.Open(testGetObjectReader(testCase.data)); err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		if records := testRecords(t, w.response); records != testCase.expectedRecords {
+			t.Fatalf("case %v: expected records %q, got %q", i+1, testCase.expectedRecords, records)
+		}
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	requestXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        %s
+    </OutputSerialization>
+</SelectObjectContentRequest>
+`
+	linesInput := `<JSON><Type>LINES</Type></JSON>`
+	documentInput := `<JSON><Type>DOCUMENT</Type></JSON>`
+	csvInput := `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`
+	csvOutput := `<CSV></CSV>`
+	jsonOutput := `<JSON></JSON>`
+
+	linesData := []byte(`{"user":{"name":"a","address":{"city":"x"}},"items":[{"sku":"s1"},{"sku":"s2"}]}
+{"user":{"name":"b","address":{"city":"y"}},"items":[{"sku":"s3"}]}
+`)
+	documentData := []byte(`{
+  "records": [
+    {"id": 1, "tags": ["p", "q"]},
+    {"id": 2, "tags": []}
+  ]
+}
+`)
+	arrayData := []byte(`[{"id": 1}, {"id": 2}, 3]`)
+
+	testCases := []struct {
+		expression      string
+		input           string
+		output          string
+		data            []byte
+		expectedRecords string
+		expectErr       bool
+	}{
+		{"SELECT s.user.address.city FROM S3Object s", linesInput, csvOutput, linesData, "x\ny\n", false},
+		{"SELECT s.items[0].sku FROM S3Object s", linesInput, jsonOutput, linesData, `{"sku":"s1"}` + "\n" + `{"sku":"s3"}` + "\n", false},
+		{"SELECT s.user.name FROM S3Object s WHERE s.items[1].sku = 's2'", linesInput, csvOutput, linesData, "a\n", false},
+		{"SELECT s.user.address.city FROM S3Object s WHERE s.user.address.city LIKE 'y'", linesInput, csvOutput, linesData, "y\n", false},
+		{"SELECT user.address FROM S3Object", linesInput, csvOutput, linesData, `"{""city"":""x""}"` + "\n" + `"{""city"":""y""}"` + "\n", false},
+		{"SELECT user.address FROM S3Object", linesInput, jsonOutput, linesData, `{"address":{"city":"x"}}` + "\n" + `{"address":{"city":"y"}}` + "\n", false},
+		{"SELECT s.tags FROM S3Object[*].records[*] s", documentInput, jsonOutput, documentData, `{"tags":["p","q"]}` + "\n" + `{"tags":[]}` + "\n", false},
+		{"SELECT s.id, s.tags[1] FROM S3Object[*].records[*] s", documentInput, csvOutput, documentData, "1,q\n2,\n", false},
+		{"SELECT * FROM S3Object[*].records[*]", documentInput, jsonOutput, documentData, `{"id":1,"tags":["p","q"]}` + "\n" + `{"id":2,"tags":[]}` + "\n", false},
+		{"SELECT COUNT(*) FROM S3Object[*].items[*]", linesInput, csvOutput, linesData, "3\n", false},
+		{"SELECT * FROM S3Object[*]", documentInput, jsonOutput, arrayData, `{"id":1}` + "\n" + `{"id":2}` + "\n" + `{"_1":3}` + "\n", false},
+		{"SELECT s.items[*].sku FROM S3Object s", linesInput, csvOutput, linesData, "", true},
+		{"SELECT s.items[x] FROM S3Object s", linesInput, csvOutput, linesData, "", true},
+		{"SELECT * FROM S3Object[*].records[*]", csvInput, csvOutput, linesData, "", true},
+	}
+
+	for i, testCase := range testCases {
+		request := fmt.Sprintf(requestXML, testCase.expression, testCase.input, testCase.output)
+		s3Select, err := NewS3Select(strings.NewReader(request))
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if err = s3Select.Open(testGetObjectReader(testCase.data)); err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		if records := testRecords(t, w.response); records != testCase.expectedRecords {
+			t.Fatalf("case %v: expected records %q, got %q", i+1, testCase.expectedRecords, records)
+		}
+	}
+}
+
+func TestCompressedInput(t *testing.T) {
+	requestXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT name FROM S3Object WHERE name != 'a'</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>%s</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>
+`
+	csvInput := `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`
+	jsonInput := `<JSON><Type>LINES</Type></JSON>`
+	csvData := []byte("id,name\n1,a\n2,bb\n3,ccc\n")
+	jsonData := []byte("{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"bb\"}\n{\"id\":3,\"name\":\"ccc\"}\n")
+
+	compress := func(compressionType string, data []byte) []byte {
+		buf := new(bytes.Buffer)
+		var w io.WriteCloser
+		switch compressionType {
+		case "ZSTD":
+			encoder, err := zstd.NewWriter(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w = encoder
+		case "SNAPPY":
+			w = snappy.NewBufferedWriter(buf)
+		case "LZ4":
+			w = lz4.NewWriter(buf)
+		default:
+			return data
+		}
+
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		compressionType string
+		input           string
+		data            []byte
+		expectErr       bool
+	}{
+		{"ZSTD", csvInput, csvData, false},
+		{"zstd", jsonInput, jsonData, false},
+		{"SNAPPY", csvInput, csvData, false},
+		{"SNAPPY", jsonInput, jsonData, false},
+		{"LZ4", csvInput, csvData, false},
+		{"LZ4", jsonInput, jsonData, false},
+		{"XZ", csvInput, csvData, true},
+	}
+
+	for i, testCase := range testCases {
+		request := fmt.Sprintf(requestXML, testCase.compressionType, testCase.input)
+		s3Select, err := NewS3Select(strings.NewReader(request))
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		data := compress(strings.ToUpper(testCase.compressionType), testCase.data)
+		if err = s3Select.Open(testGetObjectReader(data)); err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		if records := testRecords(t, w.response); records != "bb\nccc\n" {
+			t.Fatalf("case %v: expected records %q, got %q", i+1, "bb\nccc\n", records)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // ComparisonOperator - comparison operator.
@@ -78,6 +79,9 @@ func equal(leftValue, rightValue *Value) (bool, error) {
 	switch {
 	case leftValue.Type() == Null && rightValue.Type() == Null:
 		return true, nil
+	case leftValue.Type() == Null || rightValue.Type() == Null:
+		// Missing nested value is not equal to any value.
+		return false, nil
 	case leftValue.Type() == Bool && rightValue.Type() == Bool:
 		return leftValue.BoolValue() == rightValue.BoolValue(), nil
 	case (leftValue.Type() == Int || leftValue.Type() == Float) &&
@@ -99,12 +103,20 @@ type comparisonExpr struct {
 	to       Expr
 	operator ComparisonOperator
 	funcType Type
+
+	likePattern string
+	likeRegexp  *regexp.Regexp
 }
 
 // String - returns string representation of this function.
 func (f *comparisonExpr) String() string {
 	switch f.operator {
-	case Equal, NotEqual, LessThan, GreaterThan, LessThanEqual, GreaterThanEqual, In, Like, NotIn, NotLike:
+	case Like, NotLike:
+		if f.to != nil {
+			return fmt.Sprintf("(%v %v %v ESCAPE %v)", f.left, f.operator, f.right, f.to)
+		}
+		return fmt.Sprintf("(%v %v %v)", f.left, f.operator, f.right)
+	case Equal, NotEqual, LessThan, GreaterThan, LessThanEqual, GreaterThanEqual, In, NotIn:
 		return fmt.Sprintf("(%v %v %v)", f.left, f.operator, f.right)
 	case Between, NotBetween:
 		return fmt.Sprintf("(%v %v %v AND %v)", f.left, f.operator, f.right, f.to)
@@ -263,7 +275,38 @@ func (f *comparisonExpr) notIn(leftValue, rightValue *Value) (*Value, error) {
 	return NewBool(!result), nil
 }
 
-func (f *comparisonExpr) computeLike(leftValue, rightValue *Value) (matched bool, err error) {
+// likeRegexp - converts LIKE pattern to regular expression, '%' matches
+// any sequence of characters and '_' matches any single character unless
+// preceded by the escape character.
+func likeRegexp(pattern string, escape rune) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^(?s:")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("pattern '%v' ends with escape character", pattern)
+	}
+
+	sb.WriteString(")$")
+	return regexp.Compile(sb.String())
+}
+
+func (f *comparisonExpr) computeLike(leftValue, rightValue, escapeValue *Value) (matched bool, err error) {
 	if leftValue.Type() != String {
 		err := fmt.Errorf("%v: left side expression evaluated to %v; not to string", f, leftValue.Type())
 		return false, errExternalEvalException(err)
@@ -273,17 +316,29 @@ func (f *comparisonExpr) computeLike(leftValue, rightValue *Value) (matched bool
 		return false, errExternalEvalException(err)
 	}
 
-	matched, err = regexp.MatchString(rightValue.StringValue(), leftValue.StringValue())
-	if err != nil {
-		err = fmt.Errorf("%v: %v", f, err)
-		return false, errExternalEvalException(err)
+	escape := rune(-1)
+	if escapeValue != nil {
+		if escapeValue.Type() != String || utf8.RuneCountInString(escapeValue.StringValue()) != 1 {
+			err := fmt.Errorf("%v: escape %v must be a single character", f, escapeValue)
+			return false, errLikeInvalidInputs(err)
+		}
+		escape, _ = utf8.DecodeRuneInString(escapeValue.StringValue())
 	}
 
-	return matched, nil
+	pattern := rightValue.StringValue()
+	if f.likePattern != pattern || f.likeRegexp == nil {
+		if f.likeRegexp, err = likeRegexp(pattern, escape); err != nil {
+			err = fmt.Errorf("%v: %v", f, err)
+			return false, errLikeInvalidInputs(err)
+		}
+		f.likePattern = pattern
+	}
+
+	return f.likeRegexp.MatchString(leftValue.StringValue()), nil
 }
 
-func (f *comparisonExpr) like(leftValue, rightValue *Value) (*Value, error) {
-	result, err := f.computeLike(leftValue, rightValue)
+func (f *comparisonExpr) like(leftValue, rightValue, escapeValue *Value) (*Value, error) {
+	result, err := f.computeLike(leftValue, rightValue, escapeValue)
 	if err != nil {
 		return nil, err
 	}
@@ -291,8 +346,8 @@ func (f *comparisonExpr) like(leftValue, rightValue *Value) (*Value, error) {
 	return NewBool(result), nil
 }
 
-func (f *comparisonExpr) notLike(leftValue, rightValue *Value) (*Value, error) {
-	result, err := f.computeLike(leftValue, rightValue)
+func (f *comparisonExpr) notLike(leftValue, rightValue, escapeValue *Value) (*Value, error) {
+	result, err := f.computeLike(leftValue, rightValue, escapeValue)
 	if err != nil {
 		return nil, err
 	}
@@ -319,13 +374,13 @@ func (f *comparisonExpr) compute(leftValue, rightValue, toValue *Value) (*Value,
 	case In:
 		return f.in(leftValue, rightValue)
 	case Like:
-		return f.like(leftValue, rightValue)
+		return f.like(leftValue, rightValue, toValue)
 	case NotBetween:
 		return f.notBetween(leftValue, rightValue, toValue)
 	case NotIn:
 		return f.notIn(leftValue, rightValue)
 	case NotLike:
-		return f.notLike(leftValue, rightValue)
+		return f.notLike(leftValue, rightValue, toValue)
 	}
 
 	panic(fmt.Errorf("unexpected expression %v", f))
@@ -519,8 +574,14 @@ func newComparisonExpr(operator ComparisonOperator, funcs ...Expr) (*comparisonE
 		}, nil
 
 	case Like, NotLike:
-		if len(funcs) != 2 {
-			panic(fmt.Sprintf("exactly two arguments are expected, but found %v", len(funcs)))
+		if len(funcs) != 2 && len(funcs) != 3 {
+			panic(fmt.Sprintf("two or three arguments are expected, but found %v", len(funcs)))
+		}
+
+		// Optional ESCAPE character is kept as third argument.
+		var escape Expr
+		if len(funcs) == 3 {
+			escape = funcs[2]
 		}
 
 		left := funcs[0]
@@ -554,6 +615,7 @@ func newComparisonExpr(operator ComparisonOperator, funcs ...Expr) (*comparisonE
 		return &comparisonExpr{
 			left:     left,
 			right:    right,
+			to:       escape,
 			operator: operator,
 			funcType: funcType,
 		}, nil
@@ -213,3 +213,75 @@ func errInvalidKeyPath(err error) *s3Error {
 		cause:      err,
 	}
 }
+
+func errParseExpectedDatePart(err error) *s3Error {
+	return &s3Error{
+		code:       "ParseExpectedDatePart",
+		message:    "Did not find the expected date part in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errParseExpectedKeyword(err error) *s3Error {
+	return &s3Error{
+		code:       "ParseExpectedKeyword",
+		message:    "Did not find the expected keyword in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errParseExpectedTypeName(err error) *s3Error {
+	return &s3Error{
+		code:       "ParseExpectedTypeName",
+		message:    "Did not find the expected type name in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errParseCastArity(err error) *s3Error {
+	return &s3Error{
+		code:       "ParseCastArity",
+		message:    "The SQL expression CAST has incorrect arity.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errCastFailed(err error) *s3Error {
+	return &s3Error{
+		code:       "CastFailed",
+		message:    "Attempt to convert from one data type to another using CAST failed in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errEvaluatorUnterminatedTimestampFormatPatternToken(err error) *s3Error {
+	return &s3Error{
+		code:       "EvaluatorUnterminatedTimestampFormatPatternToken",
+		message:    "Time stamp format pattern contains unterminated token in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errEvaluatorInvalidTimestampFormatPatternToken(err error) *s3Error {
+	return &s3Error{
+		code:       "EvaluatorInvalidTimestampFormatPatternToken",
+		message:    "Time stamp format pattern contains an invalid token in the SQL expression.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errParseInvalidPathComponent(err error) *s3Error {
+	return &s3Error{
+		code:       "ParseInvalidPathComponent",
+		message:    "The SQL expression contains an invalid path component.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
@@ -128,6 +128,7 @@ func newValueExpr(value *Value) *valueExpr {
 
 type columnExpr struct {
 	name string
+	path JSONPath
 }
 
 func (expr *columnExpr) String() string {
@@ -155,6 +156,6 @@ func (expr *columnExpr) ReturnType() Type {
 	return column
 }
 
-func newColumnExpr(columnName string) *columnExpr {
-	return &columnExpr{name: columnName}
+func newColumnExpr(columnName string, path JSONPath) *columnExpr {
+	return &columnExpr{name: columnName, path: path}
 }
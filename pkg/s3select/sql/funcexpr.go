@@ -18,8 +18,10 @@ package sql
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // FuncName - SQL function name.
@@ -71,13 +73,37 @@ const (
 	// Upper - string SQL function UPPER().
 	Upper FuncName = "UPPER"
 
-	// DateAdd         FuncName = "DATE_ADD"
-	// DateDiff        FuncName = "DATE_DIFF"
-	// Extract         FuncName = "EXTRACT"
-	// ToString        FuncName = "TO_STRING"
-	// Cast            FuncName = "CAST" // CAST('2007-04-05T14:30Z' AS TIMESTAMP)
+	// Cast - conversion SQL function CAST().
+	Cast FuncName = "CAST"
+
+	// ToString - conversion SQL function TO_STRING().
+	ToString FuncName = "TO_STRING"
+
+	// DateAdd - date SQL function DATE_ADD().
+	DateAdd FuncName = "DATE_ADD"
+
+	// DateDiff - date SQL function DATE_DIFF().
+	DateDiff FuncName = "DATE_DIFF"
+
+	// Extract - date SQL function EXTRACT().
+	Extract FuncName = "EXTRACT"
 )
 
+// castTypes - maps type names of CAST() to value types.
+var castTypes = map[string]Type{
+	"BOOL":      Bool,
+	"BOOLEAN":   Bool,
+	"INT":       Int,
+	"INTEGER":   Int,
+	"FLOAT":     Float,
+	"DECIMAL":   Float,
+	"NUMERIC":   Float,
+	"STRING":    String,
+	"VARCHAR":   String,
+	"CHAR":      String,
+	"TIMESTAMP": Timestamp,
+}
+
 func isAggregateFuncName(s string) bool {
 	switch FuncName(s) {
 	case Avg, Count, Max, Min, Sum:
@@ -129,10 +155,25 @@ func callForString(f Expr, record Record) (*Value, error) {
 	return value, nil
 }
 
+func callForTimestamp(f Expr, record Record) (*Value, error) {
+	value, err := f.Eval(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Type() != Timestamp {
+		err := fmt.Errorf("%v evaluated to %v; not to timestamp", f, value.Type())
+		return nil, errExternalEvalException(err)
+	}
+
+	return value, nil
+}
+
 // funcExpr - SQL function.
 type funcExpr struct {
-	args []Expr
-	name FuncName
+	args     []Expr
+	name     FuncName
+	castType Type
 
 	sumValue   float64
 	countValue int64
@@ -181,9 +222,10 @@ func (f *funcExpr) max(record Record) (*Value, error) {
 	}
 
 	v := value.FloatValue()
-	if v > f.maxValue {
+	if f.countValue == 0 || v > f.maxValue {
 		f.maxValue = v
 	}
+	f.countValue++
 
 	return nil, nil
 }
@@ -195,9 +237,11 @@ func (f *funcExpr) min(record Record) (*Value, error) {
 	}
 
 	v := value.FloatValue()
-	if v < f.minValue {
+	if f.countValue == 0 || v < f.minValue {
 		f.minValue = v
 	}
+	f.countValue++
+
 	return nil, nil
 }
 
@@ -207,7 +251,7 @@ func (f *funcExpr) charLength(record Record) (*Value, error) {
 		return nil, err
 	}
 
-	return NewInt(int64(len(value.StringValue()))), nil
+	return NewInt(int64(utf8.RuneCountInString(value.StringValue()))), nil
 }
 
 func (f *funcExpr) trim(record Record) (*Value, error) {
@@ -320,7 +364,7 @@ func (f *funcExpr) toTimeStamp(record Record) (*Value, error) {
 		return nil, err
 	}
 
-	t, err := time.Parse(time.RFC3339, value.StringValue())
+	t, err := parseTimestamp(value.StringValue())
 	if err != nil {
 		err := fmt.Errorf("%v: value '%v': %v", f, value, err)
 		return nil, errValueParseFailure(err)
@@ -333,6 +377,174 @@ func (f *funcExpr) utcNow(record Record) (*Value, error) {
 	return NewTime(time.Now().UTC()), nil
 }
 
+func castToInt(value *Value) (int64, error) {
+	switch value.Type() {
+	case Bool:
+		if value.BoolValue() {
+			return 1, nil
+		}
+		return 0, nil
+	case Int:
+		return value.IntValue(), nil
+	case Float:
+		return int64(value.FloatValue()), nil
+	case String:
+		s := strings.TrimSpace(value.StringValue())
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return int64(f), err
+	}
+
+	return 0, fmt.Errorf("%v cannot be converted to int", value.Type())
+}
+
+func castToFloat(value *Value) (float64, error) {
+	switch value.Type() {
+	case Bool:
+		if value.BoolValue() {
+			return 1, nil
+		}
+		return 0, nil
+	case Int, Float:
+		return value.FloatValue(), nil
+	case String:
+		return strconv.ParseFloat(strings.TrimSpace(value.StringValue()), 64)
+	}
+
+	return 0, fmt.Errorf("%v cannot be converted to float", value.Type())
+}
+
+func castToBool(value *Value) (bool, error) {
+	switch value.Type() {
+	case Bool:
+		return value.BoolValue(), nil
+	case Int, Float:
+		return value.FloatValue() != 0, nil
+	case String:
+		switch strings.ToLower(strings.TrimSpace(value.StringValue())) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return false, fmt.Errorf("'%v' is not a bool", value.StringValue())
+	}
+
+	return false, fmt.Errorf("%v cannot be converted to bool", value.Type())
+}
+
+func castToTimestamp(value *Value) (time.Time, error) {
+	switch value.Type() {
+	case Timestamp:
+		return value.TimeValue(), nil
+	case String:
+		return parseTimestamp(strings.TrimSpace(value.StringValue()))
+	}
+
+	return time.Time{}, fmt.Errorf("%v cannot be converted to timestamp", value.Type())
+}
+
+func (f *funcExpr) cast(record Record) (*Value, error) {
+	value, err := f.args[0].Eval(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Type() == Null {
+		return value, nil
+	}
+
+	var result *Value
+	switch f.castType {
+	case Bool:
+		var b bool
+		b, err = castToBool(value)
+		result = NewBool(b)
+	case Int:
+		var i int64
+		i, err = castToInt(value)
+		result = NewInt(i)
+	case Float:
+		var v float64
+		v, err = castToFloat(value)
+		result = NewFloat(v)
+	case String:
+		result = NewString(value.CSVString())
+	case Timestamp:
+		var t time.Time
+		t, err = castToTimestamp(value)
+		result = NewTime(t)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("%v: value %v: %v", f, value, err)
+		return nil, errCastFailed(err)
+	}
+
+	return result, nil
+}
+
+func (f *funcExpr) extract(record Record) (*Value, error) {
+	value, err := callForTimestamp(f.args[1], record)
+	if err != nil {
+		return nil, err
+	}
+
+	datePart := f.args[0].(*valueExpr).value.StringValue()
+	return NewInt(extractDatePart(datePart, value.TimeValue())), nil
+}
+
+func (f *funcExpr) dateAdd(record Record) (*Value, error) {
+	quantity, err := callForInt(f.args[1], record)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := callForTimestamp(f.args[2], record)
+	if err != nil {
+		return nil, err
+	}
+
+	datePart := f.args[0].(*valueExpr).value.StringValue()
+	return NewTime(addDatePart(datePart, quantity.IntValue(), value.TimeValue())), nil
+}
+
+func (f *funcExpr) dateDiff(record Record) (*Value, error) {
+	value1, err := callForTimestamp(f.args[1], record)
+	if err != nil {
+		return nil, err
+	}
+
+	value2, err := callForTimestamp(f.args[2], record)
+	if err != nil {
+		return nil, err
+	}
+
+	datePart := f.args[0].(*valueExpr).value.StringValue()
+	return NewInt(diffDatePart(datePart, value1.TimeValue(), value2.TimeValue())), nil
+}
+
+func (f *funcExpr) toString(record Record) (*Value, error) {
+	value, err := callForTimestamp(f.args[0], record)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, err := callForString(f.args[1], record)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := formatTimestampPattern(value.TimeValue(), pattern.StringValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewString(s), nil
+}
+
 // Call - evaluates this function for given arg values and returns result as Value.
 func (f *funcExpr) Eval(record Record) (*Value, error) {
 	switch f.name {
@@ -362,6 +574,16 @@ func (f *funcExpr) Eval(record Record) (*Value, error) {
 		return f.lower(record)
 	case Upper:
 		return f.upper(record)
+	case Cast:
+		return f.cast(record)
+	case Extract:
+		return f.extract(record)
+	case DateAdd:
+		return f.dateAdd(record)
+	case DateDiff:
+		return f.dateDiff(record)
+	case ToString:
+		return f.toString(record)
 	}
 
 	panic(fmt.Sprintf("unsupported aggregate function %v", f.name))
@@ -369,6 +591,13 @@ func (f *funcExpr) Eval(record Record) (*Value, error) {
 
 // AggregateValue - returns aggregated value.
 func (f *funcExpr) AggregateValue() (*Value, error) {
+	switch f.name {
+	case Avg, Max, Min:
+		if f.countValue == 0 {
+			return NewNull(), nil
+		}
+	}
+
 	switch f.name {
 	case Avg:
 		return NewFloat(f.sumValue / float64(f.countValue)), nil
@@ -401,12 +630,14 @@ func (f *funcExpr) ReturnType() Type {
 	switch f.name {
 	case Avg, Max, Min, Sum:
 		return Float
-	case Count:
+	case Count, CharLength, CharacterLength, Extract, DateDiff:
 		return Int
-	case CharLength, CharacterLength, Trim, Lower, Upper, Substring:
+	case Trim, Lower, Upper, Substring, ToString:
 		return String
-	case ToTimestamp, UTCNow:
+	case ToTimestamp, UTCNow, DateAdd:
 		return Timestamp
+	case Cast:
+		return f.castType
 	case Coalesce, NullIf:
 		return column
 	}
@@ -540,6 +771,104 @@ func newFuncExpr(funcName FuncName, funcs ...Expr) (*funcExpr, error) {
 			}
 		}
 
+		return &funcExpr{
+			args: funcs,
+			name: funcName,
+		}, nil
+
+	case Cast:
+		if len(funcs) != 2 {
+			err := fmt.Errorf("%v(): exactly one argument expected; got %v", funcName, len(funcs)-1)
+			return nil, errParseCastArity(err)
+		}
+
+		if !funcs[0].ReturnType().isBaseKind() {
+			err := fmt.Errorf("%v(): argument %v evaluate to %v is incompatible", funcName, funcs[0], funcs[0].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		typeName, ok := funcs[1].(*valueExpr)
+		if !ok || typeName.value.Type() != String {
+			return nil, errParseExpectedTypeName(fmt.Errorf("%v(): type name expected; got %v", funcName, funcs[1]))
+		}
+
+		castType, ok := castTypes[strings.ToUpper(typeName.value.StringValue())]
+		if !ok {
+			return nil, errParseExpectedTypeName(fmt.Errorf("%v(): unknown type name %v", funcName, typeName.value.StringValue()))
+		}
+
+		return &funcExpr{
+			args:     funcs,
+			name:     funcName,
+			castType: castType,
+		}, nil
+
+	case Extract:
+		if len(funcs) != 2 {
+			err := fmt.Errorf("%v(): exactly two arguments expected; got %v", funcName, len(funcs))
+			return nil, errEvaluatorInvalidArguments(err)
+		}
+
+		if !isDatePart(funcs[0].(*valueExpr).value.StringValue(), true) {
+			return nil, errParseExpectedDatePart(fmt.Errorf("%v(): invalid date part %v", funcName, funcs[0]))
+		}
+
+		if !funcs[1].ReturnType().isTimestampKind() {
+			err := fmt.Errorf("%v(): argument-2 %v evaluate to %v, not timestamp", funcName, funcs[1], funcs[1].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		return &funcExpr{
+			args: funcs,
+			name: funcName,
+		}, nil
+
+	case DateAdd, DateDiff:
+		if len(funcs) != 3 {
+			err := fmt.Errorf("%v(): exactly three arguments expected; got %v", funcName, len(funcs))
+			return nil, errEvaluatorInvalidArguments(err)
+		}
+
+		if !isDatePart(funcs[0].(*valueExpr).value.StringValue(), false) {
+			return nil, errParseExpectedDatePart(fmt.Errorf("%v(): invalid date part %v", funcName, funcs[0]))
+		}
+
+		if funcName == DateAdd && !funcs[1].ReturnType().isIntKind() {
+			err := fmt.Errorf("%v(): argument-2 %v evaluate to %v, not int", funcName, funcs[1], funcs[1].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		if funcName == DateDiff && !funcs[1].ReturnType().isTimestampKind() {
+			err := fmt.Errorf("%v(): argument-2 %v evaluate to %v, not timestamp", funcName, funcs[1], funcs[1].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		if !funcs[2].ReturnType().isTimestampKind() {
+			err := fmt.Errorf("%v(): argument-3 %v evaluate to %v, not timestamp", funcName, funcs[2], funcs[2].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		return &funcExpr{
+			args: funcs,
+			name: funcName,
+		}, nil
+
+	case ToString:
+		if len(funcs) != 2 {
+			err := fmt.Errorf("%v(): exactly two arguments expected; got %v", funcName, len(funcs))
+			return nil, errEvaluatorInvalidArguments(err)
+		}
+
+		if !funcs[0].ReturnType().isTimestampKind() {
+			err := fmt.Errorf("%v(): argument-1 %v evaluate to %v, not timestamp", funcName, funcs[0], funcs[0].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
+		if !funcs[1].ReturnType().isStringKind() {
+			err := fmt.Errorf("%v(): argument-2 %v evaluate to %v, not string", funcName, funcs[1], funcs[1].ReturnType())
+			return nil, errIncorrectSQLFunctionArgumentType(err)
+		}
+
 		return &funcExpr{
 			args: funcs,
 			name: funcName,
@@ -0,0 +1,194 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testRecord struct {
+	names  []string
+	values map[string]*Value
+}
+
+func (r *testRecord) Get(name string) (*Value, error) {
+	value, ok := r.values[name]
+	if !ok {
+		return nil, fmt.Errorf("column %v not found", name)
+	}
+	return value, nil
+}
+
+func (r *testRecord) Set(name string, value *Value) error {
+	r.names = append(r.names, name)
+	r.values[name] = value
+	return nil
+}
+
+func (r *testRecord) MarshalCSV(fieldDelimiter rune) ([]byte, error) {
+	var data []byte
+	for i, name := range r.names {
+		if i > 0 {
+			data = append(data, string(fieldDelimiter)...)
+		}
+		data = append(data, r.values[name].CSVString()...)
+	}
+	return data, nil
+}
+
+func (r *testRecord) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func newTestRecord(values map[string]*Value) *testRecord {
+	return &testRecord{values: values}
+}
+
+func testEval(query string, input *testRecord) (string, error) {
+	statement, err := NewSelect(query)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := statement.Eval(input, newTestRecord(map[string]*Value{}))
+	if err != nil {
+		return "", err
+	}
+
+	if output == nil {
+		return "<filtered>", nil
+	}
+
+	data, err := output.MarshalCSV(',')
+	return string(data), err
+}
+
+func TestFunctions(t *testing.T) {
+	input := newTestRecord(map[string]*Value{
+		"n":    NewString(" 42 "),
+		"f":    NewString("2.75"),
+		"b":    NewString("TRUE"),
+		"ts":   NewString("2019-01-31T10:20:30.5+05:30"),
+		"name": NewString("hello_world%"),
+		"utf":  NewString("héllo"),
+	})
+
+	testCases := []struct {
+		query          string
+		expectedResult string
+		expectedCode   string
+	}{
+		{"SELECT CAST(n AS INT) FROM S3Object", "42", ""},
+		{"SELECT CAST(f AS INTEGER) FROM S3Object", "2", ""},
+		{"SELECT CAST(f AS FLOAT) FROM S3Object", "2.75", ""},
+		{"SELECT CAST(f AS DECIMAL(10,2)) FROM S3Object", "2.75", ""},
+		{"SELECT CAST(b AS BOOL) FROM S3Object", "true", ""},
+		{"SELECT CAST(CAST(n AS INT) AS STRING) FROM S3Object", "42", ""},
+		{"SELECT CAST(ts AS TIMESTAMP) FROM S3Object", "2019-01-31T10:20:30.5+05:30", ""},
+		{"SELECT CAST('2019T' AS TIMESTAMP) FROM S3Object", "2019-01-01T00:00:00Z", ""},
+		{"SELECT CAST(name AS INT) FROM S3Object", "", "CastFailed"},
+		{"SELECT CAST(n AS BLOB) FROM S3Object", "", "ParseExpectedTypeName"},
+		{"SELECT CAST(n) FROM S3Object", "", "ParseCastArity"},
+		{"SELECT CHARACTER_LENGTH(utf), CHAR_LENGTH(utf) FROM S3Object", "5,5", ""},
+		{"SELECT EXTRACT(YEAR FROM CAST(ts AS TIMESTAMP)) FROM S3Object", "2019", ""},
+		{"SELECT EXTRACT(month FROM CAST(ts AS TIMESTAMP)), EXTRACT(SECOND FROM CAST(ts AS TIMESTAMP)) FROM S3Object", "1,30", ""},
+		{"SELECT EXTRACT(TIMEZONE_HOUR FROM CAST(ts AS TIMESTAMP)), EXTRACT(TIMEZONE_MINUTE FROM CAST(ts AS TIMESTAMP)) FROM S3Object", "5,30", ""},
+		{"SELECT EXTRACT(WEEK FROM CAST(ts AS TIMESTAMP)) FROM S3Object", "", "ParseExpectedDatePart"},
+		{"SELECT EXTRACT(YEAR FROM `2010-01-01T`) FROM S3Object", "2010", ""},
+		{"SELECT DATE_ADD(month, 1, CAST(ts AS TIMESTAMP)) FROM S3Object", "2019-03-03T10:20:30.5+05:30", ""},
+		{"SELECT DATE_ADD(hour, -12, `2010-01-01T`) FROM S3Object", "2009-12-31T12:00:00Z", ""},
+		{"SELECT DATE_ADD(timezone_hour, 1, `2010-01-01T`) FROM S3Object", "", "ParseExpectedDatePart"},
+		{"SELECT DATE_DIFF(year, `2010-01-01T`, `2011-01-01T`) FROM S3Object", "1", ""},
+		{"SELECT DATE_DIFF(year, `2010-06-01T`, `2011-01-01T`) FROM S3Object", "0", ""},
+		{"SELECT DATE_DIFF(month, `2010-01-01T`, `2010-05T`) FROM S3Object", "4", ""},
+		{"SELECT DATE_DIFF(month, `2010-05T`, `2010-01-01T`) FROM S3Object", "-4", ""},
+		{"SELECT DATE_DIFF(day, `2010-01-01T23:00Z`, `2010-01-02T01:00Z`) FROM S3Object", "0", ""},
+		{"SELECT DATE_DIFF(hour, `2010-01-01T23:00Z`, `2010-01-02T01:00Z`) FROM S3Object", "2", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'MMMM d, y') FROM S3Object", "July 20, 1969", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'MMM d, yyyy') FROM S3Object", "Jul 20, 1969", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'M-d-yy') FROM S3Object", "7-20-69", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'MM-d-y') FROM S3Object", "07-20-1969", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'h:m a') FROM S3Object", "8:18 PM", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'y-MM-dd''T''HH:mm:ssXXX') FROM S3Object", "1969-07-20T20:18:00Z", ""},
+		{"SELECT TO_STRING(CAST(ts AS TIMESTAMP), 'HH:mm:ss.SSS xx') FROM S3Object", "10:20:30.500 +0530", ""},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'yyyy q') FROM S3Object", "", "EvaluatorInvalidTimestampFormatPatternToken"},
+		{"SELECT TO_STRING(`1969-07-20T20:18Z`, 'yyyy ''q') FROM S3Object", "", "EvaluatorUnterminatedTimestampFormatPatternToken"},
+		{"SELECT name FROM S3Object WHERE name LIKE 'hello%'", "hello_world%", ""},
+		{"SELECT name FROM S3Object WHERE name LIKE 'h_llo!_%!%' ESCAPE '!'", "hello_world%", ""},
+		{"SELECT name FROM S3Object WHERE name LIKE 'hello!_x%' ESCAPE '!'", "<filtered>", ""},
+		{"SELECT name FROM S3Object WHERE name LIKE 'h.llo%'", "<filtered>", ""},
+		{"SELECT name FROM S3Object WHERE name NOT LIKE '%world'", "hello_world%", ""},
+		{"SELECT name FROM S3Object WHERE name LIKE 'hello!' ESCAPE '!'", "", "LikeInvalidInputs"},
+		{"SELECT name FROM S3Object WHERE name LIKE 'hello' ESCAPE '!!'", "", "LikeInvalidInputs"},
+	}
+
+	for i, testCase := range testCases {
+		result, err := testEval(testCase.query, input)
+		if testCase.expectedCode != "" {
+			serr, ok := err.(*s3Error)
+			if !ok || serr.ErrorCode() != testCase.expectedCode {
+				t.Fatalf("case %v: %v: expected error code %v, got %v", i+1, testCase.query, testCase.expectedCode, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %v: %v: %v", i+1, testCase.query, err)
+		}
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: %v: expected %v, got %v", i+1, testCase.query, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestAggregateFunctions(t *testing.T) {
+	testCases := []struct {
+		query          string
+		values         []string
+		expectedResult string
+	}{
+		{"SELECT AVG(CAST(n AS INT)) FROM S3Object", []string{"1", "2", "6"}, "3"},
+		{"SELECT MIN(CAST(n AS FLOAT)), MAX(CAST(n AS FLOAT)) FROM S3Object", []string{"5", "2.5", "7"}, "2.5,7"},
+		{"SELECT MIN(CAST(n AS INT)), MAX(CAST(n AS INT)) FROM S3Object", []string{"-5", "-2"}, "-5,-2"},
+		{"SELECT AVG(CAST(n AS INT)) FROM S3Object", nil, ""},
+	}
+
+	for i, testCase := range testCases {
+		statement, err := NewSelect(testCase.query)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		for _, value := range testCase.values {
+			input := newTestRecord(map[string]*Value{"n": NewString(value)})
+			if _, err = statement.Eval(input, newTestRecord(map[string]*Value{})); err != nil {
+				t.Fatalf("case %v: %v", i+1, err)
+			}
+		}
+
+		output := newTestRecord(map[string]*Value{})
+		if err = statement.AggregateResult(output); err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if data, _ := output.MarshalCSV(','); string(data) != testCase.expectedResult {
+			t.Fatalf("case %v: expected %v, got %v", i+1, testCase.expectedResult, string(data))
+		}
+	}
+}
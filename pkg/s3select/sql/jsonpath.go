@@ -0,0 +1,142 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathElement - is an element of JSON path, either an object key, an
+// array index or the [*] wildcard.
+type JSONPathElement struct {
+	Key      string
+	Index    int
+	Wildcard bool
+}
+
+// String - returns string representation of this element.
+func (element JSONPathElement) String() string {
+	switch {
+	case element.Wildcard:
+		return "[*]"
+	case element.Key != "":
+		return "." + element.Key
+	}
+
+	return fmt.Sprintf("[%v]", element.Index)
+}
+
+// JSONPath - is a path to nested value of JSON record like a.b[0].c.
+type JSONPath []JSONPathElement
+
+// String - returns string representation of this path.
+func (path JSONPath) String() string {
+	var sb strings.Builder
+	for _, element := range path {
+		sb.WriteString(element.String())
+	}
+
+	return strings.TrimPrefix(sb.String(), ".")
+}
+
+func (path JSONPath) hasWildcard() bool {
+	for _, element := range path {
+		if element.Wildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lastKey - returns the last element of the path if it is an object key.
+func (path JSONPath) lastKey() string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	return path[len(path)-1].Key
+}
+
+// ParseJSONPath - parses path of object keys separated by '.' and array
+// indexes or [*] wildcards enclosed in brackets.
+func ParseJSONPath(s string) (JSONPath, error) {
+	var path JSONPath
+	for i := 0; i < len(s); {
+		if s[i] == '[' {
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, errParseInvalidPathComponent(fmt.Errorf("missing ']' in path %v", s))
+			}
+
+			index := s[i+1 : i+end]
+			if index == "*" {
+				path = append(path, JSONPathElement{Wildcard: true})
+			} else {
+				n, err := strconv.ParseUint(index, 10, 31)
+				if err != nil {
+					return nil, errParseInvalidPathComponent(fmt.Errorf("invalid array index [%v] in path %v", index, s))
+				}
+				path = append(path, JSONPathElement{Index: int(n)})
+			}
+
+			i += end + 1
+			continue
+		}
+
+		if len(path) > 0 {
+			if s[i] != '.' {
+				return nil, errParseInvalidPathComponent(fmt.Errorf("'.' or '[' expected at %v in path %v", i, s))
+			}
+			i++
+		}
+
+		end := i
+		for end < len(s) && s[end] != '.' && s[end] != '[' {
+			end++
+		}
+
+		if end == i {
+			return nil, errParseInvalidPathComponent(fmt.Errorf("empty key at %v in path %v", i, s))
+		}
+
+		path = append(path, JSONPathElement{Key: s[i:end]})
+		i = end
+	}
+
+	return path, nil
+}
+
+// parseTablePath - returns the path following S3Object in FROM clause,
+// S3Object[*].records[*] selects each element of records array as record.
+func parseTablePath(tableName string) (JSONPath, error) {
+	prefix := "s3object"
+	if len(tableName) <= len(prefix) || !strings.EqualFold(tableName[:len(prefix)], prefix) {
+		return nil, nil
+	}
+
+	switch s := tableName[len(prefix):]; s[0] {
+	case '.':
+		return ParseJSONPath(s[1:])
+	case '[':
+		return ParseJSONPath(s)
+	}
+
+	return nil, nil
+}
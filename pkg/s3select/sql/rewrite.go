@@ -0,0 +1,200 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// identEnd - returns the end index of the identifier starting at i.
+func identEnd(sql string, i int) int {
+	for i < len(sql) && isIdentChar(sql[i]) {
+		i++
+	}
+	return i
+}
+
+// pathEnd - returns the end index of the path starting at identifier
+// ending at i, the path has object keys following '.' and array indexes
+// or [*] in brackets.
+func pathEnd(sql string, i int) int {
+	for i < len(sql) {
+		switch {
+		case sql[i] == '.' && i+1 < len(sql) && isIdentStart(sql[i+1]):
+			i = identEnd(sql, i+1)
+		case sql[i] == '[':
+			end := strings.IndexByte(sql[i:], ']')
+			if end < 0 {
+				return i
+			}
+			i += end + 1
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// skipSpaces - returns the index of the first non space character from i.
+func skipSpaces(sql string, i int) int {
+	for i < len(sql) && strings.IndexByte(" \t\r\n", sql[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// quotedEnd - returns the end index of the string quoted by sql[i],
+// the quote character is escaped by doubling it or by a backslash.
+func quotedEnd(sql string, i int) int {
+	quote := sql[i]
+	for i++; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(sql)
+}
+
+type rewriteFrame struct {
+	funcName    FuncName
+	depth       int
+	keywordSeen bool
+}
+
+// rewriteSQL - rewrites S3 Select syntaxes unknown to the MySQL grammar
+// of sqlparser into known syntaxes,
+//
+//	CAST(expr AS type)      => `CAST`(expr, 'type')
+//	EXTRACT(part FROM expr) => EXTRACT(part, expr)
+//	`2019-01-02T`           => TO_TIMESTAMP('2019-01-02T')
+//	s.a[0].b                => `s.a[0].b`
+func rewriteSQL(sql string) (string, error) {
+	var sb strings.Builder
+	var frames []*rewriteFrame
+	depth := 0
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := quotedEnd(sql, i)
+			sb.WriteString(sql[i:end])
+			i = end
+		case c == '`':
+			end := quotedEnd(sql, i)
+			literal := strings.TrimSuffix(sql[i+1:end], "`")
+			if _, err := parseTimestamp(literal); err == nil {
+				fmt.Fprintf(&sb, "%v('%v')", ToTimestamp, literal)
+			} else {
+				// Quoted identifier.
+				sb.WriteString(sql[i:end])
+			}
+			i = end
+		case c == '(':
+			depth++
+			sb.WriteByte(c)
+			i++
+		case c == ')':
+			if n := len(frames); n > 0 && frames[n-1].depth == depth {
+				if frame := frames[n-1]; !frame.keywordSeen {
+					if frame.funcName == Cast {
+						return "", errParseCastArity(fmt.Errorf("AS expected in CAST()"))
+					}
+					return "", errParseExpectedKeyword(fmt.Errorf("FROM expected in %v()", frame.funcName))
+				}
+				frames = frames[:n-1]
+			}
+			depth--
+			sb.WriteByte(c)
+			i++
+		case isIdentStart(c):
+			end := identEnd(sql, i)
+			word := strings.ToUpper(sql[i:end])
+
+			var frame *rewriteFrame
+			if n := len(frames); n > 0 && frames[n-1].depth == depth {
+				frame = frames[n-1]
+			}
+
+			switch {
+			case pathEnd(sql, end) > end:
+				// Paths are quoted to be parsed as single column or
+				// table name.
+				end = pathEnd(sql, end)
+				sb.WriteString("`" + sql[i:end] + "`")
+			case (word == string(Cast) || word == string(Extract)) && skipSpaces(sql, end) < len(sql) && sql[skipSpaces(sql, end)] == '(':
+				frames = append(frames, &rewriteFrame{funcName: FuncName(word), depth: depth + 1})
+				if word == string(Cast) {
+					// CAST is a keyword of sqlparser, quote it to be
+					// parsed as a function name.
+					sb.WriteString("`" + word + "`")
+				} else {
+					sb.WriteString(sql[i:end])
+				}
+			case frame != nil && !frame.keywordSeen && frame.funcName == Cast && word == "AS":
+				frame.keywordSeen = true
+				typeStart := skipSpaces(sql, end)
+				typeEnd := identEnd(sql, typeStart)
+				if typeStart == typeEnd {
+					return "", errParseExpectedTypeName(fmt.Errorf("type name expected after AS in CAST()"))
+				}
+				fmt.Fprintf(&sb, ", '%v'", strings.ToUpper(sql[typeStart:typeEnd]))
+
+				// Precision and scale of DECIMAL(p,s) are ignored.
+				end = typeEnd
+				if next := skipSpaces(sql, end); next < len(sql) && sql[next] == '(' {
+					if closing := strings.IndexByte(sql[next:], ')'); closing >= 0 {
+						end = next + closing + 1
+					}
+				}
+			case frame != nil && !frame.keywordSeen && frame.funcName == Extract && word == "FROM":
+				frame.keywordSeen = true
+				sb.WriteString(",")
+			default:
+				sb.WriteString(sql[i:end])
+			}
+			i = end
+		case c >= '0' && c <= '9':
+			// Skip numbers to not take exponents for identifiers.
+			end := identEnd(sql, i)
+			sb.WriteString(sql[i:end])
+			i = end
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -61,7 +61,16 @@ func newLiteralExpr(parserExpr sqlparser.Expr, tableAlias string) (Expr, error)
 			columnName = strings.TrimPrefix(columnName, tableAlias+".")
 		}
 
-		return newColumnExpr(columnName), nil
+		path, err := ParseJSONPath(columnName)
+		if err != nil {
+			return nil, err
+		}
+
+		if path.hasWildcard() {
+			return nil, errParseInvalidPathComponent(fmt.Errorf("[*] is not allowed in column %v", columnName))
+		}
+
+		return newColumnExpr(columnName, path), nil
 	case sqlparser.ValTuple:
 		var valueType Type
 		var values []*Value
@@ -158,7 +167,21 @@ func toComparisonExpr(parserExpr *sqlparser.ComparisonExpr, tableAlias string, i
 		return nil, err
 	}
 
-	f, err := newComparisonExpr(ComparisonOperator(parserExpr.Operator), leftExpr, rightExpr)
+	funcs := []Expr{leftExpr, rightExpr}
+	if parserExpr.Escape != nil {
+		escapeExpr, err := newExpr(parserExpr.Escape, tableAlias, isSelectExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		if !escapeExpr.Type().isBase() {
+			return nil, errLikeInvalidInputs(fmt.Errorf("ESCAPE %v must be a string literal", escapeExpr))
+		}
+
+		funcs = append(funcs, escapeExpr)
+	}
+
+	f, err := newComparisonExpr(ComparisonOperator(parserExpr.Operator), funcs...)
 	if err != nil {
 		return nil, err
 	}
@@ -203,12 +226,40 @@ func toArithExpr(parserExpr *sqlparser.BinaryExpr, tableAlias string, isSelectEx
 	return newValueExpr(value), nil
 }
 
+// toDatePartExpr - returns date part argument of EXTRACT(), DATE_ADD() and
+// DATE_DIFF() as string value, the date part is parsed as column name.
+func toDatePartExpr(funcName string, selectExpr sqlparser.SelectExpr) (Expr, error) {
+	if aliasedExpr, ok := selectExpr.(*sqlparser.AliasedExpr); ok {
+		if colName, ok := aliasedExpr.Expr.(*sqlparser.ColName); ok && colName.Qualifier.IsEmpty() {
+			return newValueExpr(NewString(colName.Name.Lowered())), nil
+		}
+	}
+
+	return nil, errParseExpectedDatePart(fmt.Errorf("%v(): date part expected; got %v", funcName, sqlparser.String(selectExpr)))
+}
+
 func toFuncExpr(parserExpr *sqlparser.FuncExpr, tableAlias string, isSelectExpr bool) (Expr, error) {
 	funcName := strings.ToUpper(parserExpr.Name.String())
 	if !isSelectExpr && isAggregateFuncName(funcName) {
 		return nil, errUnsupportedSQLOperation(fmt.Errorf("%v() must be used in select expression", funcName))
 	}
-	funcs, aggregatedExprFound, err := newSelectExprs(parserExpr.Exprs, tableAlias)
+
+	parserExprs := parserExpr.Exprs
+	var datePartExpr Expr
+	switch FuncName(funcName) {
+	case Extract, DateAdd, DateDiff:
+		if len(parserExprs) == 0 {
+			return nil, errEvaluatorInvalidArguments(fmt.Errorf("%v(): date part expected", funcName))
+		}
+
+		var err error
+		if datePartExpr, err = toDatePartExpr(funcName, parserExprs[0]); err != nil {
+			return nil, err
+		}
+		parserExprs = parserExprs[1:]
+	}
+
+	funcs, aggregatedExprFound, err := newSelectExprs(parserExprs, tableAlias)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +268,10 @@ func toFuncExpr(parserExpr *sqlparser.FuncExpr, tableAlias string, isSelectExpr
 		return nil, errIncorrectSQLFunctionArgumentType(fmt.Errorf("%v(): aggregated expression must not be used as argument", funcName))
 	}
 
+	if datePartExpr != nil {
+		funcs = append([]Expr{datePartExpr}, funcs...)
+	}
+
 	return newFuncExpr(FuncName(funcName), funcs...)
 }
 
@@ -387,6 +442,7 @@ func newSelectExprs(parserSelectExprs []sqlparser.SelectExpr, tableAlias string)
 type Select struct {
 	tableName           string
 	tableAlias          string
+	tablePath           JSONPath
 	selectExprs         []Expr
 	aggregatedExprFound bool
 	whereExpr           Expr
@@ -397,6 +453,11 @@ func (statement *Select) TableAlias() string {
 	return statement.tableAlias
 }
 
+// TablePath - returns the path following S3Object in FROM clause.
+func (statement *Select) TablePath() JSONPath {
+	return statement.tablePath
+}
+
 // IsSelectAll - returns whether '*' is used in select expression or not.
 func (statement *Select) IsSelectAll() bool {
 	if len(statement.selectExprs) == 1 {
@@ -476,7 +537,10 @@ func (statement *Select) Eval(input, output Record) (Record, error) {
 		case *aliasExpr:
 			name = expr.(*aliasExpr).alias
 		case *columnExpr:
-			name = expr.(*columnExpr).name
+			// Nested value is named by the last key of its path.
+			if key := expr.(*columnExpr).path.lastKey(); key != "" {
+				name = key
+			}
 		}
 
 		if err = output.Set(name, value); err != nil {
@@ -489,6 +553,11 @@ func (statement *Select) Eval(input, output Record) (Record, error) {
 
 // NewSelect - creates new Select by parsing sql.
 func NewSelect(sql string) (*Select, error) {
+	sql, err := rewriteSQL(sql)
+	if err != nil {
+		return nil, err
+	}
+
 	stmt, err := sqlparser.Parse(sql)
 	if err != nil {
 		return nil, errUnsupportedSQLStructure(err)
@@ -506,6 +575,11 @@ func NewSelect(sql string) (*Select, error) {
 		tableAlias = tableExpr.As.String()
 	}
 
+	tablePath, err := parseTablePath(tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	selectExprs, aggregatedExprFound, err := newSelectExprs(selectStmt.SelectExprs, tableAlias)
 	if err != nil {
 		return nil, err
@@ -522,6 +596,7 @@ func NewSelect(sql string) (*Select, error) {
 	return &Select{
 		tableName:           tableName,
 		tableAlias:          tableAlias,
+		tablePath:           tablePath,
 		selectExprs:         selectExprs,
 		aggregatedExprFound: aggregatedExprFound,
 		whereExpr:           whereExpr,
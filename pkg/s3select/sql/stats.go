@@ -0,0 +1,228 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"sort"
+	"strings"
+)
+
+// ColumnStats - returns minimum and maximum values of top level column of
+// a set of records, ok is false if they are unknown.
+type ColumnStats func(name string) (min, max *Value, ok bool)
+
+// compareStats - compares two values of same kind, ok is false if they are
+// not comparable.
+func compareStats(v1, v2 *Value) (result int, ok bool) {
+	switch {
+	case v1.Type().isNumber() && v2.Type().isNumber():
+		f1, f2 := v1.FloatValue(), v2.FloatValue()
+		switch {
+		case f1 < f2:
+			return -1, true
+		case f1 > f2:
+			return 1, true
+		}
+		return 0, true
+
+	case v1.Type() == String && v2.Type() == String:
+		return strings.Compare(v1.StringValue(), v2.StringValue()), true
+	}
+
+	return 0, false
+}
+
+// inRange - returns whether value may be between min and max.
+func inRange(value, min, max *Value) bool {
+	c1, ok1 := compareStats(min, value)
+	c2, ok2 := compareStats(value, max)
+	return !ok1 || !ok2 || (c1 <= 0 && c2 <= 0)
+}
+
+// mayMatch - returns whether column compared to values of this comparison
+// may be true for any value between column's min and max.
+func (f *comparisonExpr) mayMatch(stats ColumnStats) bool {
+	operator := f.operator
+	left, right := f.left, f.right
+	if _, ok := left.(*valueExpr); ok {
+		// Literal on left side is compared from column's side.
+		switch operator {
+		case Equal:
+		case LessThan:
+			operator = GreaterThan
+		case GreaterThan:
+			operator = LessThan
+		case LessThanEqual:
+			operator = GreaterThanEqual
+		case GreaterThanEqual:
+			operator = LessThanEqual
+		default:
+			return true
+		}
+		left, right = right, left
+	}
+
+	column, ok := left.(*columnExpr)
+	if !ok || len(column.path) != 1 || column.path[0].Key == "" {
+		return true
+	}
+
+	literal, ok := right.(*valueExpr)
+	if !ok {
+		return true
+	}
+	value := literal.value
+
+	min, max, ok := stats(column.path[0].Key)
+	if !ok {
+		return true
+	}
+
+	switch operator {
+	case Equal:
+		return inRange(value, min, max)
+
+	case In:
+		if value.Type() != Array {
+			return true
+		}
+
+		for _, v := range value.ArrayValue() {
+			if inRange(v, min, max) {
+				return true
+			}
+		}
+		return false
+
+	case Between:
+		to, ok := f.to.(*valueExpr)
+		if !ok {
+			return true
+		}
+
+		c1, ok1 := compareStats(max, value)
+		c2, ok2 := compareStats(min, to.value)
+		return !ok1 || !ok2 || (c1 >= 0 && c2 <= 0)
+	}
+
+	// Ordering comparisons are done on numbers only.
+	if !value.Type().isNumber() || !min.Type().isNumber() || !max.Type().isNumber() {
+		return true
+	}
+
+	switch operator {
+	case LessThan:
+		return min.FloatValue() < value.FloatValue()
+	case LessThanEqual:
+		return min.FloatValue() <= value.FloatValue()
+	case GreaterThan:
+		return max.FloatValue() > value.FloatValue()
+	case GreaterThanEqual:
+		return max.FloatValue() >= value.FloatValue()
+	}
+
+	return true
+}
+
+// mayMatch - returns whether expr may evaluate to true for any record
+// having column values between given min and max.
+func mayMatch(expr Expr, stats ColumnStats) bool {
+	switch expr := expr.(type) {
+	case *andExpr:
+		// Both sides are checked to request statistics of all columns.
+		left, right := mayMatch(expr.left, stats), mayMatch(expr.right, stats)
+		return left && right
+	case *orExpr:
+		left, right := mayMatch(expr.left, stats), mayMatch(expr.right, stats)
+		return left || right
+	case *comparisonExpr:
+		return expr.mayMatch(stats)
+	}
+
+	return true
+}
+
+// columnNames - adds top level names of columns used in expr.
+func columnNames(expr Expr, names map[string]struct{}) bool {
+	switch expr := expr.(type) {
+	case *starExpr:
+		return false
+	case *columnExpr:
+		if len(expr.path) == 0 || expr.path[0].Key == "" {
+			return false
+		}
+		names[expr.path[0].Key] = struct{}{}
+	case *aliasExpr:
+		return columnNames(expr.expr, names)
+	case *funcExpr:
+		for _, arg := range expr.args {
+			// COUNT(*) does not use any column.
+			if _, ok := arg.(*starExpr); ok && expr.name == Count {
+				continue
+			}
+			if !columnNames(arg, names) {
+				return false
+			}
+		}
+	case *comparisonExpr:
+		return columnNames(expr.left, names) && columnNames(expr.right, names) &&
+			(expr.to == nil || columnNames(expr.to, names))
+	case *arithExpr:
+		return columnNames(expr.left, names) && columnNames(expr.right, names)
+	case *andExpr:
+		return columnNames(expr.left, names) && columnNames(expr.right, names)
+	case *orExpr:
+		return columnNames(expr.left, names) && columnNames(expr.right, names)
+	case *notExpr:
+		return columnNames(expr.right, names)
+	}
+
+	return true
+}
+
+// ColumnNames - returns top level names of columns used in this statement,
+// nil is returned if all columns are used.
+func (statement *Select) ColumnNames() []string {
+	names := make(map[string]struct{})
+	for _, expr := range statement.selectExprs {
+		if !columnNames(expr, names) {
+			return nil
+		}
+	}
+
+	if statement.whereExpr != nil && !columnNames(statement.whereExpr, names) {
+		return nil
+	}
+
+	columns := []string{}
+	for name := range names {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// MayMatch - returns whether WHERE expression may be true for any record
+// having column values between minimum and maximum from given statistics.
+func (statement *Select) MayMatch(stats ColumnStats) bool {
+	if statement.whereExpr == nil {
+		return true
+	}
+
+	return mayMatch(statement.whereExpr, stats)
+}
@@ -0,0 +1,102 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnNames(t *testing.T) {
+	testCases := []struct {
+		query         string
+		expectedNames []string
+	}{
+		{"SELECT * FROM S3Object", nil},
+		{"SELECT * FROM S3Object WHERE a > 1", nil},
+		{"SELECT a, b.c FROM S3Object", []string{"a", "b"}},
+		{"SELECT s.a AS x FROM S3Object s WHERE s.d.e = 'f' AND (s.g < 2 OR s.h IS NULL)", []string{"a", "d", "g", "h"}},
+		{"SELECT COUNT(*) FROM S3Object", []string{}},
+		{"SELECT SUM(a + b) FROM S3Object WHERE c BETWEEN 1 AND 2", []string{"a", "b", "c"}},
+		{"SELECT UPPER(a) FROM S3Object WHERE NOT b IN (1, 2)", []string{"a", "b"}},
+	}
+
+	for i, testCase := range testCases {
+		statement, err := NewSelect(testCase.query)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if names := statement.ColumnNames(); !reflect.DeepEqual(names, testCase.expectedNames) {
+			t.Fatalf("case %v: expected: %#v, got: %#v", i+1, testCase.expectedNames, names)
+		}
+	}
+}
+
+func TestMayMatch(t *testing.T) {
+	// Column a is between 10 and 20 and column s is between "b" and "d".
+	stats := func(name string) (min, max *Value, ok bool) {
+		switch name {
+		case "a":
+			return NewInt(10), NewInt(20), true
+		case "s":
+			return NewString("b"), NewString("d"), true
+		}
+		return nil, nil, false
+	}
+
+	testCases := []struct {
+		where    string
+		expected bool
+	}{
+		{"a = 15", true},
+		{"a = 21", false},
+		{"a = 9.5", false},
+		{"5 = a", false},
+		{"a < 10", false},
+		{"a <= 10", true},
+		{"a > 20", false},
+		{"a >= 20", true},
+		{"25 > a", true},
+		{"25 < a", false},
+		{"a BETWEEN 1 AND 9", false},
+		{"a BETWEEN 1 AND 10", true},
+		{"a IN (1, 2, 3)", false},
+		{"a IN (1, 12)", true},
+		{"s = 'c'", true},
+		{"s = 'e'", false},
+		{"a > 20 AND s = 'c'", false},
+		{"a > 20 OR s = 'c'", true},
+		{"a > 20 OR s = 'e'", false},
+		{"NOT a > 20", true},
+		{"a != 15", true},
+		{"a + 1 > 30", true},
+		{"x = 1", true},
+		{"a = 'x'", true},
+	}
+
+	for i, testCase := range testCases {
+		statement, err := NewSelect("SELECT * FROM S3Object WHERE " + testCase.where)
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		if result := statement.MayMatch(stats); result != testCase.expected {
+			t.Fatalf("case %v: %v: expected: %v, got: %v", i+1, testCase.where, testCase.expected, result)
+		}
+	}
+}
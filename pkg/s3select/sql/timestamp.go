@@ -0,0 +1,279 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time stamp formats supported by S3 Select, time stamps have at least
+// a year and time stamps without time zone are in UTC.
+var timestampLayouts = []string{
+	"2006T",
+	"2006-01T",
+	"2006-01-02",
+	"2006-01-02T",
+	"2006-01-02T15:04Z07:00",
+	time.RFC3339Nano,
+}
+
+// parseTimestamp - parses S3 Select time stamp string.
+func parseTimestamp(s string) (t time.Time, err error) {
+	for _, layout := range timestampLayouts {
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return t, err
+}
+
+// formatTimestamp - formats time stamp in RFC3339 format, time stamps
+// are written in CSV and JSON output in this format.
+func formatTimestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// Date parts used in EXTRACT(), DATE_ADD() and DATE_DIFF().
+const (
+	yearPart           = "year"
+	monthPart          = "month"
+	dayPart            = "day"
+	hourPart           = "hour"
+	minutePart         = "minute"
+	secondPart         = "second"
+	timezoneHourPart   = "timezone_hour"
+	timezoneMinutePart = "timezone_minute"
+)
+
+// isDatePart - returns whether s is a date part of DATE_ADD() and
+// DATE_DIFF(), EXTRACT() also accepts time zone date parts.
+func isDatePart(s string, timezoneAllowed bool) bool {
+	switch s {
+	case yearPart, monthPart, dayPart, hourPart, minutePart, secondPart:
+		return true
+	case timezoneHourPart, timezoneMinutePart:
+		return timezoneAllowed
+	}
+
+	return false
+}
+
+func extractDatePart(datePart string, t time.Time) int64 {
+	_, offset := t.Zone()
+	switch datePart {
+	case yearPart:
+		return int64(t.Year())
+	case monthPart:
+		return int64(t.Month())
+	case dayPart:
+		return int64(t.Day())
+	case hourPart:
+		return int64(t.Hour())
+	case minutePart:
+		return int64(t.Minute())
+	case secondPart:
+		return int64(t.Second())
+	case timezoneHourPart:
+		return int64(offset / 3600)
+	case timezoneMinutePart:
+		return int64((offset % 3600) / 60)
+	}
+
+	panic(fmt.Sprintf("unknown date part %v", datePart))
+}
+
+func addDatePart(datePart string, quantity int64, t time.Time) time.Time {
+	switch datePart {
+	case yearPart:
+		return t.AddDate(int(quantity), 0, 0)
+	case monthPart:
+		return t.AddDate(0, int(quantity), 0)
+	case dayPart:
+		return t.AddDate(0, 0, int(quantity))
+	case hourPart:
+		return t.Add(time.Duration(quantity) * time.Hour)
+	case minutePart:
+		return t.Add(time.Duration(quantity) * time.Minute)
+	case secondPart:
+		return t.Add(time.Duration(quantity) * time.Second)
+	}
+
+	panic(fmt.Sprintf("unknown date part %v", datePart))
+}
+
+// diffDatePart - returns the number of whole date parts between t1 and t2,
+// the difference is negative if t2 is before t1.
+func diffDatePart(datePart string, t1, t2 time.Time) int64 {
+	if t2.Before(t1) {
+		return -diffDatePart(datePart, t2, t1)
+	}
+
+	switch datePart {
+	case yearPart, monthPart:
+		t2 = t2.In(t1.Location())
+		months := int64(t2.Year()-t1.Year())*12 + int64(t2.Month()-t1.Month())
+		if t1.AddDate(0, int(months), 0).After(t2) {
+			months--
+		}
+		if datePart == yearPart {
+			return months / 12
+		}
+		return months
+	case dayPart:
+		return int64(t2.Sub(t1) / (24 * time.Hour))
+	case hourPart:
+		return int64(t2.Sub(t1) / time.Hour)
+	case minutePart:
+		return int64(t2.Sub(t1) / time.Minute)
+	case secondPart:
+		return int64(t2.Sub(t1) / time.Second)
+	}
+
+	panic(fmt.Sprintf("unknown date part %v", datePart))
+}
+
+func formatZoneOffset(offset int, zulu, withMinutes, withColon bool) string {
+	if offset == 0 && zulu {
+		return "Z"
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	hours, minutes := offset/3600, (offset%3600)/60
+	s := fmt.Sprintf("%c%02d", sign, hours)
+	if !withMinutes && minutes == 0 {
+		return s
+	}
+
+	if withColon {
+		s += ":"
+	}
+
+	return s + fmt.Sprintf("%02d", minutes)
+}
+
+// formatTimestampToken - formats time stamp field of given pattern
+// letter repeated count times.
+func formatTimestampToken(t time.Time, letter byte, count int) (string, bool) {
+	pad := func(i, width int) string {
+		return fmt.Sprintf("%0*d", width, i)
+	}
+
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	_, offset := t.Zone()
+	switch {
+	case letter == 'y' && count == 1:
+		return strconv.Itoa(t.Year()), true
+	case letter == 'y' && count == 2:
+		return pad(t.Year()%100, 2), true
+	case letter == 'y' && count <= 4:
+		return pad(t.Year(), count), true
+	case letter == 'M' && count <= 2:
+		return pad(int(t.Month()), count), true
+	case letter == 'M' && count == 3:
+		return t.Month().String()[:3], true
+	case letter == 'M' && count == 4:
+		return t.Month().String(), true
+	case letter == 'M' && count == 5:
+		return t.Month().String()[:1], true
+	case letter == 'd' && count <= 2:
+		return pad(t.Day(), count), true
+	case letter == 'a' && count == 1:
+		if t.Hour() < 12 {
+			return "AM", true
+		}
+		return "PM", true
+	case letter == 'h' && count <= 2:
+		return pad(hour12, count), true
+	case letter == 'H' && count <= 2:
+		return pad(t.Hour(), count), true
+	case letter == 'm' && count <= 2:
+		return pad(t.Minute(), count), true
+	case letter == 's' && count <= 2:
+		return pad(t.Second(), count), true
+	case letter == 'S' && count <= 9:
+		return pad(t.Nanosecond(), 9)[:count], true
+	case letter == 'n' && count == 1:
+		return strconv.Itoa(t.Nanosecond()), true
+	case letter == 'X' && count <= 5:
+		return formatZoneOffset(offset, true, count > 1, count == 3 || count == 5), true
+	case letter == 'x' && count <= 5:
+		return formatZoneOffset(offset, false, count > 1, count == 3 || count == 5), true
+	}
+
+	return "", false
+}
+
+func isPatternLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// formatTimestampPattern - formats time stamp as per TO_STRING() format
+// pattern. Text in single quotes is copied as is, two single quotes
+// represent a single quote.
+func formatTimestampPattern(t time.Time, pattern string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i += 2
+				continue
+			}
+
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				err := fmt.Errorf("unterminated quoted text in pattern '%v'", pattern)
+				return "", errEvaluatorUnterminatedTimestampFormatPatternToken(err)
+			}
+			sb.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+		case isPatternLetter(c):
+			count := 1
+			for i+count < len(pattern) && pattern[i+count] == c {
+				count++
+			}
+
+			s, ok := formatTimestampToken(t, c, count)
+			if !ok {
+				err := fmt.Errorf("invalid token '%v' in pattern '%v'", pattern[i:i+count], pattern)
+				return "", errEvaluatorInvalidTimestampFormatPatternToken(err)
+			}
+			sb.WriteString(s)
+			i += count
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -116,3 +116,12 @@ func (t Type) isStringKind() bool {
 
 	return false
 }
+
+func (t Type) isTimestampKind() bool {
+	switch t {
+	case Timestamp, column:
+		return true
+	}
+
+	return false
+}
@@ -32,6 +32,15 @@ type Value struct {
 	valueType Type
 }
 
+// jsonText - is the JSON text of a nested object or array, it is used as
+// a string in expressions and is encoded to JSON as is.
+type jsonText string
+
+// MarshalJSON - returns the JSON text as is.
+func (text jsonText) MarshalJSON() ([]byte, error) {
+	return []byte(text), nil
+}
+
 // String - represents value as string.
 func (value *Value) String() string {
 	if value.value == nil {
@@ -58,8 +67,11 @@ func (value *Value) String() string {
 
 // CSVString - encodes to CSV string.
 func (value *Value) CSVString() string {
-	if value.valueType == Null {
+	switch value.valueType {
+	case Null:
 		return ""
+	case Timestamp:
+		return formatTimestamp(value.TimeValue())
 	}
 
 	return fmt.Sprintf("%v", value.value)
@@ -112,6 +124,9 @@ func (value *Value) FloatValue() float64 {
 // StringValue - returns underlying string value. It panics if value is not String type.
 func (value *Value) StringValue() string {
 	if value.valueType == String {
+		if text, ok := value.value.(jsonText); ok {
+			return string(text)
+		}
 		return value.value.(string)
 	}
 
@@ -144,6 +159,12 @@ func (value *Value) recordValue() Record {
 	panic(fmt.Sprintf("requested record value but found %T type", value.value))
 }
 
+// IsJSON - returns whether value is the JSON text of a nested object or array.
+func (value *Value) IsJSON() bool {
+	_, ok := value.value.(jsonText)
+	return ok
+}
+
 // Type - returns value type.
 func (value *Value) Type() Type {
 	return value.valueType
@@ -179,6 +200,12 @@ func NewString(s string) *Value {
 	return &Value{s, String}
 }
 
+// NewJSON - creates new String value of the JSON text s of a nested
+// object or array.
+func NewJSON(s string) *Value {
+	return &Value{jsonText(s), String}
+}
+
 // NewTime - creates new Time value of t.
 func NewTime(t time.Time) *Value {
 	return &Value{t, Timestamp}
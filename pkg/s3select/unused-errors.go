@@ -190,24 +190,6 @@ func errLexerInvalidIONLiteral(err error) *s3Error {
 	}
 }
 
-func errParseExpectedDatePart(err error) *s3Error {
-	return &s3Error{
-		code:       "ParseExpectedDatePart",
-		message:    "Did not find the expected date part in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
-func errParseExpectedKeyword(err error) *s3Error {
-	return &s3Error{
-		code:       "ParseExpectedKeyword",
-		message:    "Did not find the expected keyword in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errParseExpectedTokenType(err error) *s3Error {
 	return &s3Error{
 		code:       "ParseExpectedTokenType",
@@ -244,15 +226,6 @@ func errParseExpectedRightParenBuiltinFunctionCall(err error) *s3Error {
 	}
 }
 
-func errParseExpectedTypeName(err error) *s3Error {
-	return &s3Error{
-		code:       "ParseExpectedTypeName",
-		message:    "Did not find the expected type name in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errParseExpectedWhenClause(err error) *s3Error {
 	return &s3Error{
 		code:       "ParseExpectedWhenClause",
@@ -316,15 +289,6 @@ func errParseUnsupportedAlias(err error) *s3Error {
 	}
 }
 
-func errParseInvalidPathComponent(err error) *s3Error {
-	return &s3Error{
-		code:       "ParseInvalidPathComponent",
-		message:    "The SQL expression contains an invalid path component.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errParseMissingIdentAfterAt(err error) *s3Error {
 	return &s3Error{
 		code:       "ParseMissingIdentAfterAt",
@@ -415,15 +379,6 @@ func errParseExpectedArgumentDelimiter(err error) *s3Error {
 	}
 }
 
-func errParseCastArity(err error) *s3Error {
-	return &s3Error{
-		code:       "ParseCastArity",
-		message:    "The SQL expression CAST has incorrect arity.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errParseEmptySelect(err error) *s3Error {
 	return &s3Error{
 		code:       "ParseEmptySelect",
@@ -501,15 +456,6 @@ func errParseCannotMixSqbAndWildcardInSelectList(err error) *s3Error {
 //                             CAST() related errors.
 //
 //////////////////////////////////////////////////////////////////////////////////////
-func errCastFailed(err error) *s3Error {
-	return &s3Error{
-		code:       "CastFailed",
-		message:    "Attempt to convert from one data type to another using CAST failed in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errInvalidCast(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidCast",
@@ -564,24 +510,6 @@ func errEvaluatorTimestampFormatPatternHourClockAmPmMismatch(err error) *s3Error
 	}
 }
 
-func errEvaluatorUnterminatedTimestampFormatPatternToken(err error) *s3Error {
-	return &s3Error{
-		code:       "EvaluatorUnterminatedTimestampFormatPatternToken",
-		message:    "Time stamp format pattern contains unterminated token in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
-func errEvaluatorInvalidTimestampFormatPatternToken(err error) *s3Error {
-	return &s3Error{
-		code:       "EvaluatorInvalidTimestampFormatPatternToken",
-		message:    "Time stamp format pattern contains an invalid token in the SQL expression.",
-		statusCode: 400,
-		cause:      err,
-	}
-}
-
 func errEvaluatorInvalidTimestampFormatPatternSymbol(err error) *s3Error {
 	return &s3Error{
 		code:       "EvaluatorInvalidTimestampFormatPatternSymbol",
@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Algorithm - server side encryption algorithm applied by default.
+type Algorithm string
+
+// Supported default encryption algorithms.
+const (
+	// AES256 - SSE-S3 with the default KMS key.
+	AES256 Algorithm = "AES256"
+
+	// AWSKms - SSE-S3 with a specific KMS key.
+	AWSKms Algorithm = "aws:kms"
+)
+
+// IsValid - returns true if the algorithm is supported.
+func (alg Algorithm) IsValid() bool {
+	switch alg {
+	case AES256, AWSKms:
+		return true
+	}
+	return false
+}
+
+var (
+	errInvalidRuleCount = errors.New("server side encryption configuration must have exactly one rule")
+	errInvalidAlgorithm = errors.New("invalid SSEAlgorithm, must be one of AES256 or aws:kms")
+	errUnexpectedKeyID  = errors.New("KMSMasterKeyID is only allowed with the aws:kms SSEAlgorithm")
+)
+
+// ApplySSEByDefault - represents <ApplyServerSideEncryptionByDefault>...</ApplyServerSideEncryptionByDefault>
+type ApplySSEByDefault struct {
+	SSEAlgorithm   Algorithm `xml:"SSEAlgorithm"`
+	KMSMasterKeyID string    `xml:"KMSMasterKeyID,omitempty"`
+}
+
+// Validate - validates the default encryption.
+func (a ApplySSEByDefault) Validate() error {
+	if !a.SSEAlgorithm.IsValid() {
+		return errInvalidAlgorithm
+	}
+
+	if a.SSEAlgorithm != AWSKms && a.KMSMasterKeyID != "" {
+		return errUnexpectedKeyID
+	}
+
+	return nil
+}
+
+// Rule - represents <Rule>...</Rule>
+type Rule struct {
+	XMLName                 xml.Name          `xml:"Rule"`
+	DefaultEncryptionAction ApplySSEByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// BucketSSEConfig - represents <ServerSideEncryptionConfiguration>...</ServerSideEncryptionConfiguration>
+type BucketSSEConfig struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the server side encryption configuration.
+func (config BucketSSEConfig) Validate() error {
+	if len(config.Rules) != 1 {
+		return errInvalidRuleCount
+	}
+
+	return config.Rules[0].DefaultEncryptionAction.Validate()
+}
+
+// Algorithm - returns the default encryption algorithm.
+func (config BucketSSEConfig) Algorithm() Algorithm {
+	if len(config.Rules) == 0 {
+		return ""
+	}
+	return config.Rules[0].DefaultEncryptionAction.SSEAlgorithm
+}
+
+// KeyID - returns the KMS key ID used to encrypt new objects, it is empty
+// if the default KMS key is used.
+func (config BucketSSEConfig) KeyID() string {
+	if len(config.Rules) == 0 {
+		return ""
+	}
+	return config.Rules[0].DefaultEncryptionAction.KMSMasterKeyID
+}
+
+// ParseBucketSSEConfig - parses data in given reader to BucketSSEConfig.
+func ParseBucketSSEConfig(reader io.Reader) (*BucketSSEConfig, error) {
+	var config BucketSSEConfig
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+)
+
+// Limits on object tags as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/object-tagging.html
+const (
+	maxTags        = 10
+	maxTagKeyLen   = 128
+	maxTagValueLen = 256
+)
+
+var (
+	errTooManyTags       = errors.New("object tags cannot be greater than 10")
+	errInvalidTagKey     = errors.New("the tag key is either empty or longer than 128 characters")
+	errInvalidTagValue   = errors.New("the tag value is longer than 256 characters")
+	errDuplicateTagKey   = errors.New("cannot provide multiple tags with the same key")
+	errInvalidTagsHeader = errors.New("the tag header is not a valid URL encoded query string")
+)
+
+// Tag - represents <Tag>...</Tag>
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// Validate - validates the tag key and value.
+func (tag Tag) Validate() error {
+	if tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxTagKeyLen {
+		return errInvalidTagKey
+	}
+
+	if utf8.RuneCountInString(tag.Value) > maxTagValueLen {
+		return errInvalidTagValue
+	}
+
+	return nil
+}
+
+// TagSet - represents <TagSet>...</TagSet>
+type TagSet struct {
+	Tags []Tag `xml:"Tag"`
+}
+
+// Tagging - represents <Tagging>...</Tagging>
+type Tagging struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  TagSet   `xml:"TagSet"`
+}
+
+// Validate - validates the object tags.
+func (t Tagging) Validate() error {
+	if len(t.TagSet.Tags) > maxTags {
+		return errTooManyTags
+	}
+
+	keys := make(map[string]struct{}, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return errDuplicateTagKey
+		}
+		keys[tag.Key] = struct{}{}
+	}
+
+	return nil
+}
+
+// ToMap - returns the object tags as a map of tag key to tag value.
+func (t Tagging) ToMap() map[string]string {
+	tags := make(map[string]string, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
+
+// String - returns the object tags URL encoded as in x-amz-tagging header.
+func (t Tagging) String() string {
+	values := url.Values{}
+	for _, tag := range t.TagSet.Tags {
+		values.Set(tag.Key, tag.Value)
+	}
+	return values.Encode()
+}
+
+// ParseTagging - parses data in given reader to Tagging.
+func ParseTagging(reader io.Reader) (*Tagging, error) {
+	var t Tagging
+	if err := xml.NewDecoder(reader).Decode(&t); err != nil {
+		return nil, err
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ParseTags - parses URL encoded object tags as sent in x-amz-tagging
+// header, i.e. "key1=value1&key2=value2", to Tagging.
+func ParseTags(s string) (*Tagging, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, errInvalidTagsHeader
+	}
+
+	var t Tagging
+	for key, vals := range values {
+		if len(vals) > 1 {
+			return nil, errDuplicateTagKey
+		}
+		t.TagSet.Tags = append(t.TagSet.Tags, Tag{Key: key, Value: vals[0]})
+	}
+	sort.Slice(t.TagSet.Tags, func(i, j int) bool {
+		return t.TagSet.Tags[i].Key < t.TagSet.Tags[j].Key
+	})
+
+	if err = t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTagging(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedResult map[string]string
+		expectErr      bool
+	}{
+		{`<Tagging><TagSet><Tag><Key>pii</Key><Value>true</Value></Tag><Tag><Key>retention</Key><Value>short</Value></Tag></TagSet></Tagging>`,
+			map[string]string{"pii": "true", "retention": "short"}, false},
+		{`<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet></TagSet></Tagging>`, map[string]string{}, false},
+		{`<Tagging><TagSet><Tag><Key>pii</Key><Value></Value></Tag></TagSet></Tagging>`, map[string]string{"pii": ""}, false},
+		{`<Tagging><TagSet><Tag><Key></Key><Value>true</Value></Tag></TagSet></Tagging>`, nil, true},
+		{`<Tagging><TagSet><Tag><Key>pii</Key><Value>true</Value></Tag><Tag><Key>pii</Key><Value>false</Value></Tag></TagSet></Tagging>`, nil, true},
+		{`<Tagging><TagSet><Tag><Key>` + strings.Repeat("k", 129) + `</Key><Value>true</Value></Tag></TagSet></Tagging>`, nil, true},
+		{`<Tagging><TagSet><Tag><Key>pii</Key><Value>` + strings.Repeat("v", 257) + `</Value></Tag></TagSet></Tagging>`, nil, true},
+		{`<Tagging><TagSet>` + strings.Repeat(`<Tag><Key>pii</Key><Value>true</Value></Tag>`, 11) + `</TagSet></Tagging>`, nil, true},
+		{`<TagSet><Tag><Key>pii</Key><Value>true</Value></Tag></TagSet>`, nil, true},
+		{`<Tagging>`, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseTagging(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if result := tags.ToMap(); !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		tags           string
+		expectedResult string
+		expectErr      bool
+	}{
+		{"retention=short&pii=true", "pii=true&retention=short", false},
+		{"project=a%20b", "project=a+b", false},
+		{"pii=", "pii=", false},
+		{"", "", false},
+		{"pii=true&pii=false", "", true},
+		{"=true", "", true},
+		{"pii=%zz", "", true},
+		{strings.Repeat("k", 129) + "=v", "", true},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseTags(testCase.tags)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if result := tags.String(); result != testCase.expectedResult {
+				t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
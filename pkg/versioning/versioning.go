@@ -0,0 +1,102 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// State - versioning state of a bucket.
+type State string
+
+// Supported versioning states.
+const (
+	// Enabled - every write creates a new version with a unique version ID.
+	Enabled State = "Enabled"
+
+	// Suspended - writes create a version with the "null" version ID,
+	// existing versions are retained.
+	Suspended State = "Suspended"
+)
+
+// MFADelete - MFA delete state of a bucket.
+type MFADelete string
+
+// Supported MFA delete states.
+const (
+	MFADeleteEnabled  MFADelete = "Enabled"
+	MFADeleteDisabled MFADelete = "Disabled"
+)
+
+var (
+	errInvalidState     = errors.New("invalid versioning status, must be one of Enabled or Suspended")
+	errMFADeleteEnabled = errors.New("MFA delete is not supported")
+	errInvalidMFADelete = errors.New("invalid MFA delete status, must be one of Enabled or Disabled")
+)
+
+// Versioning - represents <VersioningConfiguration>...</VersioningConfiguration>
+type Versioning struct {
+	XMLNS     string    `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name  `xml:"VersioningConfiguration"`
+	Status    State     `xml:"Status,omitempty"`
+	MFADelete MFADelete `xml:"MfaDelete,omitempty"`
+}
+
+// Validate - validates the versioning configuration.
+func (v Versioning) Validate() error {
+	switch v.Status {
+	case Enabled, Suspended:
+	default:
+		return errInvalidState
+	}
+
+	switch v.MFADelete {
+	case "", MFADeleteDisabled:
+	case MFADeleteEnabled:
+		return errMFADeleteEnabled
+	default:
+		return errInvalidMFADelete
+	}
+
+	return nil
+}
+
+// Enabled - returns true if versioning is enabled.
+func (v Versioning) Enabled() bool {
+	return v.Status == Enabled
+}
+
+// Suspended - returns true if versioning is suspended.
+func (v Versioning) Suspended() bool {
+	return v.Status == Suspended
+}
+
+// ParseConfig - parses data in given reader to Versioning.
+func ParseConfig(reader io.Reader) (*Versioning, error) {
+	var v Versioning
+	if err := xml.NewDecoder(reader).Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}